@@ -0,0 +1,80 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+Sigmoid replaces every element x of the mat with the logistic function
+1/(1+e**-x), in place. The computation never overflows, even for large
+negative values of x.
+*/
+func (m *Matf64) Sigmoid() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		if v >= 0 {
+			m.vals[i] = 1 / (1 + math.Exp(-v))
+			continue
+		}
+		e := math.Exp(v)
+		m.vals[i] = e / (1 + e)
+	}
+	return m
+}
+
+/*
+ReLU replaces every negative element of the mat with zero, in place, leaving
+the others untouched.
+*/
+func (m *Matf64) ReLU() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		if v < 0 {
+			m.vals[i] = 0
+		}
+	}
+	return m
+}
+
+/*
+LeakyReLU multiplies every negative element of the mat by alpha, in place,
+leaving the others untouched. alpha is typically a small value such as 0.01.
+*/
+func (m *Matf64) LeakyReLU(alpha float64) *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		if v < 0 {
+			m.vals[i] = alpha * v
+		}
+	}
+	return m
+}
+
+/*
+Softmax replaces each row of the mat with its softmax, in place, so that the
+elements of every row are positive and sum to 1. Each row is treated as the
+scores of one sample, in the usual layout of one sample per row. The maximum
+of each row is subtracted before exponentiation, which does not change the
+result but keeps large scores from overflowing.
+*/
+func (m *Matf64) Softmax() *Matf64 {
+	m.materialize()
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		max := math.Inf(-1)
+		for _, v := range row {
+			if v > max {
+				max = v
+			}
+		}
+		sum := 0.0
+		for j, v := range row {
+			row[j] = math.Exp(v - max)
+			sum += row[j]
+		}
+		for j := range row {
+			row[j] /= sum
+		}
+	}
+	return m
+}
@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigmoidf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{0, 2, -2, -1000, 1000}).Sigmoid()
+	assert.Equal(t, 0.5, m.vals[0], "should be equal")
+	assert.InDelta(t, 1/(1+math.Exp(-2)), m.vals[1], 1e-15, "should be equal")
+	assert.InDelta(t, 1-m.vals[1], m.vals[2], 1e-15, "should be symmetric")
+	assert.Equal(t, 0.0, m.vals[3], "should not overflow")
+	assert.Equal(t, 1.0, m.vals[4], "should not overflow")
+}
+
+func TestReLUf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{-2, 0, 3})
+	assert.Equal(t, []float64{-0.2, 0, 3}, m.Copy().LeakyReLU(0.1).vals, "should be equal")
+	assert.Equal(t, []float64{0, 0, 3}, m.ReLU().vals, "should be equal")
+}
+
+func TestSoftmaxf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {1000, 1000, 1000}, {-1, 0, 1e3}})
+	m.Softmax()
+	e := math.Exp(1) + math.Exp(2) + math.Exp(3)
+	assert.InDelta(t, math.Exp(1)/e, m.Get(0, 0), 1e-15, "should be equal")
+	assert.InDelta(t, math.Exp(3)/e, m.Get(0, 2), 1e-15, "should be equal")
+	for j := 0; j < 3; j++ {
+		assert.InDelta(t, 1.0/3, m.Get(1, j), 1e-15, "should not overflow")
+	}
+	assert.Equal(t, 1.0, m.Get(2, 2), "should be equal")
+	for i := 0; i < 3; i++ {
+		assert.InDelta(t, 1.0, m.Sum(0, i), 1e-15, "rows should sum to 1")
+	}
+}
@@ -0,0 +1,117 @@
+package matrix
+
+/*
+ArgMaxRows returns, for each row of the mat, the column index of its largest
+element. With one sample per row and one score per class, this is the
+predicted class of every sample. As with Max, the first index is returned
+when several elements are the largest.
+*/
+func (m *Matf64) ArgMaxRows() []int {
+	idx, _ := m.argRows(func(a, b float64) bool { return a > b })
+	return idx
+}
+
+/*
+ArgMinRows returns, for each row of the mat, the column index of its
+smallest element (see ArgMaxRows).
+*/
+func (m *Matf64) ArgMinRows() []int {
+	idx, _ := m.argRows(func(a, b float64) bool { return a < b })
+	return idx
+}
+
+/*
+ArgMaxCols returns, for each column of the mat, the row index of its largest
+element. As with Max, the first index is returned when several elements are
+the largest.
+*/
+func (m *Matf64) ArgMaxCols() []int {
+	idx, _ := m.argCols(func(a, b float64) bool { return a > b })
+	return idx
+}
+
+/*
+ArgMinCols returns, for each column of the mat, the row index of its
+smallest element (see ArgMaxCols).
+*/
+func (m *Matf64) ArgMinCols() []int {
+	idx, _ := m.argCols(func(a, b float64) bool { return a < b })
+	return idx
+}
+
+/*
+MaxRows returns a column vector holding the largest element of each row of
+the mat.
+*/
+func (m *Matf64) MaxRows() *Matf64 {
+	_, v := m.argRows(func(a, b float64) bool { return a > b })
+	return v
+}
+
+/*
+MinRows returns a column vector holding the smallest element of each row of
+the mat.
+*/
+func (m *Matf64) MinRows() *Matf64 {
+	_, v := m.argRows(func(a, b float64) bool { return a < b })
+	return v
+}
+
+/*
+MaxCols returns a row vector holding the largest element of each column of
+the mat.
+*/
+func (m *Matf64) MaxCols() *Matf64 {
+	_, v := m.argCols(func(a, b float64) bool { return a > b })
+	return v
+}
+
+/*
+MinCols returns a row vector holding the smallest element of each column of
+the mat.
+*/
+func (m *Matf64) MinCols() *Matf64 {
+	_, v := m.argCols(func(a, b float64) bool { return a < b })
+	return v
+}
+
+// argRows returns the index and the value of the best element of each row,
+// where better(a, b) reports whether a is strictly better than b.
+func (m *Matf64) argRows(better func(a, b float64) bool) ([]int, *Matf64) {
+	idx := make([]int, m.r)
+	v := Newf64(m.r, 1)
+	if m.c == 0 {
+		return idx, v
+	}
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		best := row[0]
+		for j := 1; j < m.c; j++ {
+			if better(row[j], best) {
+				best = row[j]
+				idx[i] = j
+			}
+		}
+		v.vals[i] = best
+	}
+	return idx, v
+}
+
+// argCols is like argRows, for the columns of the mat.
+func (m *Matf64) argCols(better func(a, b float64) bool) ([]int, *Matf64) {
+	idx := make([]int, m.c)
+	v := Newf64(1, m.c)
+	if m.r == 0 {
+		return idx, v
+	}
+	copy(v.vals, m.vals[:m.c])
+	for i := 1; i < m.r; i++ {
+		for j, x := range m.vals[i*m.c : (i+1)*m.c] {
+			if better(x, v.vals[j]) {
+				v.vals[j] = x
+				idx[j] = i
+			}
+		}
+	}
+	return idx, v
+}
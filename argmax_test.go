@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgMaxf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{0.1, 0.7, 0.2},
+		{0.5, 0.1, 0.5},
+		{-1, -3, -2},
+	})
+	assert.Equal(t, []int{1, 0, 0}, m.ArgMaxRows(), "should be equal")
+	assert.Equal(t, []int{0, 1, 1}, m.ArgMinRows(), "should be equal")
+	assert.Equal(t, []int{1, 0, 1}, m.ArgMaxCols(), "should be equal")
+	assert.Equal(t, []int{2, 2, 2}, m.ArgMinCols(), "should be equal")
+
+	v := m.MaxRows()
+	assert.Equal(t, 3, v.r, "should be a column vector")
+	assert.Equal(t, []float64{0.7, 0.5, -1}, v.vals, "should be equal")
+	assert.Equal(t, []float64{0.1, 0.1, -3}, m.MinRows().vals, "should be equal")
+	v = m.MaxCols()
+	assert.Equal(t, 1, v.r, "should be a row vector")
+	assert.Equal(t, []float64{0.5, 0.7, 0.5}, v.vals, "should be equal")
+	assert.Equal(t, []float64{-1, -3, -2}, m.MinCols().vals, "should be equal")
+}
@@ -0,0 +1,156 @@
+//go:build arrow
+// +build arrow
+
+package arrow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/gocrunch/matrix"
+)
+
+/*
+ToRecord converts m to an Arrow record batch with one Float64 column per
+column of m. names holds the names of the columns, and must either be nil,
+in which case the columns are named "c0", "c1", ..., or contain one name per
+column. The caller is responsible for releasing the returned record.
+*/
+func ToRecord(m *matrix.Matf64, names []string) (arrow.Record, error) {
+	r, c := m.Shape()
+	names, err := columnNames(names, c)
+	if err != nil {
+		return nil, err
+	}
+	mem := memory.NewGoAllocator()
+	fields := make([]arrow.Field, c)
+	cols := make([]arrow.Array, c)
+	b := array.NewFloat64Builder(mem)
+	defer b.Release()
+	for j := 0; j < c; j++ {
+		fields[j] = arrow.Field{Name: names[j], Type: arrow.PrimitiveTypes.Float64}
+		b.Reserve(r)
+		for i := 0; i < r; i++ {
+			b.Append(m.Get(i, j))
+		}
+		cols[j] = b.NewFloat64Array()
+		defer cols[j].Release()
+	}
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(r)), nil
+}
+
+/*
+FromRecord converts an Arrow record batch to a mat, and returns it along with
+the names of its columns. Every column must be of a floating point or integer
+type, and null values are converted to NaN.
+*/
+func FromRecord(rec arrow.Record) (*matrix.Matf64, []string, error) {
+	r, c := int(rec.NumRows()), int(rec.NumCols())
+	m := matrix.Newf64(r, c)
+	names := make([]string, c)
+	for j := 0; j < c; j++ {
+		names[j] = rec.Schema().Field(j).Name
+		if err := setColumn(m, j, 0, rec.Column(j)); err != nil {
+			return nil, nil, fmt.Errorf("arrow: column %s: %v", names[j], err)
+		}
+	}
+	return m, names, nil
+}
+
+/*
+WriteParquet writes m to w as a Parquet file with one double column per
+column of m. names follows the same rules as in ToRecord.
+*/
+func WriteParquet(w io.Writer, m *matrix.Matf64, names []string) error {
+	rec, err := ToRecord(m, names)
+	if err != nil {
+		return err
+	}
+	defer rec.Release()
+	tbl := array.NewTableFromRecords(rec.Schema(), []arrow.Record{rec})
+	defer tbl.Release()
+	r, _ := m.Shape()
+	chunk := int64(r)
+	if chunk == 0 {
+		chunk = 1
+	}
+	return pqarrow.WriteTable(tbl, w, chunk, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps())
+}
+
+/*
+ReadParquet reads a Parquet file into a mat, and returns it along with the
+names of its columns. Every column must be of a floating point or integer
+type, and null values are converted to NaN.
+*/
+func ReadParquet(r parquet.ReaderAtSeeker) (*matrix.Matf64, []string, error) {
+	tbl, err := pqarrow.ReadTable(context.Background(), r, nil, pqarrow.ArrowReadProperties{}, memory.NewGoAllocator())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tbl.Release()
+	rows, c := int(tbl.NumRows()), int(tbl.NumCols())
+	m := matrix.Newf64(rows, c)
+	names := make([]string, c)
+	for j := 0; j < c; j++ {
+		col := tbl.Column(j)
+		names[j] = col.Name()
+		offset := 0
+		for _, chunk := range col.Data().Chunks() {
+			if err := setColumn(m, j, offset, chunk); err != nil {
+				return nil, nil, fmt.Errorf("arrow: column %s: %v", names[j], err)
+			}
+			offset += chunk.Len()
+		}
+	}
+	return m, names, nil
+}
+
+// setColumn copies the values of a into column j of m, starting at row
+// offset.
+func setColumn(m *matrix.Matf64, j, offset int, a arrow.Array) error {
+	var at func(int) float64
+	switch v := a.(type) {
+	case *array.Float64:
+		at = func(i int) float64 { return v.Value(i) }
+	case *array.Float32:
+		at = func(i int) float64 { return float64(v.Value(i)) }
+	case *array.Int64:
+		at = func(i int) float64 { return float64(v.Value(i)) }
+	case *array.Int32:
+		at = func(i int) float64 { return float64(v.Value(i)) }
+	case *array.Uint64:
+		at = func(i int) float64 { return float64(v.Value(i)) }
+	case *array.Uint32:
+		at = func(i int) float64 { return float64(v.Value(i)) }
+	default:
+		return fmt.Errorf("unsupported type %s", a.DataType())
+	}
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) {
+			m.Set(offset+i, j, math.NaN())
+			continue
+		}
+		m.Set(offset+i, j, at(i))
+	}
+	return nil
+}
+
+func columnNames(names []string, c int) ([]string, error) {
+	if names == nil {
+		names = make([]string, c)
+		for j := range names {
+			names[j] = fmt.Sprintf("c%d", j)
+		}
+	}
+	if len(names) != c {
+		return nil, fmt.Errorf("arrow: got %d names for %d columns", len(names), c)
+	}
+	return names, nil
+}
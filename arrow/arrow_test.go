@@ -0,0 +1,35 @@
+//go:build arrow
+// +build arrow
+
+package arrow
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gocrunch/matrix"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	t.Helper()
+	m := matrix.RandMatf64(11, 3)
+	rec, err := ToRecord(m, []string{"x", "y", "z"})
+	assert.Nil(t, err, "should not fail")
+	defer rec.Release()
+	n, names, err := FromRecord(rec)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []string{"x", "y", "z"}, names, "should be equal")
+	assert.True(t, m.Equals(n), "should be equal")
+}
+
+func TestParquet(t *testing.T) {
+	t.Helper()
+	m := matrix.RandMatf64(25, 4)
+	var b bytes.Buffer
+	assert.Nil(t, WriteParquet(&b, m, nil), "should not fail")
+	n, names, err := ReadParquet(bytes.NewReader(b.Bytes()))
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []string{"c0", "c1", "c2", "c3"}, names, "should be equal")
+	assert.True(t, m.Equals(n), "should be equal")
+}
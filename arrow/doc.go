@@ -0,0 +1,15 @@
+/*
+Package arrow converts matrix.Matf64 objects to and from Apache Arrow record
+batches, and reads and writes them as Parquet files, so that matrices can be
+exchanged with data-engineering tools. Each column of a mat becomes a named
+Float64 column.
+
+This package depends on github.com/apache/arrow/go, which is large, so its
+functions are only compiled with the arrow build tag:
+
+	go get github.com/apache/arrow/go/v14
+	go build -tags arrow
+
+Without the tag, this package is empty.
+*/
+package arrow
@@ -0,0 +1,153 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Balance scales the rows and the columns of the mat to make their norms
+comparable, which reduces the rounding errors of algorithms working on
+badly scaled data. It returns the row and column scaling factors r and c,
+and the scaled mat b = diag(r) * m * diag(c). All the factors are powers of
+2, so the scaling itself is exact.
+
+If similar is false, the mat is equilibrated: rows and columns are scaled
+alternately until the largest absolute value of every nonzero row and
+column is between 1/2 and 2. This is the scaling to use before solving
+m * x = y, which becomes b * z = r * y, with x = c * z elementwise.
+
+If similar is true, the mat must be square, and it is balanced as in LAPACK:
+c is 1/r, so b = diag(r) * m * diag(r)⁻¹ has the same eigenvalues as m, and
+the norms of each row and of the matching column outside of the diagonal
+are made nearly equal. This is the scaling to use before computing
+eigenvalues, and the eigenvectors of m are the eigenvectors of b multiplied
+by c elementwise.
+
+Rows and columns holding infinities or NaNs are left unscaled. The mat is
+left intact.
+*/
+func (m *Matf64) Balance(similar bool) (r, c []float64, b *Matf64) {
+	b = m.Copy()
+	r, c = make([]float64, m.r), make([]float64, m.c)
+	for i := range r {
+		r[i] = 1
+	}
+	for j := range c {
+		c[j] = 1
+	}
+	if similar {
+		if m.r != m.c {
+			s := "\nIn %s, the mat must be square to be balanced by a\n"
+			s += "similarity, but it is %dX%d.\n"
+			s = fmt.Sprintf(s, "Balance()", m.r, m.c)
+			printErr(s)
+		}
+		b.balanceSimilar(r, c)
+	} else {
+		b.equilibrate(r, c)
+	}
+	return r, c, b
+}
+
+// equilibrate scales the rows and the columns of m in place, accumulating the
+// factors in r and c, until their largest absolute values are within a factor
+// of 2 of 1.
+func (m *Matf64) equilibrate(r, c []float64) {
+	// Each pass halves the logarithm of the norms, so 64 passes bring any
+	// finite float64 within range.
+	for pass := 0; pass < 64; pass++ {
+		changed := false
+		for i := 0; i < m.r; i++ {
+			max := 0.0
+			for _, x := range m.vals[i*m.c : (i+1)*m.c] {
+				max = math.Max(max, math.Abs(x))
+			}
+			if f := pow2Scale(max); f != 1 {
+				changed = true
+				r[i] *= f
+				for j := 0; j < m.c; j++ {
+					m.vals[i*m.c+j] *= f
+				}
+			}
+		}
+		for j := 0; j < m.c; j++ {
+			max := 0.0
+			for i := 0; i < m.r; i++ {
+				max = math.Max(max, math.Abs(m.vals[i*m.c+j]))
+			}
+			if f := pow2Scale(max); f != 1 {
+				changed = true
+				c[j] *= f
+				for i := 0; i < m.r; i++ {
+					m.vals[i*m.c+j] *= f
+				}
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+// pow2Scale returns a power of 2 close to 1/sqrt(x), or 1 if x is 0, not
+// finite, or already between 1/2 and 2.
+func pow2Scale(x float64) float64 {
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) || (x >= 0.5 && x <= 2) {
+		return 1
+	}
+	_, e := math.Frexp(x)
+	k := -e / 2
+	if k == 0 {
+		// x is between 1/4 and 1/2, or between 2 and 4.
+		k = 1
+		if x > 2 {
+			k = -1
+		}
+	}
+	return math.Ldexp(1, k)
+}
+
+// balanceSimilar balances the square mat m in place by a diagonal similarity,
+// with the iteration of Parlett and Reinsch used by LAPACK's gebal, storing
+// the row factors in r and the column factors in c.
+func (m *Matf64) balanceSimilar(r, c []float64) {
+	n := m.r
+	for done := false; !done; {
+		done = true
+		for i := 0; i < n; i++ {
+			var cn, rn float64
+			for j := 0; j < n; j++ {
+				if j != i {
+					cn += math.Abs(m.vals[j*n+i])
+					rn += math.Abs(m.vals[i*n+j])
+				}
+			}
+			// Rows and columns holding infinities or NaNs cannot be
+			// balanced, and would keep the loops below from ending.
+			if cn == 0 || rn == 0 || math.IsInf(cn+rn, 0) || math.IsNaN(cn+rn) {
+				continue
+			}
+			s := cn + rn
+			f := 1.0
+			for g := rn / 2; cn < g; {
+				f *= 2
+				cn *= 4
+			}
+			for g := rn * 2; cn >= g; {
+				f /= 2
+				cn /= 4
+			}
+			if (cn+rn)/f >= 0.95*s {
+				continue
+			}
+			done = false
+			c[i] *= f
+			r[i] /= f
+			for j := 0; j < n; j++ {
+				m.vals[i*n+j] /= f
+				m.vals[j*n+i] *= f
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalancef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1e6, 2e3, 0}, {3e-2, 1e-5, 4e-4}, {5e4, 0, 7e2}, {0, 0, 0}})
+	orig := m.Copy()
+	r, c, b := m.Balance(false)
+	assert.True(t, orig.Equals(m), "should be left intact")
+	assert.Equal(t, Diagf64(r).Dot(m).Dot(Diagf64(c)).vals, b.vals, "should be exact")
+	for i := 0; i < 3; i++ {
+		_, max := b.Copy().Abs().Max(0, i)
+		assert.True(t, max >= 0.5 && max <= 2, "row should be equilibrated")
+	}
+	for j := 0; j < 3; j++ {
+		_, max := b.Copy().Abs().Max(1, j)
+		assert.True(t, max >= 0.5 && max <= 2, "column should be equilibrated")
+	}
+	assert.Equal(t, 1.0, r[3], "zero rows should not be scaled")
+
+	m = Matf64FromData([][]float64{{1, 1e4, 0}, {1e-4, 2, 1e3}, {0, 1e-3, 3}})
+	r, c, b = m.Balance(true)
+	for i := range r {
+		assert.Equal(t, 1.0, r[i]*c[i], "should be a similarity")
+		assert.Equal(t, m.Get(i, i), b.Get(i, i), "should keep the diagonal")
+	}
+	assert.Equal(t, Diagf64(r).Dot(m).Dot(Diagf64(c)).vals, b.vals, "should be exact")
+	assert.True(t, b.norm1() < m.norm1()/100, "should reduce the norm")
+	_, max := b.Copy().Abs().Max()
+	assert.True(t, max < 10, "should be balanced")
+	assert.False(t, math.IsNaN(b.Sum()), "should be finite")
+}
+
+func TestBalanceNonFinite(t *testing.T) {
+	t.Helper()
+	for _, bad := range []float64{math.Inf(1), math.NaN()} {
+		m := Matf64FromData([][]float64{{1, bad, 0}, {2, 3, 0}, {0, 1e6, 4}})
+		r, c, _ := m.Balance(true)
+		assert.Equal(t, []float64{1, 1, 1}, r, "should leave the rows unscaled")
+		assert.Equal(t, []float64{1, 1, 1}, c, "should leave the columns unscaled")
+		r, _, _ = Matf64FromData([][]float64{{1, bad}, {2, 3}}).Balance(false)
+		assert.Equal(t, 2, len(r), "should return")
+	}
+}
@@ -0,0 +1,78 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinaryf64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(123, 45, -10.0, 10.0)
+	m.Set(3, 4, math.Inf(-1))
+	var b bytes.Buffer
+	m.WriteBinary(&b)
+	assert.Equal(t, binaryHeaderSize+8*123*45, b.Len(), "should be equal")
+	assert.Equal(t, "GCMT", string(b.Bytes()[:4]), "should start with the magic number")
+	n := Newf64().ReadBinary(&b)
+	assert.True(t, m.Equals(n), "should be equal")
+	assert.Equal(t, 0, b.Len(), "should consume the whole stream")
+
+	m = Newf64()
+	m.WriteBinary(&b)
+	n = Newf64(3).ReadBinary(&b)
+	r, c := n.Shape()
+	assert.Equal(t, 0, r, "should be empty")
+	assert.Equal(t, 0, c, "should be empty")
+}
+
+func TestBinaryf32(t *testing.T) {
+	t.Helper()
+	m := RandMatf32(17, 5)
+	var b bytes.Buffer
+	m.WriteBinary(&b)
+	assert.Equal(t, binaryHeaderSize+4*17*5, b.Len(), "should be equal")
+	n := Newf64().ReadBinary(&b)
+	for i := range m.vals {
+		assert.Equal(t, float64(m.vals[i]), n.vals[i], "should be converted")
+	}
+	n.WriteBinary(&b)
+	o := Newf32().ReadBinary(&b)
+	assert.True(t, m.Equals(o), "should be equal")
+}
+
+func TestBinaryBigEndian(t *testing.T) {
+	t.Helper()
+	h := []byte("GCMT\x01\x01\x01\x00")
+	h = append(h, make([]byte, 16)...)
+	binary.BigEndian.PutUint64(h[8:], 1)
+	binary.BigEndian.PutUint64(h[16:], 2)
+	for _, v := range []float64{1.5, -2.0} {
+		var u [8]byte
+		binary.BigEndian.PutUint64(u[:], math.Float64bits(v))
+		h = append(h, u[:]...)
+	}
+	m := Newf64().ReadBinary(bytes.NewReader(h))
+	assert.True(t, m.Equals(Matf64FromData([]float64{1.5, -2.0})), "should be equal")
+}
+
+func TestBinaryForgedShape(t *testing.T) {
+	t.Helper()
+	// A header claiming 2^28X2^28 values followed by a single value must fail
+	// on the missing data, without allocating for the claimed shape first.
+	h := []byte("GCMT\x01\x01\x00\x00")
+	h = append(h, make([]byte, 24)...)
+	binary.LittleEndian.PutUint64(h[8:], 1<<28)
+	binary.LittleEndian.PutUint64(h[16:], 1<<28)
+	_, vals, err := readBinaryf64(bytes.NewReader(h))
+	assert.Equal(t, io.ErrUnexpectedEOF, err, "should be equal")
+	assert.True(t, cap(vals) <= binaryChunk, "should not allocate for the header")
+
+	binary.LittleEndian.PutUint64(h[8:], 1<<31)
+	_, err = readBinaryHeader(bytes.NewReader(h))
+	assert.EqualError(t, err, "invalid shape 2147483648x268435456")
+}
@@ -0,0 +1,94 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+Blocks walks over the mat in non-overlapping blocks of br rows and bc
+columns, calling f with the position of each block in the grid of blocks,
+and the block itself. Blocks are visited row by row, and when the number of
+rows or columns is not a multiple of the block size, the blocks along the
+bottom and right edges are smaller. For example, the sum of every 2X2 block
+of a 4X6 mat can be collected as follows:
+
+	sums := Newf64(2, 3)
+	m.Blocks(2, 2, func(i, j int, b *Matf64) {
+		sums.Set(i, j, b.Sum())
+	})
+
+The block behaves as a view: once f returns, any change made to it is
+written back to m, so block algorithms can update m in place. f must not
+change the shape of the block, and must not modify m directly while it
+runs. br and bc must be at least 1.
+*/
+func (m *Matf64) Blocks(br, bc int, f func(i, j int, b *Matf64)) *Matf64 {
+	if br < 1 || bc < 1 {
+		s := "\nIn %s, the block size must be at least 1X1, but %dX%d was "
+		s += "received.\n"
+		s = fmt.Sprintf(s, "Blocks()", br, bc)
+		printErr(s)
+	}
+	m.materialize()
+	for r0, i := 0, 0; r0 < m.r; r0, i = r0+br, i+1 {
+		h := br
+		if r0+h > m.r {
+			h = m.r - r0
+		}
+		for c0, j := 0, 0; c0 < m.c; c0, j = c0+bc, j+1 {
+			w := bc
+			if c0+w > m.c {
+				w = m.c - c0
+			}
+			b := Newf64(h, w)
+			for k := 0; k < h; k++ {
+				copy(b.vals[k*w:(k+1)*w], m.vals[(r0+k)*m.c+c0:])
+			}
+			f(i, j, b)
+			if b.r != h || b.c != w {
+				s := "\nIn %s, the block at (%d, %d) was reshaped from %dX%d "
+				s += "to %dX%d.\n"
+				s = fmt.Sprintf(s, "Blocks()", i, j, h, w, b.r, b.c)
+				printErr(s)
+			}
+			for k := 0; k < h; k++ {
+				copy(m.vals[(r0+k)*m.c+c0:(r0+k)*m.c+c0+w], b.vals[k*w:(k+1)*w])
+			}
+		}
+	}
+	return m
+}
+
+/*
+SetSubMatrix copies n into the mat, in place, so that the element of n at row
+i and column j lands at row r0+i and column c0+j of m. It is the natural way
+to assemble block systems, for example:
+
+	k := matrix.Newf64(4, 4)
+	k.SetSubMatrix(0, 0, a).SetSubMatrix(0, 2, b)
+	k.SetSubMatrix(2, 0, b.Copy().T()).SetSubMatrix(2, 2, d)
+
+As with Row and Col, negative offsets count from the end, so
+m.SetSubMatrix(-1, 0, v) writes the row vector v over the last row of m. n
+must fit entirely within m at the given offset, and cannot be m itself.
+*/
+func (m *Matf64) SetSubMatrix(r0, c0 int, n *Matf64) *Matf64 {
+	r0 = normIndex("SetSubMatrix()", "row", r0, m.r)
+	c0 = normIndex("SetSubMatrix()", "column", c0, m.c)
+	if r0+n.r > m.r || c0+n.c > m.c {
+		s := "\nIn %s, a %dX%d mat at offset (%d, %d) does not fit within the\n"
+		s += "%dX%d receiver.\n"
+		s = fmt.Sprintf(s, "SetSubMatrix()", n.r, n.c, r0, c0, m.r, m.c)
+		printErr(s)
+	}
+	if n == m {
+		s := "\nIn %s, the passed mat cannot be the receiver.\n"
+		s = fmt.Sprintf(s, "SetSubMatrix()")
+		printErr(s)
+	}
+	m.materialize()
+	for i := 0; i < n.r; i++ {
+		copy(m.vals[(r0+i)*m.c+c0:(r0+i)*m.c+c0+n.c], n.vals[i*n.c:(i+1)*n.c])
+	}
+	return m
+}
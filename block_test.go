@@ -0,0 +1,58 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlocksf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 2, 3, 4, 5},
+		{6, 7, 8, 9, 10},
+		{11, 12, 13, 14, 15},
+	})
+	sums := Newf64(2, 3)
+	m.Blocks(2, 2, func(i, j int, b *Matf64) {
+		sums.Set(i, j, b.Sum())
+	})
+	want := Matf64FromData([][]float64{{16, 24, 15}, {23, 27, 15}})
+	assert.True(t, want.Equals(sums), "should be equal")
+
+	m.Blocks(2, 3, func(i, j int, b *Matf64) {
+		if i == j {
+			b.SetAll(0)
+		}
+	})
+	want = Matf64FromData([][]float64{
+		{0, 0, 0, 4, 5},
+		{0, 0, 0, 9, 10},
+		{11, 12, 13, 0, 0},
+	})
+	assert.True(t, want.Equals(m), "should be written back")
+}
+
+func TestBlocksCOWf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	n := m.CloneCOW()
+	n.Blocks(1, 1, func(i, j int, b *Matf64) {
+		b.Mul(2.0)
+	})
+	assert.Equal(t, []float64{1, 2, 3, 4}, m.vals, "should be left intact")
+	assert.Equal(t, []float64{2, 4, 6, 8}, n.vals, "should be equal")
+}
+
+func TestSetSubMatrixf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	m.SetSubMatrix(1, 1, Matf64FromData([][]float64{{1, 2}, {3, 4}}))
+	m.SetSubMatrix(0, -1, Matf64FromData([]float64{5}))
+	want := Matf64FromData([][]float64{
+		{0, 0, 0, 5},
+		{0, 1, 2, 0},
+		{0, 3, 4, 0},
+	})
+	assert.True(t, want.Equals(m), "should be equal")
+}
@@ -0,0 +1,82 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+Matf64Builder builds a Matf64 one row at a time, for instance while reading
+records from a stream whose length is not known in advance:
+
+	var b matrix.Matf64Builder
+	for dec.More() {
+		b.AddRow(readRecord(dec))
+	}
+	m := b.Build()
+
+AppendRow only makes room for a couple more rows when the capacity of a mat
+is exceeded, so that every value is copied again every other row. The
+builder instead grows its buffer geometrically, so that adding n rows copies
+each value a constant number of times on average. The
+number of columns is set by the first row, and every other row must have the
+same length. The zero value is an empty builder ready to use.
+*/
+type Matf64Builder struct {
+	r, c int
+	vals []float64
+}
+
+/*
+NewMatf64Builder returns a builder with room for rows rows of cols values,
+which avoids any reallocation when the number of rows is known, or can be
+estimated, in advance.
+*/
+func NewMatf64Builder(rows, cols int) *Matf64Builder {
+	if rows < 0 || cols < 0 {
+		s := "\nIn matrix.%s, cannot reserve room for %d rows of %d values.\n"
+		s = fmt.Sprintf(s, "NewMatf64Builder()", rows, cols)
+		printErr(s)
+	}
+	return &Matf64Builder{vals: make([]float64, 0, rows*cols)}
+}
+
+/*
+AddRow copies v as the next row of the mat being built.
+*/
+func (b *Matf64Builder) AddRow(v []float64) *Matf64Builder {
+	if b.r == 0 {
+		b.c = len(v)
+	} else if len(v) != b.c {
+		s := "\nIn %s the length of the passed slice is %d, which does\n"
+		s += "not match the length of the previous rows, %d."
+		s = fmt.Sprintf(s, "AddRow()", len(v), b.c)
+		printErr(s)
+	}
+	// append grows the buffer geometrically.
+	b.vals = append(b.vals, v...)
+	b.r++
+	return b
+}
+
+/*
+Rows returns the number of rows added since the builder was created or last
+built.
+*/
+func (b *Matf64Builder) Rows() int {
+	return b.r
+}
+
+/*
+Build returns the mat holding the rows added so far, and resets the builder
+so that it can be used to build another mat. The values are not copied: the
+mat takes over the buffer of the builder, including its spare capacity.
+*/
+func (b *Matf64Builder) Build() *Matf64 {
+	m := Newf64()
+	m.r, m.c = b.r, b.c
+	if b.vals != nil {
+		m.vals = b.vals
+	}
+	*b = Matf64Builder{}
+	return m
+}
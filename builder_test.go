@@ -0,0 +1,34 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64Builder(t *testing.T) {
+	t.Helper()
+	var b Matf64Builder
+	for i := 0; i < 1000; i++ {
+		b.AddRow([]float64{float64(i), float64(2 * i)})
+	}
+	assert.Equal(t, 1000, b.Rows(), "should be equal")
+	m := b.Build()
+	r, c := m.Shape()
+	assert.Equal(t, 1000, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.Equal(t, 1998.0, m.Get(-1, 1), "should be equal")
+	assert.Equal(t, 0, b.Rows(), "should be reset")
+
+	m = b.Build()
+	r, c = m.Shape()
+	assert.Equal(t, 0, r, "should be empty")
+	assert.Equal(t, 0, c, "should be empty")
+
+	n := NewMatf64Builder(3, 2)
+	vals := n.vals
+	n.AddRow([]float64{1, 2}).AddRow([]float64{3, 4}).AddRow([]float64{5, 6})
+	assert.Equal(t, 6, cap(vals), "should be reserved")
+	assert.Equal(t, &vals[:1][0], &n.vals[0], "should not reallocate")
+	assert.True(t, Matf64FromData([]float64{1, 2, 3, 4, 5, 6}, 3, 2).Equals(n.Build()), "should be equal")
+}
@@ -0,0 +1,92 @@
+package matrix
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+/*
+Reserve makes room for rows more rows in the slice backing the mat, so that
+appending up to that many rows with AppendRow, AppendRows or InsertRow does
+not reallocate. It is the cheapest way to build a mat one row at a time when
+the final number of rows is known:
+
+	m := matrix.Newf64(0, 3).Reserve(1000)
+	for _, rec := range records {
+		m.AppendRow(rec)
+	}
+
+The values and the shape of the mat do not change. See Grow to also make
+room for columns.
+*/
+func (m *Matf64) Reserve(rows int) *Matf64 {
+	if rows < 0 {
+		s := "\nIn %s, the number of rows cannot be negative, but %d was received.\n"
+		s = fmt.Sprintf(s, "Reserve()", rows)
+		printErr(s)
+	}
+	m.realloc((m.r + rows) * m.c)
+	return m
+}
+
+/*
+Grow makes room for r more rows and c more columns in the slice backing the
+mat, so that the mat can reach (rows+r)X(cols+c) through the append and
+insert methods without reallocating. The values and the shape of the mat do
+not change.
+*/
+func (m *Matf64) Grow(r, c int) *Matf64 {
+	if r < 0 || c < 0 {
+		s := "\nIn %s, the numbers of rows and columns cannot be negative, but\n"
+		s += "%d and %d were received.\n"
+		s = fmt.Sprintf(s, "Grow()", r, c)
+		printErr(s)
+	}
+	m.realloc((m.r + r) * (m.c + c))
+	return m
+}
+
+/*
+Compact releases the spare capacity of the slice backing the mat, which
+growing methods such as AppendRow keep to make later appends cheap, and
+which can be as large as the values themselves. It is worth calling on a mat
+that is done growing and will be kept for a long time, so that MemBytes
+reports the size of its values only. Appending to the mat afterwards works
+as before, at the cost of one reallocation.
+*/
+func (m *Matf64) Compact() *Matf64 {
+	n := m.r * m.c
+	if m.cow == nil && cap(m.vals) == n {
+		return m
+	}
+	vals := make([]float64, n)
+	copy(vals, m.vals)
+	m.setVals(vals)
+	return m
+}
+
+// realloc ensures that m owns a slice of values that can hold n values,
+// copying the values into a new slice of capacity n when it does not. Shared
+// values are copied in any case, since the next write would copy them anyway
+// with the default capacity.
+func (m *Matf64) realloc(n int) {
+	if m.cow == nil && cap(m.vals) >= n {
+		return
+	}
+	if n < len(m.vals) {
+		n = len(m.vals)
+	}
+	vals := make([]float64, len(m.vals), n)
+	copy(vals, m.vals)
+	m.setVals(vals)
+}
+
+// setVals replaces the values of m by vals, a copy that m owns, and stops
+// sharing the previous values with clones made by CloneCOW.
+func (m *Matf64) setVals(vals []float64) {
+	if m.cow != nil {
+		atomic.AddInt32(&m.cow.n, -1)
+		m.cow = nil
+	}
+	m.vals = vals
+}
@@ -0,0 +1,43 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservef64(t *testing.T) {
+	t.Helper()
+	m := Newf64(0, 3).Reserve(100)
+	assert.Equal(t, 8*300, m.MemBytes(), "should be equal")
+	for i := 0; i < 100; i++ {
+		m.AppendRow([]float64{1, 2, float64(i)})
+	}
+	assert.Equal(t, 8*300, m.MemBytes(), "should not reallocate")
+	assert.Equal(t, 99.0, m.Get(-1, -1), "should be equal")
+
+	m = Matf64FromData([][]float64{{1, 2}, {3, 4}}).Grow(1, 1)
+	assert.Equal(t, 8*9, m.MemBytes(), "should be equal")
+	m.AppendCol([]float64{5, 6}).AppendRow([]float64{7, 8, 9})
+	assert.Equal(t, 8*9, m.MemBytes(), "should not reallocate")
+	assert.Equal(t, [][]float64{{1, 2, 5}, {3, 4, 6}, {7, 8, 9}}, m.ToSlice2D(), "should be equal")
+}
+
+func TestCompactf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 2).AppendRow([]float64{1, 2})
+	assert.True(t, m.MemBytes() > 8*6, "should have spare capacity")
+	assert.Equal(t, 8*6, m.Compact().MemBytes(), "should be equal")
+	assert.Equal(t, []float64{0, 0, 0, 0, 1, 2}, m.ToSlice1D(), "should be equal")
+	m.AppendRow([]float64{3, 4})
+	assert.Equal(t, 4, m.r, "should be equal")
+
+	// A clone stops sharing its values when it is compacted or reserved.
+	n := m.CloneCOW()
+	n.Compact().Set(0, 0, 5)
+	o := m.CloneCOW().Reserve(1)
+	o.Set(0, 1, 6)
+	assert.Equal(t, [][]float64{{0, 0}, {0, 0}, {1, 2}, {3, 4}}, m.ToSlice2D(), "should leave m intact")
+	assert.Equal(t, 5.0, n.Get(0, 0), "should be equal")
+	assert.Equal(t, 6.0, o.Get(0, 1), "should be equal")
+}
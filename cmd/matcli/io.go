@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gocrunch/matrix"
+)
+
+// fileFormat returns the format of the named file, based on its extension,
+// or the given format for the standard streams, and whether the file is
+// compressed with gzip.
+func fileFormat(name, format string) (string, bool, error) {
+	if name == "-" {
+		switch format {
+		case "csv", "npy", "bin":
+			return format, false, nil
+		}
+		return "", false, fmt.Errorf("unknown format %q, it should be csv, npy or bin", format)
+	}
+	gz := strings.HasSuffix(name, ".gz")
+	switch ext := filepath.Ext(strings.TrimSuffix(name, ".gz")); ext {
+	case ".csv", ".bin":
+		return ext[1:], gz, nil
+	case ".npy":
+		if !gz {
+			return "npy", false, nil
+		}
+	}
+	return "", false, fmt.Errorf("cannot tell the format of %s, its extension should be .csv, .csv.gz, .npy, .bin or .bin.gz", name)
+}
+
+// load reads a mat from the named file, or from the standard input if name
+// is "-".
+func (c *cli) load(name, format string) (*matrix.Matf64, error) {
+	format, gz, err := fileFormat(name, format)
+	if err != nil {
+		return nil, err
+	}
+	r := c.stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	if gz {
+		z, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", name, err)
+		}
+		defer z.Close()
+		r = z
+	}
+	switch format {
+	case "npy":
+		return matrix.Newf64().ReadNpy(r), nil
+	case "bin":
+		return matrix.Newf64().ReadBinary(r), nil
+	}
+	// The first line is read ahead, so that an empty file is an error rather
+	// than an exit.
+	br := bufio.NewReader(r)
+	line, err := br.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if strings.TrimSpace(line) == "" {
+		return nil, fmt.Errorf("%s: empty CSV file", name)
+	}
+	return matrix.Matf64FromCSVReader(io.MultiReader(strings.NewReader(line), br)), nil
+}
+
+// save writes m to the named file, or to the standard output if name is "-".
+func (c *cli) save(m *matrix.Matf64, name, format string) (err error) {
+	format, gz, err := fileFormat(name, format)
+	if err != nil {
+		return err
+	}
+	w := c.stdout
+	if name != "-" {
+		f, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		w = f
+	}
+	if gz {
+		z := gzip.NewWriter(w)
+		defer func() {
+			if cerr := z.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		w = z
+	}
+	switch format {
+	case "npy":
+		m.WriteNpy(w)
+	case "bin":
+		m.WriteBinary(w)
+	default:
+		a := matrix.NewCSVAppender(w)
+		if names := m.ColNames(); names != nil {
+			a.WriteHeader(names)
+		}
+		for i := 0; i < rows(m); i++ {
+			a.AppendRow(m.RawRow(i))
+		}
+		a.Flush()
+	}
+	return nil
+}
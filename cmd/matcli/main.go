@@ -0,0 +1,272 @@
+/*
+Command matcli is a small command line tool built on the matrix package,
+for inspecting and transforming mats stored in files from a shell. Its
+usage is:
+
+	matcli <command> [flags] [files]
+
+where command is one of:
+
+	describe  print the shape and per column statistics of a mat
+	head      print the first rows of a mat
+	transpose write the transpose of a mat
+	dot       write the product of two mats
+	solve     write the solution X of A X = B
+	convert   convert a mat from one file format to another
+
+The format of a file is chosen from its extension: ".csv", ".npy" or ".bin"
+(see Matf64.WriteBinary), and ".csv" and ".bin" files may be compressed
+with gzip by appending ".gz". The name "-" stands for the standard input or
+output, whose format is set with the -f flag, and defaults to CSV. When the
+first line of a CSV file has no numerical entries, it is read as a header
+holding the names of the columns. Since every command writes to the standard
+output by default, commands can be chained with pipes:
+
+	matcli transpose data.npy | matcli dot - weights.csv | matcli head -n 3 -
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gocrunch/matrix"
+)
+
+// commands maps the name of each subcommand of matcli to the function
+// running it, which receives the arguments following the name.
+var commands = map[string]func(c *cli, args []string) error{
+	"describe":  (*cli).describe,
+	"head":      (*cli).head,
+	"transpose": (*cli).transpose,
+	"dot":       (*cli).dot,
+	"solve":     (*cli).solve,
+	"convert":   (*cli).convert,
+}
+
+// usages holds the synopsis of each command, in the order of the help.
+var usages = [][2]string{
+	{"describe", "describe [-f format] file"},
+	{"head", "head [-n rows] [-f format] [-o output] file"},
+	{"transpose", "transpose [-f format] [-o output] file"},
+	{"dot", "dot [-f format] [-o output] a b"},
+	{"solve", "solve [-f format] [-o output] a b"},
+	{"convert", "convert [-f format] input output"},
+}
+
+// cli holds the standard streams that the commands read from and write to,
+// so that they can be replaced in tests.
+type cli struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	c := &cli{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	if err := c.main(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "matcli: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+func (c *cli) main(args []string) error {
+	if len(args) == 0 {
+		c.usage()
+		return fmt.Errorf("no command given")
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		c.usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return run(c, args[1:])
+}
+
+func (c *cli) usage() {
+	fmt.Fprintln(c.stderr, "usage: matcli <command> [flags] [files]")
+	fmt.Fprintln(c.stderr, "\ncommands:")
+	for _, u := range usages {
+		fmt.Fprintf(c.stderr, "\tmatcli %s\n", u[1])
+	}
+}
+
+// flags is the flag set of a command. The format flag is always defined,
+// and the output flag only for the commands that write a mat.
+type flags struct {
+	*flag.FlagSet
+	format string
+	output string
+}
+
+func (c *cli) flags(name string, out bool) *flags {
+	f := &flags{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError)}
+	f.SetOutput(c.stderr)
+	f.StringVar(&f.format, "f", "csv", "format of the standard input and output: csv, npy or bin")
+	if out {
+		f.StringVar(&f.output, "o", "-", "output file")
+	}
+	f.Usage = func() {
+		for _, u := range usages {
+			if u[0] == name {
+				fmt.Fprintf(c.stderr, "usage: matcli %s\n", u[1])
+			}
+		}
+		f.PrintDefaults()
+	}
+	return f
+}
+
+// parse parses the flags of the command, and checks that n files remain
+// after them.
+func (f *flags) parse(args []string, n int) error {
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if f.NArg() != n {
+		f.Usage()
+		return fmt.Errorf("%s expects %d files, got %d", f.Name(), n, f.NArg())
+	}
+	return nil
+}
+
+func (c *cli) describe(args []string) error {
+	f := c.flags("describe", false)
+	if err := f.parse(args, 1); err != nil {
+		return err
+	}
+	m, err := c.load(f.Arg(0), f.format)
+	if err != nil {
+		return err
+	}
+	r, cols := m.Shape()
+	fmt.Fprintf(c.stdout, "shape: %dX%d\n", r, cols)
+	if r == 0 {
+		return nil
+	}
+	names := m.ColNames()
+	w := tabwriter.NewWriter(c.stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\tmean\tstd\tmin\t25%\t50%\t75%\tmax\t")
+	for j := 0; j < cols; j++ {
+		name := fmt.Sprint(j)
+		if names != nil {
+			name = names[j]
+		}
+		_, min := m.Min(1, j)
+		_, max := m.Max(1, j)
+		fmt.Fprintf(w, "%s\t%.6g\t%.6g\t%.6g\t%.6g\t%.6g\t%.6g\t%.6g\t\n", name,
+			m.Avg(1, j), m.Std(1, j), min,
+			m.Percentile(25, matrix.InterpLinear, 1, j),
+			m.Percentile(50, matrix.InterpLinear, 1, j),
+			m.Percentile(75, matrix.InterpLinear, 1, j), max)
+	}
+	return w.Flush()
+}
+
+func (c *cli) head(args []string) error {
+	f := c.flags("head", true)
+	n := f.Int("n", 5, "number of rows")
+	if err := f.parse(args, 1); err != nil {
+		return err
+	}
+	if *n < 0 {
+		return fmt.Errorf("the number of rows cannot be negative, got %d", *n)
+	}
+	m, err := c.load(f.Arg(0), f.format)
+	if err != nil {
+		return err
+	}
+	if *n < rows(m) {
+		names := m.ColNames()
+		m = m.SplitAt([]int{*n}, 0)[0]
+		if names != nil {
+			m.SetColNames(names)
+		}
+	}
+	return c.save(m, f.output, f.format)
+}
+
+func (c *cli) transpose(args []string) error {
+	f := c.flags("transpose", true)
+	if err := f.parse(args, 1); err != nil {
+		return err
+	}
+	m, err := c.load(f.Arg(0), f.format)
+	if err != nil {
+		return err
+	}
+	return c.save(m.T(), f.output, f.format)
+}
+
+func (c *cli) dot(args []string) error {
+	f := c.flags("dot", true)
+	if err := f.parse(args, 2); err != nil {
+		return err
+	}
+	a, b, err := c.loadPair(f.Arg(0), f.Arg(1), f.format)
+	if err != nil {
+		return err
+	}
+	if _, ac := a.Shape(); ac != rows(b) {
+		return fmt.Errorf("cannot multiply a %s mat by a %s mat", shape(a), shape(b))
+	}
+	return c.save(a.Dot(b), f.output, f.format)
+}
+
+func (c *cli) solve(args []string) error {
+	f := c.flags("solve", true)
+	if err := f.parse(args, 2); err != nil {
+		return err
+	}
+	a, b, err := c.loadPair(f.Arg(0), f.Arg(1), f.format)
+	if err != nil {
+		return err
+	}
+	x, err := solve(a, b)
+	if err != nil {
+		return err
+	}
+	return c.save(x, f.output, f.format)
+}
+
+func (c *cli) convert(args []string) error {
+	f := c.flags("convert", false)
+	if err := f.parse(args, 2); err != nil {
+		return err
+	}
+	m, err := c.load(f.Arg(0), f.format)
+	if err != nil {
+		return err
+	}
+	return c.save(m, f.Arg(1), f.format)
+}
+
+// loadPair loads the two operands of a binary command, only one of which
+// may be read from the standard input.
+func (c *cli) loadPair(a, b, format string) (*matrix.Matf64, *matrix.Matf64, error) {
+	if a == "-" && b == "-" {
+		return nil, nil, fmt.Errorf("only one operand can be read from the standard input")
+	}
+	m, err := c.load(a, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := c.load(b, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return m, n, nil
+}
+
+func rows(m *matrix.Matf64) int {
+	r, _ := m.Shape()
+	return r
+}
+
+func shape(m *matrix.Matf64) string {
+	r, c := m.Shape()
+	return fmt.Sprintf("%dX%d", r, c)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gocrunch/matrix"
+	"github.com/stretchr/testify/assert"
+)
+
+func run(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	c := &cli{stdin: strings.NewReader(stdin), stdout: &out, stderr: &errOut}
+	err := c.main(args)
+	return out.String(), err
+}
+
+// parse reads the CSV output of a command back into a mat.
+func parse(t *testing.T, out string) *matrix.Matf64 {
+	t.Helper()
+	c := &cli{stdin: strings.NewReader(out)}
+	m, err := c.load("-", "csv")
+	assert.Nil(t, err, "should not fail")
+	return m
+}
+
+func TestHead(t *testing.T) {
+	t.Helper()
+	out, err := run(t, "a,b\n1,2\n3,4\n5,6\n", "head", "-n", "2", "-")
+	assert.Nil(t, err, "should not fail")
+	m := parse(t, out)
+	assert.True(t, matrix.Matf64FromData([][]float64{{1, 2}, {3, 4}}).Equals(m), "should be equal")
+	assert.Equal(t, []string{"a", "b"}, m.ColNames(), "should be equal")
+}
+
+func TestTranspose(t *testing.T) {
+	t.Helper()
+	out, err := run(t, "1,2,3\n4,5,6\n", "transpose", "-")
+	assert.Nil(t, err, "should not fail")
+	want := matrix.Matf64FromData([][]float64{{1, 4}, {2, 5}, {3, 6}})
+	assert.True(t, want.Equals(parse(t, out)), "should be equal")
+}
+
+func TestDotSolve(t *testing.T) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "matcli")
+	assert.Nil(t, err, "should not fail")
+	defer os.RemoveAll(dir)
+	a := filepath.Join(dir, "a.npy")
+	f, err := os.Create(a)
+	assert.Nil(t, err, "should not fail")
+	matrix.Matf64FromData([][]float64{{0, 2}, {4, 1}}).WriteNpy(f)
+	f.Close()
+
+	b := filepath.Join(dir, "b.csv.gz")
+	_, err = run(t, "1\n2\n", "convert", "-", b)
+	assert.Nil(t, err, "should not fail")
+
+	out, err := run(t, "", "dot", a, b)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []float64{4, 6}, parse(t, out).ToSlice1D(), "should be equal")
+
+	x := filepath.Join(dir, "x.bin")
+	_, err = run(t, "4\n6\n", "solve", "-o", x, a, "-")
+	assert.Nil(t, err, "should not fail")
+	out, err = run(t, "", "convert", x, "-")
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []float64{1, 2}, parse(t, out).ToSlice1D(), "should be equal")
+
+	_, err = run(t, "1,2\n2,4\n", "solve", "-", b)
+	assert.EqualError(t, err, "the 2X2 mat is singular")
+}
+
+func TestDescribe(t *testing.T) {
+	t.Helper()
+	out, err := run(t, "x,y\n1,10\n2,20\n3,30\n", "describe", "-")
+	assert.Nil(t, err, "should not fail")
+	lines := strings.Split(out, "\n")
+	assert.Equal(t, "shape: 3X2", lines[0], "should be equal")
+	assert.Equal(t, []string{"x", "2", "0.816497", "1", "1.5", "2", "2.5", "3"},
+		strings.Fields(lines[2]), "should be equal")
+}
+
+func TestErrors(t *testing.T) {
+	t.Helper()
+	_, err := run(t, "", "frobnicate")
+	assert.EqualError(t, err, `unknown command "frobnicate"`)
+	_, err = run(t, "", "head", "data.txt")
+	assert.EqualError(t, err, "cannot tell the format of data.txt, its extension should be .csv, .csv.gz, .npy, .bin or .bin.gz")
+	_, err = run(t, "", "dot", "-", "-")
+	assert.EqualError(t, err, "only one operand can be read from the standard input")
+	_, err = run(t, "1,2\n", "dot", "-f", "csv", "-", "-")
+	assert.EqualError(t, err, "only one operand can be read from the standard input")
+	_, err = run(t, "", "transpose", "a.csv", "b.csv")
+	assert.EqualError(t, err, "transpose expects 1 files, got 2")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gocrunch/matrix"
+)
+
+// solve returns the solution X of A X = B, where A is square, by Gaussian
+// elimination with partial pivoting. A and B are left intact.
+func solve(a, b *matrix.Matf64) (*matrix.Matf64, error) {
+	n, c := a.Shape()
+	if n != c {
+		return nil, fmt.Errorf("cannot solve a system with a %s mat, it must be square", shape(a))
+	}
+	if rows(b) != n {
+		return nil, fmt.Errorf("cannot solve a system with a %s mat and a %s mat", shape(a), shape(b))
+	}
+	_, k := b.Shape()
+	lu := a.Copy().RawData()
+	x := b.Copy()
+	xv := x.RawData()
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(lu[i*n+j]) > math.Abs(lu[p*n+j]) {
+				p = i
+			}
+		}
+		if lu[p*n+j] == 0 {
+			return nil, fmt.Errorf("the %s mat is singular", shape(a))
+		}
+		if p != j {
+			for l := 0; l < n; l++ {
+				lu[j*n+l], lu[p*n+l] = lu[p*n+l], lu[j*n+l]
+			}
+			for l := 0; l < k; l++ {
+				xv[j*k+l], xv[p*k+l] = xv[p*k+l], xv[j*k+l]
+			}
+		}
+		for i := j + 1; i < n; i++ {
+			f := lu[i*n+j] / lu[j*n+j]
+			for l := j; l < n; l++ {
+				lu[i*n+l] -= f * lu[j*n+l]
+			}
+			for l := 0; l < k; l++ {
+				xv[i*k+l] -= f * xv[j*k+l]
+			}
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for l := 0; l < k; l++ {
+			s := xv[i*k+l]
+			for j := i + 1; j < n; j++ {
+				s -= lu[i*n+j] * xv[j*k+l]
+			}
+			xv[i*k+l] = s / lu[i*n+i]
+		}
+	}
+	return x, nil
+}
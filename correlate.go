@@ -0,0 +1,126 @@
+package matrix
+
+import "fmt"
+
+/*
+Padding selects how the sliding window functions, such as Correlate2D,
+handle the borders of a mat. Elements outside of the mat are taken to be 0.
+*/
+type Padding int
+
+const (
+	// PadValid only places the window where it fits entirely inside the
+	// mat, so the result is smaller than the mat.
+	PadValid Padding = iota
+	// PadSame pads the mat so that, with a stride of 1, the result has the
+	// same shape as the mat. Any odd padding goes after the mat.
+	PadSame
+	// PadFull places the window wherever it overlaps the mat, so the result
+	// is larger than the mat.
+	PadFull
+)
+
+/*
+Correlate2D slides the kernel k over the mat, and returns the mat of the
+sums of the products of the elements of k with the elements below it. That
+is, with no padding and a stride of 1:
+
+	o[i][j] = sum over a, b of m[i+a][j+b]*k[a][b]
+
+pad chooses how the window is placed along the borders, and the window moves
+by stride rows and columns at a time. For example, to find where a template
+best matches an image:
+
+	c := image.Correlate2D(template, matrix.PadValid, 1)
+	i, _ := c.Max()
+
+The kernel is not flipped, so this is a convolution by the kernel rotated by
+180 degrees. Both mats are left intact.
+*/
+func (m *Matf64) Correlate2D(k *Matf64, pad Padding, stride int) *Matf64 {
+	checkWindow("Correlate2D()", k.r, k.c, pad, stride)
+	return m.correlate(k, pad, stride, stride)
+}
+
+/*
+Correlate slides the kernel k along each row of the mat, and returns the mat
+whose rows are the cross-correlations of the rows of m with k:
+
+	o[i][j] = sum over b of m[i][j+b]*k[b]
+
+with the same padding and stride as in Correlate2D, which only apply to the
+columns. This is handy for lag analysis of several series at once, or for
+moving sums with a kernel of ones. The mat is left intact.
+*/
+func (m *Matf64) Correlate(k []float64, pad Padding, stride int) *Matf64 {
+	checkWindow("Correlate()", 1, len(k), pad, stride)
+	return m.correlate(&Matf64{r: 1, c: len(k), vals: k}, pad, 1, stride)
+}
+
+// checkWindow checks the arguments of the sliding window functions, where
+// kr and kc are the shape of the kernel.
+func checkWindow(fn string, kr, kc int, pad Padding, stride int) {
+	if kr == 0 || kc == 0 {
+		s := "\nIn %s, the kernel cannot be empty, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, fn, kr, kc)
+		printHelperErr(s)
+	}
+	if pad < PadValid || pad > PadFull {
+		s := "\nIn %s, %d is not a valid padding.\n"
+		s = fmt.Sprintf(s, fn, pad)
+		printHelperErr(s)
+	}
+	if stride < 1 {
+		s := "\nIn %s, the stride must be positive, but %d was received.\n"
+		s = fmt.Sprintf(s, fn, stride)
+		printHelperErr(s)
+	}
+}
+
+// window returns the number of positions of a window of size k sliding by
+// stride over n elements padded according to pad, and the padding before
+// the first element.
+func window(n, k int, pad Padding, stride int) (int, int) {
+	switch pad {
+	case PadSame:
+		out := (n + stride - 1) / stride
+		before := ((out-1)*stride + k - n) / 2
+		if before < 0 {
+			before = 0
+		}
+		return out, before
+	case PadFull:
+		return (n+k-2)/stride + 1, k - 1
+	}
+	if n < k {
+		return 0, 0
+	}
+	return (n-k)/stride + 1, 0
+}
+
+// correlate is the sliding window loop shared by Correlate2D and Correlate,
+// with separate strides for the rows and the columns.
+func (m *Matf64) correlate(k *Matf64, pad Padding, sr, sc int) *Matf64 {
+	or, br := window(m.r, k.r, pad, sr)
+	oc, bc := window(m.c, k.c, pad, sc)
+	o := Newf64(or, oc)
+	for i := 0; i < or; i++ {
+		for j := 0; j < oc; j++ {
+			r0, c0 := i*sr-br, j*sc-bc
+			sum := 0.0
+			for a := 0; a < k.r; a++ {
+				r := r0 + a
+				if r < 0 || r >= m.r {
+					continue
+				}
+				for b := 0; b < k.c; b++ {
+					if c := c0 + b; c >= 0 && c < m.c {
+						sum += m.vals[r*m.c+c] * k.vals[a*k.c+b]
+					}
+				}
+			}
+			o.vals[i*oc+j] = sum
+		}
+	}
+	return o
+}
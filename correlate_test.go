@@ -0,0 +1,44 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelate2Df64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	k := Matf64FromData([][]float64{{1, 0}, {0, 1}})
+	o := m.Correlate2D(k, PadValid, 1)
+	assert.Equal(t, [][]float64{{6, 8}, {12, 14}}, o.ToSlice2D(), "should be equal")
+	o = m.Correlate2D(k, PadSame, 1)
+	assert.Equal(t, [][]float64{{6, 8, 3}, {12, 14, 6}, {7, 8, 9}}, o.ToSlice2D(), "should be equal")
+	o = m.Correlate2D(k, PadSame, 2)
+	assert.Equal(t, [][]float64{{6, 3}, {7, 9}}, o.ToSlice2D(), "should be equal")
+	o = m.Correlate2D(k, PadFull, 1)
+	r, c := o.Shape()
+	assert.Equal(t, []int{4, 4}, []int{r, c}, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 0}, o.Row(0).ToSlice1D(), "should be equal")
+	assert.Equal(t, 9.0, o.Get(3, 3), "should be equal")
+	o = k.Correlate2D(m, PadValid, 1)
+	r, c = o.Shape()
+	assert.Equal(t, []int{0, 0}, []int{r, c}, "should be equal")
+
+	img := Newf64(5, 6)
+	tmpl := Matf64FromData([][]float64{{1, -1}, {-1, 1}})
+	img.SetSubMatrix(2, 3, tmpl)
+	i, _ := img.Correlate2D(tmpl, PadValid, 1).Max()
+	assert.Equal(t, 2*5+3, i, "should be equal")
+}
+
+func TestCorrelatef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3, 4}, {1, 0, 0, 1}})
+	o := m.Correlate([]float64{1, 1}, PadValid, 1)
+	assert.Equal(t, [][]float64{{3, 5, 7}, {1, 0, 1}}, o.ToSlice2D(), "should be equal")
+	o = m.Correlate([]float64{1, 1}, PadValid, 2)
+	assert.Equal(t, [][]float64{{3, 7}, {1, 1}}, o.ToSlice2D(), "should be equal")
+	o = m.Correlate([]float64{1, 1}, PadFull, 1)
+	assert.Equal(t, [][]float64{{1, 3, 5, 7, 4}, {1, 1, 0, 1, 1}}, o.ToSlice2D(), "should be equal")
+}
@@ -0,0 +1,155 @@
+package matrix
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+/*
+CSVAppender writes rows of float64s to a CSV file, or to any io.Writer, one
+row at a time. This makes it possible to stream results to disk without ever
+holding them all in memory, for instance in a long running simulation:
+
+	a := matrix.CSVAppenderFromFile("results.csv")
+	defer a.Close()
+	for step := 0; step < steps; step++ {
+		a.AppendRow(simulate(step))
+	}
+
+The values are written with the same format as ToCSV, so the file can be
+read back with Matf64FromCSV. Every row must have the same number of values.
+*/
+type CSVAppender struct {
+	w     *bufio.Writer
+	c     io.Closer
+	name  string
+	cols  int
+	line  []byte
+	first bool
+}
+
+/*
+NewCSVAppender returns a CSVAppender that writes to w. The rows are buffered,
+so Flush must be called once all rows have been appended.
+*/
+func NewCSVAppender(w io.Writer) *CSVAppender {
+	return &CSVAppender{
+		w:     bufio.NewWriter(w),
+		first: true,
+	}
+}
+
+/*
+CSVAppenderFromFile returns a CSVAppender that appends rows to the end of the
+named file, which is created if it does not exist. If the name of the file
+ends with ".gz", the rows are compressed with gzip. Close must be called once
+all rows have been appended.
+*/
+func CSVAppenderFromFile(filename string) *CSVAppender {
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "CSVAppenderFromFile()", filename, err)
+		printErr(s)
+	}
+	var w io.WriteCloser = f
+	if strings.HasSuffix(filename, ".gz") {
+		w = &gzipWriter{gzip.NewWriter(f), f}
+	}
+	a := NewCSVAppender(w)
+	a.c = w
+	a.name = filename
+	return a
+}
+
+/*
+WriteHeader writes the names of the columns as a single comma separated line,
+quoting them as needed. It must be called before the first row is appended,
+and the number of names sets the number of values of every row.
+*/
+func (a *CSVAppender) WriteHeader(names []string) *CSVAppender {
+	if !a.first {
+		s := "\nIn %s, the header must be written before the first row.\n"
+		s = fmt.Sprintf(s, "WriteHeader()")
+		printErr(s)
+	}
+	a.cols = len(names)
+	a.first = false
+	w := csv.NewWriter(a.w)
+	if err := w.Write(names); err != nil {
+		a.fail("WriteHeader()", err)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		a.fail("WriteHeader()", err)
+	}
+	return a
+}
+
+/*
+AppendRow writes a row of values as a single comma separated line. The
+number of values must be the same as in the first appended row.
+*/
+func (a *CSVAppender) AppendRow(v []float64) *CSVAppender {
+	if a.first {
+		a.cols = len(v)
+		a.first = false
+	}
+	if len(v) != a.cols {
+		s := "\nIn %s the length of the passed slice is %d, which does\n"
+		s += "not match the length of the previous rows, %d."
+		s = fmt.Sprintf(s, "AppendRow()", len(v), a.cols)
+		printErr(s)
+	}
+	a.line = a.line[:0]
+	for j := range v {
+		if j != 0 {
+			a.line = append(a.line, ',')
+		}
+		a.line = strconv.AppendFloat(a.line, v[j], 'e', 14, 64)
+	}
+	a.line = append(a.line, '\n')
+	if _, err := a.w.Write(a.line); err != nil {
+		a.fail("AppendRow()", err)
+	}
+	return a
+}
+
+/*
+Flush writes any buffered rows to the underlying io.Writer.
+*/
+func (a *CSVAppender) Flush() {
+	if err := a.w.Flush(); err != nil {
+		a.fail("Flush()", err)
+	}
+}
+
+/*
+Close flushes the buffered rows, and closes the file of an appender created
+with CSVAppenderFromFile. For other appenders, Close is the same as Flush.
+*/
+func (a *CSVAppender) Close() {
+	a.Flush()
+	if a.c != nil {
+		if err := a.c.Close(); err != nil {
+			a.fail("Close()", err)
+		}
+		a.c = nil
+	}
+}
+
+func (a *CSVAppender) fail(fn string, err error) {
+	s := "\nIn %s, cannot write to %s due to error: %v.\n"
+	name := a.name
+	if name == "" {
+		name = "the writer"
+	}
+	s = fmt.Sprintf(s, fn, name, err)
+	printHelperErr(s)
+}
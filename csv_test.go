@@ -0,0 +1,42 @@
+package matrix
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVAppender(t *testing.T) {
+	t.Helper()
+	var b bytes.Buffer
+	a := NewCSVAppender(&b)
+	a.AppendRow([]float64{1, 2}).AppendRow([]float64{3, 4.5})
+	assert.Equal(t, 0, b.Len(), "should be buffered")
+	a.Flush()
+	want := "1.00000000000000e+00,2.00000000000000e+00\n"
+	want += "3.00000000000000e+00,4.50000000000000e+00\n"
+	assert.Equal(t, want, b.String(), "should be equal")
+}
+
+func TestCSVAppenderFromFile(t *testing.T) {
+	t.Helper()
+	for _, filename := range []string{"appender_test.csv", "appender_test.csv.gz"} {
+		m := RandMatf64(5, 3)
+		m.ToCSV(filename)
+		a := CSVAppenderFromFile(filename)
+		for i := 0; i < 4; i++ {
+			v := []float64{float64(i), 1.0, 2.0}
+			a.AppendRow(v)
+			m.AppendRow(v)
+		}
+		a.Close()
+		n := Matf64FromCSV(filename)
+		assert.Equal(t, 9, n.r, "should have all the rows")
+		for i := range m.vals {
+			assert.InDelta(t, m.vals[i], n.vals[i], 1e-14, "should be equal")
+		}
+		os.Remove(filename)
+	}
+}
@@ -0,0 +1,81 @@
+package matrix
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+Diag returns the kth diagonal of the mat as a row vector. The main diagonal
+is k = 0, diagonals above it have k > 0, and diagonals below it have k < 0.
+For example:
+
+	m := matrix.Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	m.Diag(0)  // [1, 5]
+	m.Diag(1)  // [2, 6]
+	m.Diag(-1) // [4]
+
+The mat does not need to be square. If the diagonal lies outside of the mat,
+the returned vector is empty. The values are copied.
+*/
+func (m *Matf64) Diag(k int) *Matf64 {
+	i0, j0, n := m.diagBounds(k)
+	v := Newf64(1, n)
+	for d := 0; d < n; d++ {
+		v.vals[d] = m.vals[(i0+d)*m.c+j0+d]
+	}
+	return v
+}
+
+/*
+SetDiag sets the elements of the kth diagonal of the mat (see Diag). When a
+float64 is passed, every element of the diagonal is set to it, and when a
+[]float64 is passed, it must have as many elements as the diagonal. For
+example, to add a ridge penalty lambda to a square mat:
+
+	m.SetDiag(0, m.Diag(0).Add(lambda).ToSlice1D())
+*/
+func (m *Matf64) SetDiag(k int, floatOrSlice interface{}) *Matf64 {
+	i0, j0, n := m.diagBounds(k)
+	m.materialize()
+	switch val := floatOrSlice.(type) {
+	case float64:
+		for d := 0; d < n; d++ {
+			m.vals[(i0+d)*m.c+j0+d] = val
+		}
+	case []float64:
+		if len(val) != n {
+			s := "\nIn %s the length of the passed slice is %d, which does\n"
+			s += "not match the length of diagonal %d of the receiver, %d."
+			s = fmt.Sprintf(s, "SetDiag()", len(val), k, n)
+			printErr(s)
+		}
+		for d := 0; d < n; d++ {
+			m.vals[(i0+d)*m.c+j0+d] = val[d]
+		}
+	default:
+		s := "\nIn %s, the passed value must be a float64 or []float64.\n"
+		s += "However, value of type  %v was received.\n"
+		s = fmt.Sprintf(s, "SetDiag()", reflect.TypeOf(val))
+		printErr(s)
+	}
+	return m
+}
+
+// diagBounds returns the row and column of the first element of the kth
+// diagonal of m, and the number of elements of that diagonal.
+func (m *Matf64) diagBounds(k int) (i0, j0, n int) {
+	if k >= 0 {
+		j0 = k
+	} else {
+		i0 = -k
+	}
+	n = m.r - i0
+	if m.c-j0 < n {
+		n = m.c - j0
+	}
+	if n < 0 {
+		n = 0
+	}
+	return i0, j0, n
+}
@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagMethodf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Equal(t, []float64{1, 5}, m.Diag(0).vals, "should be equal")
+	assert.Equal(t, []float64{2, 6}, m.Diag(1).vals, "should be equal")
+	assert.Equal(t, []float64{3}, m.Diag(2).vals, "should be equal")
+	assert.Equal(t, []float64{4}, m.Diag(-1).vals, "should be equal")
+	assert.Equal(t, 0, len(m.Diag(3).vals), "should be empty")
+	assert.Equal(t, 0, len(m.Diag(-2).vals), "should be empty")
+	assert.Equal(t, 1, m.Diag(0).r, "should be a row vector")
+}
+
+func TestSetDiagf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(3)
+	m.SetDiag(0, 2.0).SetDiag(1, []float64{7, 8}).SetDiag(-2, 9.0)
+	want := Matf64FromData([][]float64{{2, 7, 0}, {0, 2, 8}, {9, 0, 2}})
+	assert.True(t, want.Equals(m), "should be equal")
+	m.SetDiag(0, m.Diag(0).Add(0.5).ToSlice1D())
+	assert.Equal(t, []float64{2.5, 2.5, 2.5}, m.Diag(0).vals, "should be equal")
+}
@@ -0,0 +1,584 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sort"
+)
+
+/*
+PowerIteration returns the eigenvalue of largest magnitude of the square mat
+m, and a matching eigenvector of unit length, by repeatedly multiplying a
+vector by m. Each product costs O(n²), which makes it much cheaper than a
+full eigendecomposition when only the dominant eigenpair is needed, as in
+PageRank:
+
+	rank, v, err := links.PowerIteration(1e-10, 1000)
+
+The iteration starts from a random vector, with a fixed seed so that the
+results are reproducible, and stops when the residual |m*v - λ*v| is at most
+tol*|λ|. If that does not happen within maxIter iterations, the last
+estimates are returned along with an error. The convergence is slow when the
+two largest eigenvalues have close magnitudes, and it fails when they have
+equal magnitudes and different values. The sign of the eigenvector is chosen
+so that its largest element is positive. The mat is left intact.
+*/
+func (m *Matf64) PowerIteration(tol float64, maxIter int) (float64, []float64, error) {
+	if m.r != m.c {
+		s := "\nIn %s, the mat must be square, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, "PowerIteration()", m.r, m.c)
+		printErr(s)
+	}
+	if !(tol > 0) || maxIter < 1 {
+		s := "\nIn %s, the tolerance and the maximum number of iterations\n"
+		s += "must be positive, but %g and %d were received.\n"
+		s = fmt.Sprintf(s, "PowerIteration()", tol, maxIter)
+		printErr(s)
+	}
+	n := m.r
+	// A fixed random start has a component along the dominant eigenvector
+	// with probability 1, which a vector of ones lacks for some mats.
+	v := RandnMatf64With(rand.New(rand.NewSource(1)), 1, n).vals
+	normalizeVector(v)
+	w := make([]float64, n)
+	lambda := 0.0
+	for iter := 0; iter < maxIter; iter++ {
+		for i := range w {
+			w[i] = dotf64Helper(m.vals[i*n:(i+1)*n], v)
+		}
+		lambda = dotf64Helper(v, w)
+		res, norm := 0.0, 0.0
+		for i := range w {
+			res = math.Hypot(res, w[i]-lambda*v[i])
+			norm = math.Hypot(norm, w[i])
+		}
+		if norm == 0 {
+			// v is in the null space of m, so it is an eigenvector for 0.
+			return 0, orientVector(v), nil
+		}
+		if res <= tol*math.Abs(lambda) {
+			return lambda, orientVector(v), nil
+		}
+		for i := range v {
+			v[i] = w[i] / norm
+		}
+	}
+	err := fmt.Errorf("In %s: no convergence after %d iterations", "PowerIteration()", maxIter)
+	return lambda, orientVector(v), err
+}
+
+// orientVector flips the sign of v if needed so that its element of largest
+// magnitude is positive, and returns it.
+func orientVector(v []float64) []float64 {
+	k := 0
+	for i, x := range v {
+		if math.Abs(x) > math.Abs(v[k]) {
+			k = i
+		}
+	}
+	if len(v) > 0 && v[k] < 0 {
+		for i := range v {
+			v[i] = -v[i]
+		}
+	}
+	return v
+}
+
+/*
+EigenWhich selects which end of the spectrum Lanczos and Arnoldi look for.
+*/
+type EigenWhich int
+
+const (
+	// EigenLargest selects the largest eigenvalues, by value for Lanczos and
+	// by magnitude for Arnoldi.
+	EigenLargest EigenWhich = iota
+	// EigenSmallest selects the smallest eigenvalues, by value for Lanczos
+	// and by magnitude for Arnoldi.
+	EigenSmallest
+)
+
+// krylovTol is the accuracy of the eigenpairs found by Lanczos and Arnoldi,
+// relative to the largest eigenvalue found.
+const krylovTol = 1e-10
+
+/*
+Lanczos returns k eigenvalues of the symmetric mat m, either the largest or
+the smallest ones according to which, sorted from the most extreme, along
+with a mat whose columns are the matching eigenvectors of unit length:
+
+	vals, vecs, err := m.Lanczos(3, matrix.EigenSmallest)
+
+m is only used through products with vectors, and the work is done on a
+Krylov subspace of dimension about 2k, so a few eigenpairs of a large mat
+are found far faster than with a full eigendecomposition. The subspace is
+rebuilt from the current estimates until the residuals |m*v - λ*v| are
+within a relative tolerance of 1e-10, and an error is returned along with
+the last estimates if that takes more than 300 restarts. The start of the
+iteration is fixed, so the result is reproducible. The mat is left intact.
+*/
+func (m *Matf64) Lanczos(k int, which EigenWhich) ([]float64, *Matf64, error) {
+	ncv := checkKrylov("Lanczos()", m, k, which)
+	max := 0.0
+	for _, x := range m.vals {
+		max = math.Max(max, math.Abs(x))
+	}
+	if err := m.Validate(ValidateOpts{Symmetric: true, Tol: 1e-10 * max}); err != nil {
+		s := "\nIn %s, the mat must be symmetric, but %v.\n"
+		s = fmt.Sprintf(s, "Lanczos()", err)
+		printErr(s)
+	}
+	n := m.r
+	v0 := RandnMatf64With(rand.New(rand.NewSource(1)), 1, n).vals
+	vals := make([]float64, k)
+	vecs := Newf64(n, k)
+	report := progressFunc()
+	for restart := 0; restart < 300; restart++ {
+		v, h := m.arnoldi(v0, ncv)
+		// In exact arithmetic h is tridiagonal, so only its diagonals are
+		// kept.
+		t := Newf64(ncv, ncv)
+		for i := 0; i < ncv; i++ {
+			t.vals[i*ncv+i] = h[i][i]
+			if i > 0 {
+				t.vals[i*ncv+i-1], t.vals[(i-1)*ncv+i] = h[i][i-1], h[i][i-1]
+			}
+		}
+		theta, s := symmetricEigen(t)
+		if which == EigenSmallest {
+			for i, j := 0, ncv-1; i < j; i, j = i+1, j-1 {
+				theta[i], theta[j] = theta[j], theta[i]
+				swapCols(s, i, j)
+			}
+		}
+		scale := math.Max(math.Abs(theta[0]), math.Abs(theta[ncv-1]))
+		converged := true
+		for i := 0; i < k; i++ {
+			if math.Abs(h[ncv][ncv-1]*s.vals[(ncv-1)*ncv+i]) > krylovTol*scale {
+				converged = false
+			}
+		}
+		for i := range v0 {
+			v0[i] = 0
+		}
+		for i := 0; i < k; i++ {
+			vals[i] = theta[i]
+			for r := 0; r < n; r++ {
+				x := 0.0
+				for j := 0; j < ncv; j++ {
+					x += v[j][r] * s.vals[j*ncv+i]
+				}
+				vecs.vals[r*k+i] = x
+				v0[r] += x
+			}
+		}
+		if report != nil {
+			report("Lanczos()", restart+1, -1)
+		}
+		if converged || ncv == n {
+			return vals, vecs, nil
+		}
+	}
+	err := fmt.Errorf("In %s: no convergence after %d restarts", "Lanczos()", 300)
+	return vals, vecs, err
+}
+
+/*
+Arnoldi is the counterpart of Lanczos for mats that are not symmetric. It
+returns k eigenvalues of m, the largest or the smallest in magnitude
+according to which, sorted from the most extreme. Since the eigenvalues of a
+real mat may be complex, they are returned as complex128s, and the real and
+imaginary parts of the matching eigenvectors are returned as the columns of
+two mats, as in FFT2D. The eigenvectors have unit length, and the phase of
+each is chosen so that its element of largest magnitude is real and
+positive, so the eigenvectors of real eigenvalues have no imaginary part.
+The convergence criterion and the restarts are the same as in Lanczos. The
+mat is left intact.
+*/
+func (m *Matf64) Arnoldi(k int, which EigenWhich) ([]complex128, *Matf64, *Matf64, error) {
+	ncv := checkKrylov("Arnoldi()", m, k, which)
+	n := m.r
+	v0 := RandnMatf64With(rand.New(rand.NewSource(1)), 1, n).vals
+	vals := make([]complex128, k)
+	re, im := Newf64(n, k), Newf64(n, k)
+	report := progressFunc()
+	for restart := 0; restart < 300; restart++ {
+		v, h := m.arnoldi(v0, ncv)
+		hc := make([][]complex128, ncv)
+		for i := range hc {
+			hc[i] = make([]complex128, ncv)
+			for j := range hc[i] {
+				hc[i][j] = complex(h[i][j], 0)
+			}
+		}
+		theta, ok := hessenbergEigenvalues(hc)
+		if !ok {
+			err := fmt.Errorf("In %s: the QR algorithm does not converge", "Arnoldi()")
+			return vals, re, im, err
+		}
+		sortEigenvalues(theta, which)
+		scale := cmplx.Abs(theta[0])
+		if s := cmplx.Abs(theta[ncv-1]); s > scale {
+			scale = s
+		}
+		converged := true
+		for i := range v0 {
+			v0[i] = 0
+		}
+		for i := 0; i < k; i++ {
+			vals[i] = theta[i]
+			y := hessenbergEigenvector(h, theta[i])
+			if cmplx.Abs(complex(h[ncv][ncv-1], 0)*y[ncv-1]) > krylovTol*scale {
+				converged = false
+			}
+			// x = v*y, rotated so that its largest element is real and
+			// positive.
+			x := make([]complex128, n)
+			for r := range x {
+				for j := 0; j < ncv; j++ {
+					x[r] += complex(v[j][r], 0) * y[j]
+				}
+			}
+			top := 0
+			for r := range x {
+				if cmplx.Abs(x[r]) > cmplx.Abs(x[top]) {
+					top = r
+				}
+			}
+			phase := cmplx.Conj(x[top]) / complex(cmplx.Abs(x[top]), 0)
+			for r := range x {
+				x[r] *= phase
+				re.vals[r*k+i], im.vals[r*k+i] = real(x[r]), imag(x[r])
+				v0[r] += real(x[r]) + imag(x[r])
+			}
+		}
+		if report != nil {
+			report("Arnoldi()", restart+1, -1)
+		}
+		if converged || ncv == n {
+			return vals, re, im, nil
+		}
+	}
+	err := fmt.Errorf("In %s: no convergence after %d restarts", "Arnoldi()", 300)
+	return vals, re, im, err
+}
+
+// checkKrylov checks the arguments of Lanczos and Arnoldi, and returns the
+// dimension of the Krylov subspace to use.
+func checkKrylov(fn string, m *Matf64, k int, which EigenWhich) int {
+	if m.r != m.c {
+		s := "\nIn %s, the mat must be square, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, fn, m.r, m.c)
+		printHelperErr(s)
+	}
+	if k < 1 || k > m.r {
+		s := "\nIn %s, the number of eigenvalues must be between 1 and %d,\n"
+		s += "but %d was received.\n"
+		s = fmt.Sprintf(s, fn, m.r, k)
+		printHelperErr(s)
+	}
+	if which != EigenLargest && which != EigenSmallest {
+		s := "\nIn %s, %d is not a valid choice of eigenvalues.\n"
+		s = fmt.Sprintf(s, fn, which)
+		printHelperErr(s)
+	}
+	ncv := 2*k + 1
+	if ncv < k+20 {
+		ncv = k + 20
+	}
+	if ncv > m.r {
+		ncv = m.r
+	}
+	return ncv
+}
+
+// arnoldi builds an orthonormal basis v[0], ..., v[steps] of the Krylov
+// subspace of m started from v0, and the (steps+1)Xsteps Hessenberg mat h
+// such that m*v[j] = sum over i of h[i][j]*v[i]. The vectors are
+// reorthogonalized, and if the subspace is invariant the basis is continued
+// with a random vector, with a zero entry in h.
+func (m *Matf64) arnoldi(v0 []float64, steps int) ([][]float64, [][]float64) {
+	n := m.r
+	rng := rand.New(rand.NewSource(2))
+	v := make([][]float64, steps+1)
+	h := make([][]float64, steps+1)
+	for i := range h {
+		h[i] = make([]float64, steps)
+	}
+	v[0] = append([]float64(nil), v0...)
+	if normalizeVector(v[0]) == 0 {
+		v[0][0] = 1
+	}
+	for j := 0; j < steps; j++ {
+		w := make([]float64, n)
+		for r := range w {
+			w[r] = dotf64Helper(m.vals[r*n:(r+1)*n], v[j])
+		}
+		norm0 := math.Sqrt(dotf64Helper(w, w))
+		for pass := 0; pass < 2; pass++ {
+			for i := 0; i <= j; i++ {
+				d := dotf64Helper(v[i], w)
+				h[i][j] += d
+				for r := range w {
+					w[r] -= d * v[i][r]
+				}
+			}
+		}
+		h[j+1][j] = normalizeVector(w)
+		for h[j+1][j] <= 1e-12*norm0 && j+1 < n {
+			// The subspace is invariant, so it is left with a new direction
+			// orthogonal to the previous ones.
+			h[j+1][j] = 0
+			for r := range w {
+				w[r] = rng.NormFloat64()
+			}
+			for pass := 0; pass < 2; pass++ {
+				for i := 0; i <= j; i++ {
+					d := dotf64Helper(v[i], w)
+					for r := range w {
+						w[r] -= d * v[i][r]
+					}
+				}
+			}
+			if normalizeVector(w) > 1e-12 {
+				break
+			}
+		}
+		v[j+1] = w
+	}
+	return v, h
+}
+
+// normalizeVector scales v to unit length, unless it is 0, and returns its
+// original length.
+func normalizeVector(v []float64) float64 {
+	norm := math.Sqrt(dotf64Helper(v, v))
+	if norm > 0 {
+		for i := range v {
+			v[i] /= norm
+		}
+	}
+	return norm
+}
+
+// symmetricEigen returns the eigenvalues of the symmetric mat a in decreasing
+// order, and the mat whose columns are the matching eigenvectors, with the
+// cyclic Jacobi method. a is overwritten.
+func symmetricEigen(a *Matf64) ([]float64, *Matf64) {
+	n := a.r
+	v := Identityf64(n)
+	for sweep := 0; sweep < 100; sweep++ {
+		off := 0.0
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += a.vals[p*n+q] * a.vals[p*n+q]
+			}
+		}
+		if off == 0 {
+			break
+		}
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := a.vals[p*n+q]
+				if apq == 0 {
+					continue
+				}
+				app, aqq := a.vals[p*n+p], a.vals[q*n+q]
+				if math.Abs(apq) <= 1e-18*(math.Abs(app)+math.Abs(aqq)) {
+					a.vals[p*n+q], a.vals[q*n+p] = 0, 0
+					continue
+				}
+				zeta := (aqq - app) / (2 * apq)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+				// a = jᵀ*a*j, where j rotates the coordinates p and q.
+				rotateCols(a, p, q, c, s)
+				for k := 0; k < n; k++ {
+					x, y := a.vals[p*n+k], a.vals[q*n+k]
+					a.vals[p*n+k] = c*x - s*y
+					a.vals[q*n+k] = s*x + c*y
+				}
+				a.vals[p*n+q], a.vals[q*n+p] = 0, 0
+				rotateCols(v, p, q, c, s)
+			}
+		}
+	}
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = a.vals[i*n+i]
+	}
+	for j := range vals {
+		k := j
+		for i := j + 1; i < n; i++ {
+			if vals[i] > vals[k] {
+				k = i
+			}
+		}
+		if k != j {
+			vals[j], vals[k] = vals[k], vals[j]
+			swapCols(v, j, k)
+		}
+	}
+	return vals, v
+}
+
+// hessenbergEigenvalues returns the eigenvalues of the upper Hessenberg mat
+// h with the shifted QR algorithm, and false if it does not converge. h is
+// overwritten.
+func hessenbergEigenvalues(h [][]complex128) ([]complex128, bool) {
+	n := len(h)
+	eig := make([]complex128, n)
+	iter := 0
+	for hi := n - 1; hi >= 0; {
+		l := hi
+		for l > 0 && cmplx.Abs(h[l][l-1]) > 1e-15*(cmplx.Abs(h[l][l])+cmplx.Abs(h[l-1][l-1])) {
+			l--
+		}
+		if l == hi {
+			eig[hi] = h[hi][hi]
+			hi--
+			iter = 0
+			continue
+		}
+		if iter++; iter > 100 {
+			return eig, false
+		}
+		// The Wilkinson shift is the eigenvalue of the trailing 2X2 block
+		// closest to its last diagonal element, with an exceptional shift
+		// now and then to break cycles.
+		a, b, c, d := h[hi-1][hi-1], h[hi-1][hi], h[hi][hi-1], h[hi][hi]
+		disc := cmplx.Sqrt((a-d)*(a-d)/4 + b*c)
+		mu := (a+d)/2 + disc
+		if mu2 := (a+d)/2 - disc; cmplx.Abs(mu2-d) < cmplx.Abs(mu-d) {
+			mu = mu2
+		}
+		if iter%10 == 0 {
+			mu += complex(cmplx.Abs(c), 0)
+		}
+		for i := l; i <= hi; i++ {
+			h[i][i] -= mu
+		}
+		// h - mu = q*r with Givens rotations, then h = r*q + mu.
+		cs := make([]float64, hi-l)
+		sn := make([]complex128, hi-l)
+		for i := l; i < hi; i++ {
+			x, y := h[i][i], h[i+1][i]
+			r := math.Hypot(cmplx.Abs(x), cmplx.Abs(y))
+			if r == 0 {
+				cs[i-l], sn[i-l] = 1, 0
+				continue
+			}
+			if x == 0 {
+				cs[i-l], sn[i-l] = 0, cmplx.Conj(y)/complex(r, 0)
+			} else {
+				cs[i-l] = cmplx.Abs(x) / r
+				sn[i-l] = x / complex(cmplx.Abs(x), 0) * cmplx.Conj(y) / complex(r, 0)
+			}
+			c, s := complex(cs[i-l], 0), sn[i-l]
+			for j := i; j <= hi; j++ {
+				x, y := h[i][j], h[i+1][j]
+				h[i][j] = c*x + s*y
+				h[i+1][j] = -cmplx.Conj(s)*x + c*y
+			}
+		}
+		for i := l; i < hi; i++ {
+			c, s := complex(cs[i-l], 0), sn[i-l]
+			for j := l; j <= hi; j++ {
+				x, y := h[j][i], h[j][i+1]
+				h[j][i] = c*x + cmplx.Conj(s)*y
+				h[j][i+1] = -s*x + c*y
+			}
+		}
+		for i := l; i <= hi; i++ {
+			h[i][i] += mu
+		}
+	}
+	return eig, true
+}
+
+// sortEigenvalues sorts eig by decreasing magnitude for EigenLargest, and by
+// increasing magnitude for EigenSmallest. Ties are broken by the real part
+// and then by the imaginary part, in decreasing order.
+func sortEigenvalues(eig []complex128, which EigenWhich) {
+	sort.Slice(eig, func(i, j int) bool {
+		a, b := cmplx.Abs(eig[i]), cmplx.Abs(eig[j])
+		if math.Abs(a-b) > 1e-12*math.Max(a, b) {
+			return (a > b) == (which == EigenLargest)
+		}
+		if real(eig[i]) != real(eig[j]) {
+			return real(eig[i]) > real(eig[j])
+		}
+		return imag(eig[i]) > imag(eig[j])
+	})
+}
+
+// hessenbergEigenvector returns an eigenvector of unit length of the leading
+// square block of the Hessenberg mat h for its eigenvalue lambda, with two
+// steps of inverse iteration.
+func hessenbergEigenvector(h [][]float64, lambda complex128) []complex128 {
+	n := len(h[0])
+	norm := 0.0
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			norm = math.Max(norm, math.Abs(h[i][j]))
+		}
+	}
+	// The shift is moved off lambda so that the system is not exactly
+	// singular.
+	shift := lambda + complex(1e-10*(norm+1), 0)
+	y := make([]complex128, n)
+	for i := range y {
+		y[i] = 1
+	}
+	for step := 0; step < 2; step++ {
+		a := make([][]complex128, n)
+		for i := range a {
+			a[i] = make([]complex128, n+1)
+			for j := 0; j < n; j++ {
+				a[i][j] = complex(h[i][j], 0)
+			}
+			a[i][i] -= shift
+			a[i][n] = y[i]
+		}
+		// Gaussian elimination with partial pivoting.
+		for j := 0; j < n; j++ {
+			p := j
+			for i := j + 1; i < n; i++ {
+				if cmplx.Abs(a[i][j]) > cmplx.Abs(a[p][j]) {
+					p = i
+				}
+			}
+			a[j], a[p] = a[p], a[j]
+			if a[j][j] == 0 {
+				a[j][j] = complex(1e-300, 0)
+			}
+			for i := j + 1; i < n; i++ {
+				f := a[i][j] / a[j][j]
+				for l := j; l <= n; l++ {
+					a[i][l] -= f * a[j][l]
+				}
+			}
+		}
+		for i := n - 1; i >= 0; i-- {
+			s := a[i][n]
+			for j := i + 1; j < n; j++ {
+				s -= a[i][j] * y[j]
+			}
+			y[i] = s / a[i][i]
+		}
+		norm := 0.0
+		for _, x := range y {
+			norm = math.Hypot(norm, cmplx.Abs(x))
+		}
+		for i := range y {
+			y[i] /= complex(norm, 0)
+		}
+	}
+	return y
+}
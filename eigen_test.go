@@ -0,0 +1,124 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPowerIterationf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{2, 1}, {1, 2}})
+	lambda, v, err := m.PowerIteration(1e-12, 100)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 3, lambda, 1e-10, "should be equal")
+	assert.InDeltaSlice(t, []float64{math.Sqrt2 / 2, math.Sqrt2 / 2}, v, 1e-10, "should be equal")
+
+	// The dominant eigenvalue may be negative.
+	m = Matf64FromData([][]float64{{-5, 0, 0}, {0, 2, 0}, {0, 0, 1}})
+	lambda, v, err = m.PowerIteration(1e-12, 100)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, -5, lambda, 1e-10, "should be equal")
+	assert.InDeltaSlice(t, []float64{1, 0, 0}, v, 1e-10, "should be equal")
+
+	// PageRank of a small column stochastic link mat.
+	links := Matf64FromData([][]float64{{0, 0.5, 1}, {0.5, 0, 0}, {0.5, 0.5, 0}})
+	lambda, v, err = links.PowerIteration(1e-12, 1000)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 1, lambda, 1e-10, "should be equal")
+	assert.InDeltaSlice(t, []float64{2, 1, 1.5}, []float64{v[0] / v[1] * 1, 1, v[2] / v[1]}, 1e-9, "should be equal")
+
+	// The dominant eigenvectors of these mats are orthogonal to the vector of
+	// ones, which must not stop the iteration from finding them.
+	lambda, v, err = Matf64FromData([][]float64{{3, -2}, {-2, 3}}).PowerIteration(1e-12, 100)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 5, lambda, 1e-10, "should be equal")
+	assert.InDelta(t, 0, v[0]+v[1], 1e-10, "should be equal")
+	lambda, _, err = Matf64FromData([][]float64{{1, -1}, {-1, 1}}).PowerIteration(1e-12, 100)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 2, lambda, 1e-10, "should be equal")
+
+	_, _, err = Matf64FromData([][]float64{{1, 0}, {0, -1}}).PowerIteration(1e-12, 50)
+	assert.EqualError(t, err, "In PowerIteration(): no convergence after 50 iterations")
+
+	lambda, v, err = Newf64(2, 2).PowerIteration(1e-12, 10)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, 0.0, lambda, "should be equal")
+	assert.Equal(t, 2, len(v), "should be equal")
+}
+
+// eigenTestMat returns q*b*qᵀ for a random orthogonal q, which has the same
+// eigenvalues as b.
+func eigenTestMat(b *Matf64) *Matf64 {
+	n, _ := b.Shape()
+	q := RandnMatf64With(rand.New(rand.NewSource(5)), n, n)
+	orthonormalize(q)
+	return q.Dot(b).DotT(q)
+}
+
+func TestLanczosf64(t *testing.T) {
+	t.Helper()
+	d := make([]float64, 60)
+	for i := range d {
+		d[i] = float64(i + 1)
+	}
+	m := eigenTestMat(Diagf64(d))
+	m = m.Add(m.Copy().T()).Mul(0.5)
+	vals, vecs, err := m.Lanczos(3, EigenLargest)
+	assert.Nil(t, err, "should not fail")
+	assert.InDeltaSlice(t, []float64{60, 59, 58}, vals, 1e-8, "should be equal")
+	for j, lambda := range vals {
+		v := vecs.Col(j)
+		assert.InDeltaSlice(t, v.Copy().Mul(lambda).vals, m.Dot(v).vals, 1e-6, "should be equal")
+	}
+
+	vals, vecs, err = m.Lanczos(2, EigenSmallest)
+	assert.Nil(t, err, "should not fail")
+	assert.InDeltaSlice(t, []float64{1, 2}, vals, 1e-8, "should be equal")
+	for j, lambda := range vals {
+		v := vecs.Col(j)
+		assert.InDeltaSlice(t, v.Copy().Mul(lambda).vals, m.Dot(v).vals, 1e-6, "should be equal")
+	}
+
+	// The path graph Laplacian, small enough to be solved at once.
+	l := Matf64FromData([][]float64{{1, -1, 0}, {-1, 2, -1}, {0, -1, 1}})
+	vals, vecs, err = l.Lanczos(1, EigenSmallest)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 0, vals[0], 1e-12, "should be equal")
+	c := 1 / math.Sqrt(3)
+	assert.InDeltaSlice(t, []float64{c, c, c}, vecs.Abs().vals, 1e-12, "should be equal")
+}
+
+func TestArnoldif64(t *testing.T) {
+	t.Helper()
+	n := 40
+	b := Newf64(n, n)
+	// A pair of complex eigenvalues 10±3i, then the real ones 8, 7, ...
+	b.Set(0, 0, 10).Set(0, 1, -3).Set(1, 0, 3).Set(1, 1, 10)
+	for i := 2; i < n; i++ {
+		b.Set(i, i, float64(n-i)/4)
+		if i+1 < n {
+			b.Set(i, i+1, 0.1)
+		}
+	}
+	m := eigenTestMat(b)
+	vals, re, im, err := m.Arnoldi(3, EigenLargest)
+	assert.Nil(t, err, "should not fail")
+	want := []complex128{complex(10, 3), complex(10, -3), 9.5}
+	for i := range want {
+		assert.InDelta(t, real(want[i]), real(vals[i]), 1e-8, "should be equal")
+		assert.InDelta(t, imag(want[i]), imag(vals[i]), 1e-8, "should be equal")
+		// m*x = λ*x, split into real and imaginary parts.
+		x, y := re.Col(i), im.Col(i)
+		l, u := real(vals[i]), imag(vals[i])
+		assert.InDeltaSlice(t, x.Copy().Mul(l).Sub(y.Copy().Mul(u)).vals, m.Dot(x).vals, 1e-6, "should be equal")
+		assert.InDeltaSlice(t, y.Copy().Mul(l).Add(x.Copy().Mul(u)).vals, m.Dot(y).vals, 1e-6, "should be equal")
+	}
+	assert.InDeltaSlice(t, make([]float64, n), im.Col(2).vals, 1e-12, "should be real")
+
+	vals, _, _, err = m.Arnoldi(1, EigenSmallest)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 0.25, real(vals[0]), 1e-8, "should be equal")
+}
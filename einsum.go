@@ -0,0 +1,262 @@
+package matrix
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+Einsum evaluates a product of mats given in Einstein notation, as numpy's
+einsum does. The subscripts name the axes of each operand with letters,
+separated by commas, and optionally the axes of the result after "->".
+Axes with the same letter are multiplied together, and axes that are not in
+the result are summed over. For example:
+
+	matrix.Einsum("ij,jk->ik", a, b)    // a.Dot(b)
+	matrix.Einsum("ij,kj->ik", a, b)    // a.Dot(b.T())
+	matrix.Einsum("ii->", a)            // the trace of a
+	matrix.Einsum("ii->i", a)           // the diagonal of a
+	matrix.Einsum("ij->j", a)           // the sums of the columns of a
+	matrix.Einsum("ij,ij->", a, b)      // the sum of a * b elementwise
+	matrix.Einsum("i,j->ij", u, v)      // the outer product of u and v
+	matrix.Einsum("ij,jk,kl->il", a, b, c)
+
+Each operand has 1 or 2 subscripts: with 2 it is a mat, and with 1 it must
+be a row or a column vector. Without "->", the result has the letters that
+appear exactly once, in alphabetical order. The result has at most 2 axes:
+with 2 it is a mat, with 1 it is a row vector, and with none it is a 1X1
+mat.
+
+Operands are contracted two at a time from the left while the intermediate
+results have at most 2 axes, and products of two mats are computed with
+Gemmf64, after transposing copies of the operands as needed. The remaining
+cases are computed with a direct loop over all the subscripts. An error is
+returned if the subscripts are malformed or do not match the operands. The
+operands are left intact.
+*/
+func Einsum(subscripts string, operands ...*Matf64) (*Matf64, error) {
+	ops, out, dims, err := parseEinsum(subscripts, operands)
+	if err != nil {
+		return nil, err
+	}
+	for len(ops) > 2 {
+		later := out
+		for _, op := range ops[2:] {
+			later += op.idx
+		}
+		keep := ""
+		for _, l := range ops[0].idx + ops[1].idx {
+			if strings.ContainsRune(later, l) && !strings.ContainsRune(keep, l) {
+				keep += string(l)
+			}
+		}
+		if len(keep) > 2 {
+			break
+		}
+		op := einsumOp{idx: keep, m: contractEinsum(ops[:2], keep, dims)}
+		ops = append([]einsumOp{op}, ops[2:]...)
+	}
+	return contractEinsum(ops, out, dims), nil
+}
+
+// einsumOp is an operand of Einsum, with the letters of its axes.
+type einsumOp struct {
+	idx string
+	m   *Matf64
+}
+
+// parseEinsum checks the subscripts against the operands, and returns the
+// operands with their letters, the letters of the result, and the size of
+// the axis named by each letter.
+func parseEinsum(subscripts string, operands []*Matf64) ([]einsumOp, string, map[rune]int, error) {
+	fn := "Einsum()"
+	spec := strings.Replace(subscripts, " ", "", -1)
+	in, out := spec, ""
+	explicit := strings.Contains(spec, "->")
+	if explicit {
+		parts := strings.SplitN(spec, "->", 2)
+		in, out = parts[0], parts[1]
+	}
+	inputs := strings.Split(in, ",")
+	if len(inputs) != len(operands) {
+		return nil, "", nil, fmt.Errorf("In %s: the subscripts have %d operands, but %d mats were passed", fn, len(inputs), len(operands))
+	}
+	for _, l := range in + out {
+		if l != ',' && !(l >= 'a' && l <= 'z' || l >= 'A' && l <= 'Z') {
+			return nil, "", nil, fmt.Errorf("In %s: invalid character %q in the subscripts", fn, l)
+		}
+	}
+	ops := make([]einsumOp, len(inputs))
+	dims := make(map[rune]int)
+	counts := make(map[rune]int)
+	for k, idx := range inputs {
+		m := operands[k]
+		var shape []int
+		switch len(idx) {
+		case 1:
+			if m.r != 1 && m.c != 1 {
+				return nil, "", nil, fmt.Errorf("In %s: operand %d has 1 subscript, but it is a %dX%d mat", fn, k, m.r, m.c)
+			}
+			shape = []int{m.r * m.c}
+		case 2:
+			shape = []int{m.r, m.c}
+		default:
+			return nil, "", nil, fmt.Errorf("In %s: operand %d has %d subscripts, but it must have 1 or 2", fn, k, len(idx))
+		}
+		for i, l := range idx {
+			if d, ok := dims[l]; ok && d != shape[i] {
+				return nil, "", nil, fmt.Errorf("In %s: the axis %q has size %d in operand %d, but %d before", fn, l, shape[i], k, d)
+			}
+			dims[l] = shape[i]
+			counts[l]++
+		}
+		ops[k] = einsumOp{idx: idx, m: m}
+	}
+	if !explicit {
+		var once []string
+		for l, n := range counts {
+			if n == 1 {
+				once = append(once, string(l))
+			}
+		}
+		sort.Strings(once)
+		out = strings.Join(once, "")
+	}
+	if len(out) > 2 {
+		return nil, "", nil, fmt.Errorf("In %s: the result has %d subscripts, but it can have at most 2", fn, len(out))
+	}
+	for i, l := range out {
+		if counts[l] == 0 {
+			return nil, "", nil, fmt.Errorf("In %s: the result subscript %q is not in any operand", fn, l)
+		}
+		if strings.IndexRune(out, l) != i {
+			return nil, "", nil, fmt.Errorf("In %s: the result subscript %q is repeated", fn, l)
+		}
+	}
+	return ops, out, dims, nil
+}
+
+// contractEinsum returns the contraction of ops into the axes out, with Gemmf64
+// when it is a product of two mats, and with a direct loop otherwise.
+func contractEinsum(ops []einsumOp, out string, dims map[rune]int) *Matf64 {
+	if len(ops) == 2 {
+		if o := gemmEinsum(ops[0], ops[1], out); o != nil {
+			return o
+		}
+	}
+	var letters []rune
+	for _, l := range out {
+		letters = append(letters, l)
+	}
+	for _, op := range ops {
+		for _, l := range op.idx {
+			if !strings.ContainsRune(string(letters), l) {
+				letters = append(letters, l)
+			}
+		}
+	}
+	pos := make(map[rune]int, len(letters))
+	for i, l := range letters {
+		pos[l] = i
+	}
+	shape := []int{1, 1}
+	switch len(out) {
+	case 1:
+		shape[1] = dims[rune(out[0])]
+	case 2:
+		shape[0], shape[1] = dims[rune(out[0])], dims[rune(out[1])]
+	}
+	o := Newf64(shape[0], shape[1])
+	for _, d := range dims {
+		if d == 0 {
+			return o
+		}
+	}
+	// strides[k][i] is the step in the values of operand k for letter i.
+	strides := make([][]int, len(ops))
+	for k, op := range ops {
+		strides[k] = make([]int, len(letters))
+		step := []int{1}
+		if len(op.idx) == 2 {
+			step = []int{op.m.c, 1}
+		}
+		for i, l := range op.idx {
+			strides[k][pos[l]] += step[i]
+		}
+	}
+	ostride := make([]int, len(letters))
+	if len(out) == 2 {
+		ostride[0], ostride[1] = shape[1], 1
+	} else if len(out) == 1 {
+		ostride[0] = 1
+	}
+	idx := make([]int, len(letters))
+	offs := make([]int, len(ops))
+	ooff := 0
+	for {
+		p := 1.0
+		for k, op := range ops {
+			p *= op.m.vals[offs[k]]
+		}
+		o.vals[ooff] += p
+		// Advance the last letter, carrying into the previous ones.
+		i := len(letters) - 1
+		for ; i >= 0; i-- {
+			l := letters[i]
+			idx[i]++
+			for k := range ops {
+				offs[k] += strides[k][i]
+			}
+			ooff += ostride[i]
+			if idx[i] < dims[l] {
+				break
+			}
+			for k := range ops {
+				offs[k] -= strides[k][i] * idx[i]
+			}
+			ooff -= ostride[i] * idx[i]
+			idx[i] = 0
+		}
+		if i < 0 {
+			return o
+		}
+	}
+}
+
+// gemmEinsum returns the contraction of two mats over one shared axis, in
+// which each of out is a free axis of a or b, with Gemmf64. It returns nil
+// for any other contraction.
+func gemmEinsum(a, b einsumOp, out string) *Matf64 {
+	if len(a.idx) != 2 || len(b.idx) != 2 || a.idx[0] == a.idx[1] || b.idx[0] == b.idx[1] || len(out) != 2 {
+		return nil
+	}
+	var shared, fa, fb byte
+	n := 0
+	for i := 0; i < 2; i++ {
+		if strings.IndexByte(b.idx, a.idx[i]) >= 0 {
+			shared = a.idx[i]
+			n++
+		} else {
+			fa = a.idx[i]
+		}
+		if strings.IndexByte(a.idx, b.idx[i]) < 0 {
+			fb = b.idx[i]
+		}
+	}
+	if n != 1 || strings.IndexByte(out, shared) >= 0 || strings.IndexByte(out, fa) < 0 || strings.IndexByte(out, fb) < 0 {
+		return nil
+	}
+	am, bm := a.m, b.m
+	if a.idx[1] != shared {
+		am = am.Copy().T()
+	}
+	if b.idx[0] != shared {
+		bm = bm.Copy().T()
+	}
+	o := Gemmf64(1, am, bm, 0, Newf64(am.r, bm.c))
+	if out[0] == fb {
+		o.T()
+	}
+	return o
+}
@@ -0,0 +1,62 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEinsum(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := Matf64FromData([][]float64{{1, 0}, {2, -1}, {0, 3}})
+	sq := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+
+	einsum := func(spec string, ops ...*Matf64) *Matf64 {
+		o, err := Einsum(spec, ops...)
+		assert.Nil(t, err, "should not fail")
+		return o
+	}
+	ab := a.Dot(b)
+	assert.True(t, ab.Equals(einsum("ij,jk->ik", a, b)), "should be equal")
+	assert.True(t, ab.Equals(einsum("ij,jk", a, b)), "should be equal")
+	assert.True(t, ab.Copy().T().Equals(einsum("ij,jk->ki", a, b)), "should be equal")
+	assert.True(t, ab.Equals(einsum("ij,kj->ik", a, b.Copy().T())), "should be equal")
+	assert.True(t, ab.Equals(einsum("ji,jk->ik", a.Copy().T(), b)), "should be equal")
+	// The same mat may be passed twice.
+	assert.True(t, sq.Dot(sq).Equals(einsum("ij,jk->ik", sq, sq)), "should be equal")
+	assert.True(t, ab.Dot(sq).Equals(einsum("ij,jk,kl->il", a, b, sq)), "should be equal")
+
+	assert.Equal(t, []float64{5}, einsum("ii->", sq).ToSlice1D(), "should be the trace")
+	assert.Equal(t, []float64{5}, einsum("ii", sq).ToSlice1D(), "should be the trace")
+	assert.Equal(t, []float64{1, 4}, einsum("ii->i", sq).ToSlice1D(), "should be the diagonal")
+	assert.Equal(t, []float64{5, 7, 9}, einsum("ij->j", a).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{6, 15}, einsum("ij->i", a).ToSlice1D(), "should be equal")
+	assert.True(t, a.Copy().T().Equals(einsum("ij->ji", a)), "should be equal")
+	assert.Equal(t, []float64{30}, einsum("ij,ij->", sq, sq).ToSlice1D(), "should be equal")
+
+	u := Matf64FromData([]float64{1, 2})
+	v := Matf64FromData([]float64{3, 4, 5}).Reshape(3, 1)
+	assert.Equal(t, [][]float64{{3, 4, 5}, {6, 8, 10}}, einsum("i,j->ij", u, v).ToSlice2D(), "should be equal")
+	assert.Equal(t, []float64{26, 62}, einsum("ij,j->i", a, v).ToSlice1D(), "should be equal")
+	assert.Equal(t, []float64{9, 12, 15}, einsum("i,ij->j", u, a).ToSlice1D(), "should be equal")
+}
+
+func TestEinsumErrors(t *testing.T) {
+	t.Helper()
+	a := Newf64(2, 3)
+	_, err := Einsum("ij,jk->ik", a)
+	assert.EqualError(t, err, "In Einsum(): the subscripts have 2 operands, but 1 mats were passed")
+	_, err = Einsum("ij,jk->ik", a, a)
+	assert.EqualError(t, err, "In Einsum(): the axis 'j' has size 2 in operand 1, but 3 before")
+	_, err = Einsum("i->i", a)
+	assert.EqualError(t, err, "In Einsum(): operand 0 has 1 subscript, but it is a 2X3 mat")
+	_, err = Einsum("ij->k", a)
+	assert.EqualError(t, err, "In Einsum(): the result subscript 'k' is not in any operand")
+	_, err = Einsum("ij->ii", a)
+	assert.EqualError(t, err, "In Einsum(): the result subscript 'i' is repeated")
+	_, err = Einsum("i.j", a)
+	assert.EqualError(t, err, "In Einsum(): invalid character '.' in the subscripts")
+	_, err = Einsum("ij,kl", a, a)
+	assert.EqualError(t, err, "In Einsum(): the result has 4 subscripts, but it can have at most 2")
+}
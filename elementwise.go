@@ -0,0 +1,330 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gorgonia/vecf64"
+)
+
+/*
+Exp replaces every element x of the mat with e**x, in place, and returns the
+mat so that calls can be chained.
+*/
+func (m *Matf64) Exp() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Exp(v)
+	}
+	return m
+}
+
+/*
+Log replaces every element x of the mat with its natural logarithm, in
+place. Just like math.Log, zeros become -Inf and negative values become NaN.
+*/
+func (m *Matf64) Log() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Log(v)
+	}
+	return m
+}
+
+/*
+Log2 replaces every element x of the mat with its binary logarithm, in
+place. Zeros become -Inf and negative values become NaN.
+*/
+func (m *Matf64) Log2() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Log2(v)
+	}
+	return m
+}
+
+/*
+Sqrt replaces every element x of the mat with its square root, in place.
+Negative values become NaN.
+*/
+func (m *Matf64) Sqrt() *Matf64 {
+	m.materialize()
+	vecf64.Sqrt(m.vals)
+	return m
+}
+
+/*
+Abs replaces every element x of the mat with its absolute value, in place.
+*/
+func (m *Matf64) Abs() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Abs(v)
+	}
+	return m
+}
+
+/*
+Sin replaces every element x of the mat with its sine, in place. The
+elements are taken to be in radians.
+*/
+func (m *Matf64) Sin() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Sin(v)
+	}
+	return m
+}
+
+/*
+Cos replaces every element x of the mat with its cosine, in place. The
+elements are taken to be in radians.
+*/
+func (m *Matf64) Cos() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Cos(v)
+	}
+	return m
+}
+
+/*
+Tan replaces every element x of the mat with its tangent, in place. The
+elements are taken to be in radians.
+*/
+func (m *Matf64) Tan() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Tan(v)
+	}
+	return m
+}
+
+/*
+Asin replaces every element x of the mat with its arcsine, in radians, in
+place. Values outside of [-1, 1] become NaN.
+*/
+func (m *Matf64) Asin() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Asin(v)
+	}
+	return m
+}
+
+/*
+Acos replaces every element x of the mat with its arccosine, in radians, in
+place. Values outside of [-1, 1] become NaN.
+*/
+func (m *Matf64) Acos() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Acos(v)
+	}
+	return m
+}
+
+/*
+Atan replaces every element x of the mat with its arctangent, in radians, in
+place.
+*/
+func (m *Matf64) Atan() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Atan(v)
+	}
+	return m
+}
+
+/*
+Sinh replaces every element x of the mat with its hyperbolic sine, in place.
+*/
+func (m *Matf64) Sinh() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Sinh(v)
+	}
+	return m
+}
+
+/*
+Cosh replaces every element x of the mat with its hyperbolic cosine, in place.
+*/
+func (m *Matf64) Cosh() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Cosh(v)
+	}
+	return m
+}
+
+/*
+Tanh replaces every element x of the mat with its hyperbolic tangent, in place.
+*/
+func (m *Matf64) Tanh() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Tanh(v)
+	}
+	return m
+}
+
+/*
+Asinh replaces every element x of the mat with its inverse hyperbolic sine,
+in place.
+*/
+func (m *Matf64) Asinh() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Asinh(v)
+	}
+	return m
+}
+
+/*
+Acosh replaces every element x of the mat with its inverse hyperbolic
+cosine, in place. Values less than 1 become NaN.
+*/
+func (m *Matf64) Acosh() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Acosh(v)
+	}
+	return m
+}
+
+/*
+Atanh replaces every element x of the mat with its inverse hyperbolic
+tangent, in place. Values outside of [-1, 1] become NaN, while -1 and 1
+become -Inf and +Inf.
+*/
+func (m *Matf64) Atanh() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Atanh(v)
+	}
+	return m
+}
+
+/*
+Sign replaces every element of the mat with -1 if it is negative, 1 if it is
+positive, and 0 if it is zero, in place. NaNs are left untouched.
+*/
+func (m *Matf64) Sign() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		switch {
+		case v < 0:
+			m.vals[i] = -1
+		case v > 0:
+			m.vals[i] = 1
+		case v == 0:
+			m.vals[i] = 0
+		}
+	}
+	return m
+}
+
+/*
+Heaviside replaces every element of the mat with the Heaviside step function
+of that element, in place: 0 for negative values and 1 for positive values.
+NaNs are left untouched. The value used for zeros can be passed as an
+argument, and defaults to 0.5:
+
+	m.Heaviside()  // zeros become 0.5
+	m.Heaviside(1) // zeros become 1
+*/
+func (m *Matf64) Heaviside(args ...float64) *Matf64 {
+	h0 := 0.5
+	switch len(args) {
+	case 0:
+	case 1:
+		h0 = args[0]
+	default:
+		s := "\nIn %s, expected 0 or 1 arguments, but received %d.\n"
+		s = fmt.Sprintf(s, "Heaviside()", len(args))
+		printErr(s)
+	}
+	m.materialize()
+	for i, v := range m.vals {
+		switch {
+		case v < 0:
+			m.vals[i] = 0
+		case v > 0:
+			m.vals[i] = 1
+		case v == 0:
+			m.vals[i] = h0
+		}
+	}
+	return m
+}
+
+/*
+Mod replaces every element of the mat with the remainder of its division by
+x, in place, using math.Mod. The result has the sign of the element, so for
+example -7 mod 3 is -1. To wrap phases into [0, 2*Pi), add 2*Pi to the
+negative results afterwards.
+*/
+func (m *Matf64) Mod(x float64) *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = math.Mod(v, x)
+	}
+	return m
+}
+
+/*
+ModElem replaces every element of the mat with the remainder of its division
+by the corresponding element of n, in place, using math.Mod. Both mats must
+have the same shape.
+*/
+func (m *Matf64) ModElem(n *Matf64) *Matf64 {
+	checkSameShape("ModElem()", m, n)
+	m.materialize()
+	for i, v := range n.vals {
+		m.vals[i] = math.Mod(m.vals[i], v)
+	}
+	return m
+}
+
+// checkSameShape makes sure that m and n have the same number of rows and
+// columns.
+func checkSameShape(fn string, m, n *Matf64) {
+	if m.r != n.r || m.c != n.c {
+		s := "\nIn %s, the receiver is %dx%d but the passed mat is %dx%d.\n"
+		s += "They must have the same shape.\n"
+		s = fmt.Sprintf(s, fn, m.r, m.c, n.r, n.c)
+		printHelperErr(s)
+	}
+}
+
+/*
+Reciprocal replaces every element x of the mat with 1/x, in place. Zeros
+become +Inf or -Inf, depending on their sign; use DivSafe on a mat of ones to
+replace them with a finite value instead.
+*/
+func (m *Matf64) Reciprocal() *Matf64 {
+	m.materialize()
+	for i, v := range m.vals {
+		m.vals[i] = 1 / v
+	}
+	return m
+}
+
+/*
+ZipWith combines every element of the mat with the corresponding element of
+n, in place, by setting it to f(a, b), where a is the element of the
+receiver and b the element of n. Both mats must have the same shape. It
+extends Add, Sub, Mul and Div to any element-wise operation, for example:
+
+	m.ZipWith(n, math.Hypot)
+	m.ZipWith(n, math.Max)
+*/
+func (m *Matf64) ZipWith(n *Matf64, f func(a, b float64) float64) *Matf64 {
+	checkSameShape("ZipWith()", m, n)
+	m.materialize()
+	for i, b := range n.vals {
+		m.vals[i] = f(m.vals[i], b)
+	}
+	return m
+}
@@ -0,0 +1,110 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpLogf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{0, 1, -2.5, 10})
+	n := m.Copy().Exp()
+	for i, v := range m.vals {
+		assert.Equal(t, math.Exp(v), n.vals[i], "should be equal")
+	}
+	n.Log()
+	for i, v := range m.vals {
+		assert.InDelta(t, v, n.vals[i], 1e-12, "should undo Exp")
+	}
+	m = Matf64FromData([]float64{1, 8, 0.5, 0, -1})
+	m.Log2()
+	assert.Equal(t, []float64{0, 3, -1}, m.vals[:3], "should be equal")
+	assert.True(t, math.IsInf(m.vals[3], -1), "log of 0 should be -Inf")
+	assert.True(t, math.IsNaN(m.vals[4]), "log of a negative should be NaN")
+}
+
+func TestSqrtAbsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{-4, 9, -0.25, 0})
+	m.Abs()
+	assert.Equal(t, []float64{4, 9, 0.25, 0}, m.vals, "should be equal")
+	m.Sqrt()
+	assert.Equal(t, []float64{2, 3, 0.5, 0}, m.vals, "should be equal")
+
+	n := Matf64FromData([]float64{4})
+	c := n.CloneCOW().Sqrt()
+	assert.Equal(t, 4.0, n.vals[0], "should not modify the original")
+	assert.Equal(t, 2.0, c.vals[0], "should be equal")
+}
+
+func TestTrigf64(t *testing.T) {
+	t.Helper()
+	vals := []float64{-0.9, -0.3, 0, 0.5, 0.99}
+	fns := []struct {
+		method func(*Matf64) *Matf64
+		f      func(float64) float64
+	}{
+		{(*Matf64).Sin, math.Sin},
+		{(*Matf64).Cos, math.Cos},
+		{(*Matf64).Tan, math.Tan},
+		{(*Matf64).Asin, math.Asin},
+		{(*Matf64).Acos, math.Acos},
+		{(*Matf64).Atan, math.Atan},
+		{(*Matf64).Sinh, math.Sinh},
+		{(*Matf64).Cosh, math.Cosh},
+		{(*Matf64).Tanh, math.Tanh},
+		{(*Matf64).Asinh, math.Asinh},
+		{(*Matf64).Atanh, math.Atanh},
+	}
+	for _, fn := range fns {
+		m := fn.method(Matf64FromData(vals))
+		for i, v := range vals {
+			assert.Equal(t, fn.f(v), m.vals[i], "should be equal")
+		}
+	}
+	m := Matf64FromData([]float64{1, 2, 0.5}).Acosh()
+	assert.Equal(t, 0.0, m.vals[0], "should be equal")
+	assert.Equal(t, math.Acosh(2), m.vals[1], "should be equal")
+	assert.True(t, math.IsNaN(m.vals[2]), "should be NaN")
+}
+
+func TestSignf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{-3, 0, 0.1, math.NaN()}).Sign()
+	assert.Equal(t, []float64{-1, 0, 1}, m.vals[:3], "should be equal")
+	assert.True(t, math.IsNaN(m.vals[3]), "should stay NaN")
+}
+
+func TestHeavisidef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{-3, 0, 0.1})
+	assert.Equal(t, []float64{0, 0.5, 1}, m.Copy().Heaviside().vals, "should be equal")
+	assert.Equal(t, []float64{0, 1, 1}, m.Heaviside(1).vals, "should be equal")
+}
+
+func TestModf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{7, -7, 7.5, 0})
+	assert.Equal(t, []float64{1, -1, 1.5, 0}, m.Copy().Mod(3).vals, "should be equal")
+	n := Matf64FromData([]float64{2, 4, 0.5, 1})
+	assert.Equal(t, []float64{1, -3, 0, 0}, m.ModElem(n).vals, "should be equal")
+}
+
+func TestReciprocalf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{2, -0.5, 0}).Reciprocal()
+	assert.Equal(t, []float64{0.5, -2}, m.vals[:2], "should be equal")
+	assert.True(t, math.IsInf(m.vals[2], 1), "should be +Inf")
+}
+
+func TestZipWithf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{3, 5}, {-1, 8}})
+	n := Matf64FromData([][]float64{{4, 12}, {2, 6}})
+	assert.Equal(t, []float64{4, 12, 2, 8}, m.Copy().ZipWith(n, math.Max).vals, "should be equal")
+	m.ZipWith(n, math.Hypot)
+	assert.Equal(t, []float64{5, 13}, m.vals[:2], "should be equal")
+	assert.Equal(t, 4.0, n.vals[0], "should leave the argument intact")
+}
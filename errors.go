@@ -8,11 +8,10 @@ import (
 )
 
 var (
-	wrongArity    = "In %s: expected %s arguments, got %d"
-	wrongArgType  = "In %s: expected input type %s, got %T"
-	sizeMismatch  = "In %s: size mismatch: %dx%d vs %dx%d"
-	colOutOfBound = "In %s the column %d is outside of bounds [%d, %d)"
-	rowOutOfBound = "In %s the column %d is outside of bounds [%d, %d)"
+	wrongArity   = "In %s: expected %s arguments, got %d"
+	wrongArgType = "In %s: expected input type %s, got %T"
+	sizeMismatch = "In %s: size mismatch: %dx%d vs %dx%d"
+	wrongLength  = "In %s: a %dx%d mat cannot hold %d values"
 )
 
 func printErr(s string) {
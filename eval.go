@@ -0,0 +1,281 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+/*
+Eval evaluates a formula combining mats and scalars, which makes it possible
+to describe a computation in a configuration file, or to explore data
+interactively. For example:
+
+	o, err := matrix.Eval("A' * B + 0.5*C", map[string]*Matf64{
+		"A": a, "B": b, "C": c,
+	})
+
+The formula may use the names of the given mats, numbers, parentheses, and
+the following operators, from the highest to the lowest precedence:
+
+	'           transpose, as in A'
+	-           negation, as in -A
+	* .* / ./   matrix product, elementwise product, and division
+	+ -         addition and subtraction
+
+The matrix product of two mats is computed with Dot, while the product of a
+scalar and a mat multiplies every value of the mat. Division is elementwise,
+and scalars can be added to or subtracted from mats. The given mats are never
+modified, but the intermediate results are reused for the following
+operations, so that A + B + C only allocates one mat. An error is returned
+when the formula is malformed, uses an unknown name, or combines mats of
+incompatible shapes. The result is a 1X1 mat when the formula only involves
+scalars.
+*/
+func Eval(expr string, vars map[string]*Matf64) (*Matf64, error) {
+	p := &evalParser{expr: expr, vars: vars}
+	p.next()
+	v, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, p.errorf("unexpected %q", p.tok)
+	}
+	if v.m == nil {
+		return Matf64FromData([]float64{v.s}), nil
+	}
+	if !v.tmp {
+		return v.m.Copy(), nil
+	}
+	return v.m, nil
+}
+
+// evalValue is either a scalar s, when m is nil, or a mat. tmp is true when
+// the mat is an intermediate result, which can be modified in place.
+type evalValue struct {
+	m   *Matf64
+	s   float64
+	tmp bool
+}
+
+// own returns a mat holding the value of v that can be modified in place.
+func (v evalValue) own() *Matf64 {
+	if v.tmp {
+		return v.m
+	}
+	return v.m.Copy()
+}
+
+type evalParser struct {
+	expr string
+	vars map[string]*Matf64
+	pos  int    // Position of the next token.
+	tok  string // Current token, or "" at the end of the formula.
+	at   int    // Position of the current token.
+}
+
+func (p *evalParser) errorf(format string, args ...interface{}) error {
+	s := fmt.Sprintf(format, args...)
+	return fmt.Errorf("In %s: %s at position %d of %q", "Eval()", s, p.at, p.expr)
+}
+
+// next reads the next token, which is a number, a name, or an operator.
+func (p *evalParser) next() {
+	for p.pos < len(p.expr) && unicode.IsSpace(rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	p.at = p.pos
+	if p.pos == len(p.expr) {
+		p.tok = ""
+		return
+	}
+	start := p.pos
+	c := p.expr[p.pos]
+	switch {
+	case c == '.' && p.pos+1 < len(p.expr) && (p.expr[p.pos+1] == '*' || p.expr[p.pos+1] == '/'):
+		p.pos += 2
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.expr) {
+			c = p.expr[p.pos]
+			if c >= '0' && c <= '9' || c == '.' {
+				p.pos++
+			} else if (c == 'e' || c == 'E') && p.pos+1 < len(p.expr) {
+				p.pos++
+				if p.expr[p.pos] == '+' || p.expr[p.pos] == '-' {
+					p.pos++
+				}
+			} else {
+				break
+			}
+		}
+	case c == '_' || unicode.IsLetter(rune(c)):
+		for p.pos < len(p.expr) {
+			c = p.expr[p.pos]
+			if c != '_' && !unicode.IsLetter(rune(c)) && !unicode.IsDigit(rune(c)) {
+				break
+			}
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+	p.tok = p.expr[start:p.pos]
+}
+
+func (p *evalParser) parseSum() (evalValue, error) {
+	v, err := p.parseProduct()
+	for err == nil && (p.tok == "+" || p.tok == "-") {
+		op := p.tok
+		p.next()
+		var w evalValue
+		if w, err = p.parseProduct(); err == nil {
+			v, err = p.apply(op, v, w)
+		}
+	}
+	return v, err
+}
+
+func (p *evalParser) parseProduct() (evalValue, error) {
+	v, err := p.parseUnary()
+	for err == nil && (p.tok == "*" || p.tok == ".*" || p.tok == "/" || p.tok == "./") {
+		op := p.tok
+		p.next()
+		var w evalValue
+		if w, err = p.parseUnary(); err == nil {
+			v, err = p.apply(op, v, w)
+		}
+	}
+	return v, err
+}
+
+func (p *evalParser) parseUnary() (evalValue, error) {
+	if p.tok == "-" {
+		p.next()
+		v, err := p.parseUnary()
+		if err != nil {
+			return v, err
+		}
+		if v.m == nil {
+			return evalValue{s: -v.s}, nil
+		}
+		return evalValue{m: v.own().Mul(-1.0), tmp: true}, nil
+	}
+	v, err := p.parsePrimary()
+	for err == nil && p.tok == "'" {
+		p.next()
+		if v.m != nil {
+			v = evalValue{m: v.own().T(), tmp: true}
+		}
+	}
+	return v, err
+}
+
+func (p *evalParser) parsePrimary() (evalValue, error) {
+	tok := p.tok
+	switch {
+	case tok == "":
+		return evalValue{}, p.errorf("unexpected end of formula")
+	case tok == "(":
+		p.next()
+		v, err := p.parseSum()
+		if err != nil {
+			return v, err
+		}
+		if p.tok != ")" {
+			return v, p.errorf("expected \")\"")
+		}
+		p.next()
+		return v, nil
+	case tok[0] >= '0' && tok[0] <= '9' || tok[0] == '.':
+		s, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return evalValue{}, p.errorf("invalid number %q", tok)
+		}
+		p.next()
+		return evalValue{s: s}, nil
+	case tok[0] == '_' || unicode.IsLetter(rune(tok[0])):
+		m, ok := p.vars[tok]
+		if !ok || m == nil {
+			return evalValue{}, p.errorf("unknown mat %q", tok)
+		}
+		p.next()
+		return evalValue{m: m}, nil
+	}
+	return evalValue{}, p.errorf("unexpected %q", tok)
+}
+
+// apply applies the binary operator op to v and w.
+func (p *evalParser) apply(op string, v, w evalValue) (evalValue, error) {
+	if v.m == nil && w.m == nil {
+		switch op {
+		case "+":
+			return evalValue{s: v.s + w.s}, nil
+		case "-":
+			return evalValue{s: v.s - w.s}, nil
+		case "*", ".*":
+			return evalValue{s: v.s * w.s}, nil
+		default:
+			return evalValue{s: v.s / w.s}, nil
+		}
+	}
+	if v.m == nil || w.m == nil {
+		return p.applyScalar(op, v, w)
+	}
+	if op == "*" {
+		if v.m.c != w.m.r {
+			return v, p.errorf("cannot multiply a %dX%d mat by a %dX%d mat", v.m.r, v.m.c, w.m.r, w.m.c)
+		}
+		return evalValue{m: v.m.Dot(w.m), tmp: true}, nil
+	}
+	if v.m.r != w.m.r || v.m.c != w.m.c {
+		return v, p.errorf("the shapes %dX%d and %dX%d of the operands of %q differ", v.m.r, v.m.c, w.m.r, w.m.c, op)
+	}
+	// Reuse w for commutative operators when only w is a temporary.
+	if !v.tmp && w.tmp && (op == "+" || op == ".*") {
+		v, w = w, v
+	}
+	m := v.own()
+	switch op {
+	case "+":
+		m.Add(w.m)
+	case "-":
+		m.Sub(w.m)
+	case ".*":
+		m.Mul(w.m)
+	default:
+		m.Div(w.m)
+	}
+	return evalValue{m: m, tmp: true}, nil
+}
+
+// applyScalar applies the binary operator op to v and w, one of which is a
+// scalar while the other is a mat.
+func (p *evalParser) applyScalar(op string, v, w evalValue) (evalValue, error) {
+	if v.m == nil {
+		m := w.own()
+		switch op {
+		case "+":
+			m.Add(v.s)
+		case "-":
+			m.Mul(-1.0).Add(v.s)
+		case "*", ".*":
+			m.Mul(v.s)
+		default:
+			m.Map(func(x *float64) { *x = v.s / *x })
+		}
+		return evalValue{m: m, tmp: true}, nil
+	}
+	m := v.own()
+	switch op {
+	case "+":
+		m.Add(w.s)
+	case "-":
+		m.Sub(w.s)
+	case "*", ".*":
+		m.Mul(w.s)
+	default:
+		m.Div(w.s)
+	}
+	return evalValue{m: m, tmp: true}, nil
+}
@@ -0,0 +1,64 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEval(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1, 2}, {3, 4}, {5, 6}})
+	b := Matf64FromData([][]float64{{1, 0}, {0, 1}, {1, 1}})
+	c := Matf64FromData([][]float64{{2, 2}, {2, 2}})
+	vars := map[string]*Matf64{"A": a, "B": b, "C": c}
+	aCopy, bCopy := a.Copy(), b.Copy()
+
+	o, err := Eval("A' * B + 0.5*C", vars)
+	assert.Nil(t, err, "should not fail")
+	want := a.Copy().T().Dot(b).Add(1.0)
+	assert.True(t, want.Equals(o), "should be equal")
+	assert.True(t, aCopy.Equals(a), "should be left intact")
+	assert.True(t, bCopy.Equals(b), "should be left intact")
+
+	o, err = Eval("-(A - B) .* B ./ 2 + 1", vars)
+	assert.Nil(t, err, "should not fail")
+	want = Matf64FromData([][]float64{{1, 1}, {1, -0.5}, {-1, -1.5}})
+	assert.True(t, want.Equals(o), "should be equal")
+
+	o, err = Eval("A'*A", vars)
+	assert.Nil(t, err, "should not fail")
+	assert.True(t, a.Copy().T().Dot(a).Equals(o), "should be equal")
+
+	o, err = Eval("C * C", vars)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []float64{8, 8, 8, 8}, o.vals, "should be equal")
+
+	o, err = Eval("2 * (3 + 1e1) / 2", vars)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, []float64{13}, o.vals, "should be equal")
+
+	o, err = Eval("A", vars)
+	assert.Nil(t, err, "should not fail")
+	o.Set(0, 0, 9)
+	assert.Equal(t, 1.0, a.Get(0, 0), "should return a copy")
+}
+
+func TestEvalErrors(t *testing.T) {
+	t.Helper()
+	vars := map[string]*Matf64{"A": Newf64(2, 3), "B": Newf64(2, 2)}
+	for expr, msg := range map[string]string{
+		"A * A":   `In Eval(): cannot multiply a 2X3 mat by a 2X3 mat at position 5 of "A * A"`,
+		"A + B":   `In Eval(): the shapes 2X3 and 2X2 of the operands of "+" differ at position 5 of "A + B"`,
+		"A + X":   `In Eval(): unknown mat "X" at position 4 of "A + X"`,
+		"(A + A":  `In Eval(): expected ")" at position 6 of "(A + A"`,
+		"A B":     `In Eval(): unexpected "B" at position 2 of "A B"`,
+		"2 *":     `In Eval(): unexpected end of formula at position 3 of "2 *"`,
+		"1.2.3":   `In Eval(): invalid number "1.2.3" at position 0 of "1.2.3"`,
+		"A # B":   `In Eval(): unexpected "#" at position 2 of "A # B"`,
+		"B' + A'": `In Eval(): the shapes 2X2 and 3X2 of the operands of "+" differ at position 7 of "B' + A'"`,
+	} {
+		_, err := Eval(expr, vars)
+		assert.EqualError(t, err, msg, expr)
+	}
+}
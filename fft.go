@@ -0,0 +1,166 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+/*
+FFT2D returns the 2D discrete Fourier transform of the mat, as two mats of
+the same shape holding its real and imaginary parts:
+
+	X[u][v] = sum over i, j of m[i][j]*exp(-2πi*(u*i/r + v*j/c))
+
+The transform is computed with a fast Fourier transform along the rows and
+then along the columns, which takes O(r*c*log(r*c)) operations for any
+shape, although powers of 2 are the fastest. The mat is left intact.
+*/
+func (m *Matf64) FFT2D() (re, im *Matf64) {
+	x := make([]complex128, len(m.vals))
+	for i, v := range m.vals {
+		x[i] = complex(v, 0)
+	}
+	fft2D(x, m.r, m.c, false)
+	return splitComplex(x, m.r, m.c)
+}
+
+/*
+IFFT2D returns the inverse of FFT2D, as the real and imaginary parts of the
+2D inverse discrete Fourier transform of re + i*im, so that
+
+	re, im := m.FFT2D()
+	n, _ := matrix.IFFT2D(re, im)
+
+gives back m, up to rounding. im may be nil, in which case the imaginary
+parts are taken to be 0. For example, a mat can be filtered in the frequency
+domain by multiplying both parts by a mask before taking the inverse. The
+passed mats are left intact.
+*/
+func IFFT2D(re, im *Matf64) (*Matf64, *Matf64) {
+	if im != nil && (re.r != im.r || re.c != im.c) {
+		s := "\nIn %s, the real part is %dX%d but the imaginary part is\n"
+		s += "%dX%d. They must have the same shape.\n"
+		s = fmt.Sprintf(s, "IFFT2D()", re.r, re.c, im.r, im.c)
+		printErr(s)
+	}
+	x := make([]complex128, len(re.vals))
+	for i, v := range re.vals {
+		x[i] = complex(v, 0)
+		if im != nil {
+			x[i] += complex(0, im.vals[i])
+		}
+	}
+	fft2D(x, re.r, re.c, true)
+	scale := complex(1/float64(len(x)), 0)
+	for i := range x {
+		x[i] *= scale
+	}
+	return splitComplex(x, re.r, re.c)
+}
+
+// splitComplex returns the real and imaginary parts of the rXc values x.
+func splitComplex(x []complex128, r, c int) (*Matf64, *Matf64) {
+	re, im := Newf64(r, c), Newf64(r, c)
+	for i, v := range x {
+		re.vals[i], im.vals[i] = real(v), imag(v)
+	}
+	return re, im
+}
+
+// fft2D transforms the rXc values x in place, along the rows and then along
+// the columns. The inverse transform is not scaled.
+func fft2D(x []complex128, r, c int, inverse bool) {
+	for i := 0; i < r; i++ {
+		fft(x[i*c:(i+1)*c], inverse)
+	}
+	col := make([]complex128, r)
+	for j := 0; j < c; j++ {
+		for i := range col {
+			col[i] = x[i*c+j]
+		}
+		fft(col, inverse)
+		for i, v := range col {
+			x[i*c+j] = v
+		}
+	}
+}
+
+// fft computes the discrete Fourier transform of x in place, with a radix-2
+// transform when the length of x is a power of 2, and with Bluestein's
+// algorithm otherwise. The inverse transform is not scaled.
+func fft(x []complex128, inverse bool) {
+	n := len(x)
+	if n <= 1 {
+		return
+	}
+	if n&(n-1) == 0 {
+		fftRadix2(x, inverse)
+		return
+	}
+	// Bluestein's algorithm writes the transform as a convolution with a
+	// chirp, which is computed with radix-2 transforms of size m >= 2n-1.
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	w := make([]complex128, n)
+	for k := range w {
+		// k*k is reduced modulo 2n to keep the angle accurate.
+		w[k] = cmplx.Rect(1, sign*math.Pi*float64(k*k%(2*n))/float64(n))
+	}
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		a[k] = x[k] * w[k]
+		b[k] = cmplx.Conj(w[k])
+		if k > 0 {
+			b[m-k] = b[k]
+		}
+	}
+	fftRadix2(a, false)
+	fftRadix2(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	fftRadix2(a, true)
+	scale := complex(1/float64(m), 0)
+	for k := range x {
+		x[k] = a[k] * scale * w[k]
+	}
+}
+
+// fftRadix2 computes the discrete Fourier transform of x in place, where the
+// length of x is a power of 2. The inverse transform is not scaled.
+func fftRadix2(x []complex128, inverse bool) {
+	n := len(x)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				u, v := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = u+v, u-v
+				w *= step
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFFT2Df64(t *testing.T) {
+	t.Helper()
+	for _, shape := range [][2]int{{4, 8}, {3, 5}, {1, 6}, {7, 1}} {
+		r, c := shape[0], shape[1]
+		m := RandMatf64(r, c)
+		re, im := m.FFT2D()
+		for u := 0; u < r; u++ {
+			for v := 0; v < c; v++ {
+				var want complex128
+				for i := 0; i < r; i++ {
+					for j := 0; j < c; j++ {
+						a := -2 * math.Pi * (float64(u*i)/float64(r) + float64(v*j)/float64(c))
+						want += complex(m.Get(i, j), 0) * cmplx.Rect(1, a)
+					}
+				}
+				assert.InDelta(t, real(want), re.Get(u, v), 1e-9, "should be equal")
+				assert.InDelta(t, imag(want), im.Get(u, v), 1e-9, "should be equal")
+			}
+		}
+		n, nim := IFFT2D(re, im)
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				assert.InDelta(t, m.Get(i, j), n.Get(i, j), 1e-9, "should be equal")
+				assert.InDelta(t, 0, nim.Get(i, j), 1e-9, "should be equal")
+			}
+		}
+	}
+}
+
+func TestIFFT2Df64(t *testing.T) {
+	t.Helper()
+	// The inverse transform of a constant is a delta at the origin.
+	re, im := IFFT2D(Fullf64(3, 4, 12), nil)
+	want := Newf64(3, 4).Set(0, 0, 12)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			assert.InDelta(t, want.Get(i, j), re.Get(i, j), 1e-12, "should be equal")
+			assert.InDelta(t, 0, im.Get(i, j), 1e-12, "should be equal")
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+Format implements the fmt.Formatter interface, so that the precision and
+width of the verbs of the fmt package apply to each value of the mat. For
+instance
+
+	fmt.Printf("%.2f\n", m)
+
+prints every value with two decimals, one row per line:
+
+	[[ 1.00 -0.50]
+	 [12.25  3.00]]
+
+The verbs f, F, e, E, g and G are supported, as well as v and s, which use
+the shortest representation of each value, like %g does. Values are right
+aligned so that the columns line up, and a width, as in %8.2e, sets the
+minimum width of each value. The - flag aligns values to the left instead,
+and the + flag always prints the sign. Finally, the # flag only prints the
+type and the shape of the mat, which is handy for logging large mats:
+
+	fmt.Printf("%#v\n", m) // Matf64(2X2)
+*/
+func (m *Matf64) Format(f fmt.State, verb rune) {
+	formatMat(f, verb, "Matf64", m.r, m.c, 64, func(i int) float64 {
+		return m.vals[i]
+	})
+}
+
+/*
+Format implements the fmt.Formatter interface, following the same rules as
+for Matf64.
+*/
+func (m *Matf32) Format(f fmt.State, verb rune) {
+	formatMat(f, verb, "Matf32", m.r, m.c, 32, func(i int) float64 {
+		return float64(m.vals[i])
+	})
+}
+
+// formatMat writes the r by c values returned by at to f, as described in
+// Matf64.Format. bits is the size of the floats, so that the shortest
+// representation of float32 values is not polluted by the conversion.
+func formatMat(f fmt.State, verb rune, typ string, r, c, bits int, at func(int) float64) {
+	if f.Flag('#') {
+		fmt.Fprintf(f, "%s(%dX%d)", typ, r, c)
+		return
+	}
+	prec, hasPrec := f.Precision()
+	switch verb {
+	case 'v', 's':
+		verb = 'g'
+		if !hasPrec {
+			prec = -1
+		}
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		if !hasPrec {
+			prec = 6
+			if verb == 'g' || verb == 'G' {
+				prec = -1
+			}
+		}
+		if verb == 'F' {
+			verb = 'f'
+		}
+	default:
+		fmt.Fprintf(f, "%%!%c(*matrix.%s=%dX%d)", verb, typ, r, c)
+		return
+	}
+
+	cells := make([]string, r*c)
+	width, _ := f.Width()
+	for i := range cells {
+		x := at(i)
+		s := strconv.FormatFloat(x, byte(verb), prec, bits)
+		// strconv already signs -0 and the infinities.
+		if f.Flag('+') && s[0] != '+' && s[0] != '-' && !math.IsNaN(x) {
+			s = "+" + s
+		}
+		cells[i] = s
+		if len(s) > width {
+			width = len(s)
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; i < r; i++ {
+		if i != 0 {
+			b.WriteString("\n ")
+		}
+		b.WriteByte('[')
+		for j := 0; j < c; j++ {
+			if j != 0 {
+				b.WriteByte(' ')
+			}
+			s := cells[i*c+j]
+			pad := strings.Repeat(" ", width-len(s))
+			if f.Flag('-') {
+				b.WriteString(s + pad)
+			} else {
+				b.WriteString(pad + s)
+			}
+		}
+		b.WriteByte(']')
+	}
+	b.WriteByte(']')
+	f.Write(b.Bytes())
+}
@@ -0,0 +1,32 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, -0.5}, {12.25, 3}})
+	assert.Equal(t, "[[    1  -0.5]\n [12.25     3]]", fmt.Sprintf("%v", m), "should be equal")
+	assert.Equal(t, "[[ 1.00 -0.50]\n [12.25  3.00]]", fmt.Sprintf("%.2f", m), "should be equal")
+	assert.Equal(t, "[[ 1.0e+00 -5.0e-01]\n [ 1.2e+01  3.0e+00]]", fmt.Sprintf("%.1e", m), "should be equal")
+	assert.Equal(t, "[[1      -0.5  ]\n [12.25  3     ]]", fmt.Sprintf("%-6v", m), "should be equal")
+	assert.Equal(t, "[[+1 -2]]", fmt.Sprintf("%+v", Matf64FromData([]float64{1, -2})), "should be equal")
+	inf := Matf64FromData([]float64{math.Inf(1), math.Inf(-1), 0})
+	assert.Equal(t, "[[+Inf -Inf +0.0]]", fmt.Sprintf("%+.1f", inf), "should not sign twice")
+	assert.Equal(t, "Matf64(2X2)", fmt.Sprintf("%#v", m), "should be equal")
+	assert.Equal(t, "%!d(*matrix.Matf64=2X2)", fmt.Sprintf("%d", m), "should be equal")
+	assert.Equal(t, fmt.Sprintf("%v", m), m.String(), "should be equal")
+	assert.Equal(t, "[]", fmt.Sprint(Newf64()), "should be equal")
+}
+
+func TestFormatf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{{0.1, 2}})
+	assert.Equal(t, "[[0.1   2]]", fmt.Sprintf("%v", m), "should be equal")
+	assert.Equal(t, "[[  0.10   2.00]]", fmt.Sprintf("%6.2f", m), "should be equal")
+}
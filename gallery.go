@@ -0,0 +1,216 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+/*
+Hilbertf64 returns the nXn Hilbert matrix, whose element at row i and
+column j is 1/(i+j+1). Hilbert matrices are notoriously ill-conditioned,
+which makes them a good stress test for solvers and decompositions.
+*/
+func Hilbertf64(n int) *Matf64 {
+	checkGallerySize("Hilbertf64()", n)
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			m.vals[i*n+j] = 1.0 / float64(i+j+1)
+		}
+	}
+	return m
+}
+
+/*
+Pascalf64 returns the nXn symmetric Pascal matrix, whose element at row i
+and column j is the binomial coefficient (i+j choose i). Pascal matrices are
+positive definite, their inverse only has integer elements, and their
+determinant is always 1.
+*/
+func Pascalf64(n int) *Matf64 {
+	checkGallerySize("Pascalf64()", n)
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == 0 || j == 0 {
+				m.vals[i*n+j] = 1.0
+				continue
+			}
+			m.vals[i*n+j] = m.vals[(i-1)*n+j] + m.vals[i*n+j-1]
+		}
+	}
+	return m
+}
+
+/*
+Magicf64 returns an nXn magic square, which holds each of the integers from
+1 to n*n exactly once, and whose rows, columns and both diagonals all sum to
+n*(n*n+1)/2. There is no magic square of size 2, so n must not be 2.
+*/
+func Magicf64(n int) *Matf64 {
+	checkGallerySize("Magicf64()", n)
+	if n == 2 {
+		s := "\nIn matrix.%s, there is no magic square of size 2.\n"
+		s = fmt.Sprintf(s, "Magicf64()")
+		printErr(s)
+	}
+	m := Newf64(n)
+	switch {
+	case n%2 == 1:
+		magicOdd(m.vals, n, n)
+	case n%4 == 0:
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				v := float64(i*n + j + 1)
+				if i%4 == j%4 || i%4+j%4 == 3 {
+					v = float64(n*n) + 1 - v
+				}
+				m.vals[i*n+j] = v
+			}
+		}
+	default:
+		// The LUX method: four odd magic squares of size p, shifted by
+		// multiples of p*p, with some of their columns swapped.
+		p := n / 2
+		magicOdd(m.vals, p, n)
+		pp := float64(p * p)
+		for i := 0; i < p; i++ {
+			for j := 0; j < p; j++ {
+				v := m.vals[i*n+j]
+				m.vals[i*n+j+p] = v + 2*pp
+				m.vals[(i+p)*n+j] = v + 3*pp
+				m.vals[(i+p)*n+j+p] = v + pp
+			}
+		}
+		swap := func(i, j int) {
+			m.vals[i*n+j], m.vals[(i+p)*n+j] = m.vals[(i+p)*n+j], m.vals[i*n+j]
+		}
+		k := (n - 2) / 4
+		for i := 0; i < p; i++ {
+			for j := 0; j < k; j++ {
+				swap(i, j)
+			}
+			for j := n - k + 1; j < n; j++ {
+				swap(i, j)
+			}
+		}
+		swap(k, 0)
+		swap(k, k)
+	}
+	return m
+}
+
+// magicOdd fills the top left nXn block of a mat with stride columns, using
+// the Siamese method. n must be odd.
+func magicOdd(vals []float64, n, stride int) {
+	i, j := 0, n/2
+	for k := 1; k <= n*n; k++ {
+		vals[i*stride+j] = float64(k)
+		ni, nj := (i-1+n)%n, (j+1)%n
+		if vals[ni*stride+nj] != 0 {
+			ni, nj = (i+1)%n, j
+		}
+		i, j = ni, nj
+	}
+}
+
+/*
+RandOrthof64 returns a random nXn orthogonal matrix, drawn uniformly (from
+the Haar distribution) among all the orthogonal matrices of that size. The
+transpose of such a matrix is its inverse.
+*/
+func RandOrthof64(n int) *Matf64 {
+	return RandOrthof64With(nil, n)
+}
+
+/*
+RandOrthof64With is like RandOrthof64, but draws its values from rng, or from
+the package source if rng is nil (see SetRandSource).
+*/
+func RandOrthof64With(rng *rand.Rand, n int) *Matf64 {
+	rng = randOrDefault(rng)
+	checkGallerySize("RandOrthof64()", n)
+	m := Newf64(n)
+	for i := range m.vals {
+		m.vals[i] = rng.NormFloat64()
+	}
+	// The Q factor of a Gaussian matrix, with a positive diagonal for R, is
+	// Haar distributed. Gram-Schmidt gives exactly that factor, and running
+	// it twice keeps the columns orthogonal to machine precision.
+	for j := 0; j < n; j++ {
+		for pass := 0; pass < 2; pass++ {
+			for k := 0; k < j; k++ {
+				var dot float64
+				for i := 0; i < n; i++ {
+					dot += m.vals[i*n+k] * m.vals[i*n+j]
+				}
+				for i := 0; i < n; i++ {
+					m.vals[i*n+j] -= dot * m.vals[i*n+k]
+				}
+			}
+		}
+		var norm float64
+		for i := 0; i < n; i++ {
+			norm += m.vals[i*n+j] * m.vals[i*n+j]
+		}
+		norm = math.Sqrt(norm)
+		for i := 0; i < n; i++ {
+			m.vals[i*n+j] /= norm
+		}
+	}
+	return m
+}
+
+/*
+RandSPDf64 returns a random nXn symmetric positive definite matrix whose
+2-norm condition number is cond. Its eigenvalues are spaced geometrically
+from 1 down to 1/cond, and its eigenvectors are random (see RandOrthof64).
+cond must be at least 1.
+*/
+func RandSPDf64(n int, cond float64) *Matf64 {
+	return RandSPDf64With(nil, n, cond)
+}
+
+/*
+RandSPDf64With is like RandSPDf64, but draws its values from rng, or from the
+package source if rng is nil (see SetRandSource).
+*/
+func RandSPDf64With(rng *rand.Rand, n int, cond float64) *Matf64 {
+	rng = randOrDefault(rng)
+	checkGallerySize("RandSPDf64()", n)
+	if !(cond >= 1) || math.IsInf(cond, 0) {
+		s := "\nIn matrix.%s, the condition number must be finite and at least\n"
+		s += "1, but received %f.\n"
+		s = fmt.Sprintf(s, "RandSPDf64()", cond)
+		printErr(s)
+	}
+	eig := make([]float64, n)
+	for i := range eig {
+		eig[i] = 1.0
+		if n > 1 {
+			eig[i] = math.Pow(cond, -float64(i)/float64(n-1))
+		}
+	}
+	q := RandOrthof64With(rng, n)
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			var v float64
+			for k := 0; k < n; k++ {
+				v += q.vals[i*n+k] * eig[k] * q.vals[j*n+k]
+			}
+			m.vals[i*n+j] = v
+			m.vals[j*n+i] = v
+		}
+	}
+	return m
+}
+
+func checkGallerySize(fn string, n int) {
+	if n < 0 {
+		s := "\nIn matrix.%s, the size must be non-negative, but received %d.\n"
+		s = fmt.Sprintf(s, fn, n)
+		printHelperErr(s)
+	}
+}
@@ -0,0 +1,109 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHilbertf64(t *testing.T) {
+	t.Helper()
+	m := Hilbertf64(3)
+	want := Matf64FromData([][]float64{
+		{1, 1.0 / 2, 1.0 / 3},
+		{1.0 / 2, 1.0 / 3, 1.0 / 4},
+		{1.0 / 3, 1.0 / 4, 1.0 / 5},
+	})
+	assert.True(t, want.Equals(m), "should be equal")
+}
+
+func TestPascalf64(t *testing.T) {
+	t.Helper()
+	m := Pascalf64(4)
+	want := Matf64FromData([][]float64{
+		{1, 1, 1, 1},
+		{1, 2, 3, 4},
+		{1, 3, 6, 10},
+		{1, 4, 10, 20},
+	})
+	assert.True(t, want.Equals(m), "should be equal")
+}
+
+func TestMagicf64(t *testing.T) {
+	t.Helper()
+	for _, n := range []int{1, 3, 4, 5, 6, 8, 10, 14} {
+		m := Magicf64(n)
+		sum := float64(n * (n*n + 1) / 2)
+		seen := make(map[float64]bool)
+		var d1, d2 float64
+		for i := 0; i < n; i++ {
+			var row, col float64
+			for j := 0; j < n; j++ {
+				row += m.Get(i, j)
+				col += m.Get(j, i)
+				seen[m.Get(i, j)] = true
+			}
+			assert.Equal(t, sum, row, "rows should sum to the magic constant")
+			assert.Equal(t, sum, col, "cols should sum to the magic constant")
+			d1 += m.Get(i, i)
+			d2 += m.Get(i, n-1-i)
+		}
+		assert.Equal(t, sum, d1, "diagonal should sum to the magic constant")
+		assert.Equal(t, sum, d2, "anti-diagonal should sum to the magic constant")
+		for v := 1; v <= n*n; v++ {
+			assert.True(t, seen[float64(v)], "should hold every value once")
+		}
+	}
+}
+
+func TestRandOrthof64(t *testing.T) {
+	t.Helper()
+	n := 20
+	q := RandOrthof64(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var dot float64
+			for k := 0; k < n; k++ {
+				dot += q.Get(k, i) * q.Get(k, j)
+			}
+			want := 0.0
+			if i == j {
+				want = 1.0
+			}
+			assert.InDelta(t, want, dot, 1e-12, "columns should be orthonormal")
+		}
+	}
+}
+
+func TestRandSPDf64(t *testing.T) {
+	t.Helper()
+	n, cond := 12, 1e4
+	m := RandSPDf64(n, cond)
+	var trace, eigSum float64
+	for i := 0; i < n; i++ {
+		trace += m.Get(i, i)
+		eigSum += math.Pow(cond, -float64(i)/float64(n-1))
+		for j := 0; j < n; j++ {
+			assert.Equal(t, m.Get(i, j), m.Get(j, i), "should be symmetric")
+		}
+	}
+	assert.InDelta(t, eigSum, trace, 1e-10, "trace should be the sum of eigenvalues")
+	// A Cholesky factorization only exists for positive definite matrices.
+	l := Newf64(n)
+	for j := 0; j < n; j++ {
+		d := m.Get(j, j)
+		for k := 0; k < j; k++ {
+			d -= l.Get(j, k) * l.Get(j, k)
+		}
+		assert.True(t, d > 0, "should be positive definite")
+		l.Set(j, j, math.Sqrt(d))
+		for i := j + 1; i < n; i++ {
+			v := m.Get(i, j)
+			for k := 0; k < j; k++ {
+				v -= l.Get(i, k) * l.Get(j, k)
+			}
+			l.Set(i, j, v/l.Get(j, j))
+		}
+	}
+}
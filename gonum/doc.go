@@ -0,0 +1,17 @@
+/*
+Package gonum converts matrix.Matf64 objects to and from the mat.Dense type of
+gonum.org/v1/gonum, so that gonum's solvers, decompositions and plotting can
+be used on mats without leaving the API of the matrix package for the rest of
+a program. Whenever the memory layouts match, the conversions share the
+values rather than copying them. The Matrix type also wraps a Matf64 so that
+it can be passed directly to any function taking a mat.Matrix.
+
+To keep gonum out of the dependencies of the matrix package, the functions
+of this package are only compiled with the gonum build tag:
+
+	go get gonum.org/v1/gonum
+	go build -tags gonum
+
+Without the tag, this package is empty.
+*/
+package gonum
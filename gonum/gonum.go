@@ -0,0 +1,45 @@
+//go:build gonum
+// +build gonum
+
+package gonum
+
+import (
+	"github.com/gocrunch/matrix"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+ToDense returns a mat.Dense holding the values of m. No values are copied:
+the two share the same memory, so changes made through either are seen by
+the other, until an operation changing the shape of m reallocates it. Since
+gonum does not allow empty matrices, an empty m gives a zero mat.Dense,
+which is ready to be used as the receiver of a gonum operation.
+*/
+func ToDense(m *matrix.Matf64) *mat.Dense {
+	r, c := m.Shape()
+	if r == 0 || c == 0 {
+		return &mat.Dense{}
+	}
+	return mat.NewDense(r, c, m.RawData())
+}
+
+/*
+FromDense returns a Matf64 holding the values of d. When the rows of d are
+contiguous in memory, which is the case unless d is a slice of a larger
+matrix, the values are shared rather than copied, just as with ToDense.
+Otherwise they are copied one row at a time.
+*/
+func FromDense(d *mat.Dense) *matrix.Matf64 {
+	if d.IsEmpty() {
+		return matrix.Newf64()
+	}
+	raw := d.RawMatrix()
+	if raw.Stride == raw.Cols {
+		return matrix.Matf64FromRawData(raw.Data[:raw.Rows*raw.Cols], raw.Rows, raw.Cols)
+	}
+	vals := make([]float64, raw.Rows*raw.Cols)
+	for i := 0; i < raw.Rows; i++ {
+		copy(vals[i*raw.Cols:(i+1)*raw.Cols], raw.Data[i*raw.Stride:])
+	}
+	return matrix.Matf64FromRawData(vals, raw.Rows, raw.Cols)
+}
@@ -0,0 +1,38 @@
+//go:build gonum
+// +build gonum
+
+package gonum
+
+import (
+	"testing"
+
+	"github.com/gocrunch/matrix"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestToDense(t *testing.T) {
+	t.Helper()
+	m := matrix.RandMatf64(4, 3)
+	d := ToDense(m)
+	r, c := d.Dims()
+	assert.Equal(t, 4, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, m.Get(2, 1), d.At(2, 1), "should be equal")
+	d.Set(0, 0, 42)
+	assert.Equal(t, 42.0, m.Get(0, 0), "should share the values")
+}
+
+func TestFromDense(t *testing.T) {
+	t.Helper()
+	d := mat.NewDense(3, 3, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	m := FromDense(d)
+	assert.Equal(t, 6.0, m.Get(1, 2), "should be equal")
+	m.Set(1, 2, 0)
+	assert.Equal(t, 0.0, d.At(1, 2), "should share the values")
+
+	s := d.Slice(1, 3, 0, 2).(*mat.Dense)
+	m = FromDense(s)
+	want := matrix.Matf64FromData([][]float64{{4, 5}, {7, 8}})
+	assert.True(t, want.Equals(m), "should be equal")
+}
@@ -0,0 +1,40 @@
+//go:build gonum
+// +build gonum
+
+package gonum
+
+import (
+	"github.com/gocrunch/matrix"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+)
+
+/*
+Matrix wraps a Matf64 so that it implements gonum's mat.Matrix interface,
+and can be passed directly to any gonum routine:
+
+	var svd mat.SVD
+	svd.Factorize(gonum.Matrix{Matf64: m}, mat.SVDThin)
+
+Matf64 already has the Dims and At methods, but its T method transposes the
+mat in place and returns a *Matf64, so the wrapper is needed to provide the
+T method gonum expects. All the other methods of Matf64 remain available
+through the wrapper. Matrix also implements mat.RawMatrixer, which lets
+gonum read the values of the mat without copying them.
+*/
+type Matrix struct {
+	*matrix.Matf64
+}
+
+// T returns the transpose of the wrapped mat, without copying or modifying
+// it.
+func (m Matrix) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
+// RawMatrix returns a view of the values of the wrapped mat, in the layout
+// used by gonum's BLAS routines.
+func (m Matrix) RawMatrix() blas64.General {
+	r, c := m.Dims()
+	return blas64.General{Rows: r, Cols: c, Stride: c, Data: m.RawData()}
+}
@@ -0,0 +1,30 @@
+//go:build gonum
+// +build gonum
+
+package gonum
+
+import (
+	"testing"
+
+	"github.com/gocrunch/matrix"
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMatrix(t *testing.T) {
+	t.Helper()
+	m := matrix.Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	var a mat.Matrix = Matrix{m}
+	r, c := a.T().Dims()
+	assert.Equal(t, 3, r, "should be equal")
+	assert.Equal(t, 2, c, "should be equal")
+	assert.Equal(t, 6.0, a.T().At(2, 1), "should be equal")
+
+	var p mat.Dense
+	p.Mul(a, a.T())
+	want := matrix.Matf64FromData([][]float64{{14, 32}, {32, 77}})
+	assert.True(t, want.Equals(FromDense(&p)), "should be equal")
+	r, c = m.Shape()
+	assert.Equal(t, 2, r, "should be left intact")
+	assert.Equal(t, 3, c, "should be left intact")
+}
@@ -0,0 +1,73 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Degreef64 returns the degree mat of the graph whose adjacency mat is adj,
+that is the diagonal mat whose ith element is the sum of the ith row of adj.
+For a weighted graph this is the total weight of the edges leaving each
+node. adj must be square, and is left intact.
+*/
+func Degreef64(adj *Matf64) *Matf64 {
+	return Diagf64(degrees("Degreef64()", adj))
+}
+
+/*
+Laplacianf64 returns the Laplacian L = D - A of the graph whose adjacency mat
+is A, where D is the degree mat (see Degreef64). If normalized is true, the
+symmetric normalized Laplacian D^-1/2 * L * D^-1/2 is returned instead, whose
+eigenvalues are between 0 and 2, and whose rows and columns for isolated
+nodes are 0.
+
+For an undirected graph, the Laplacian is symmetric and positive
+semidefinite, and the eigenvectors of its smallest eigenvalues give a
+spectral embedding of the nodes, which is the basis of spectral clustering:
+
+	_, emb, err := matrix.Laplacianf64(adj, true).Lanczos(k, matrix.EigenSmallest)
+
+after which the rows of emb can be clustered with k-means. adj must be
+square, and is left intact.
+*/
+func Laplacianf64(adj *Matf64, normalized bool) *Matf64 {
+	d := degrees("Laplacianf64()", adj)
+	n := len(d)
+	l := Newf64(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			l.vals[i*n+j] = -adj.vals[i*n+j]
+		}
+		l.vals[i*n+i] += d[i]
+	}
+	if normalized {
+		for i := range d {
+			if d[i] > 0 {
+				d[i] = 1 / math.Sqrt(d[i])
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				l.vals[i*n+j] *= d[i] * d[j]
+			}
+		}
+	}
+	return l
+}
+
+// degrees returns the sums of the rows of the square mat adj.
+func degrees(fn string, adj *Matf64) []float64 {
+	if adj.r != adj.c {
+		s := "\nIn %s, the adjacency mat must be square, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, fn, adj.r, adj.c)
+		printHelperErr(s)
+	}
+	d := make([]float64, adj.r)
+	for i := range d {
+		for _, x := range adj.vals[i*adj.c : (i+1)*adj.c] {
+			d[i] += x
+		}
+	}
+	return d
+}
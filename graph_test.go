@@ -0,0 +1,46 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDegreef64(t *testing.T) {
+	t.Helper()
+	adj := Matf64FromData([][]float64{{0, 1, 1}, {1, 0, 0}, {1, 0, 0}})
+	assert.Equal(t, Diagf64([]float64{2, 1, 1}).vals, Degreef64(adj).vals, "should be equal")
+}
+
+func TestLaplacianf64(t *testing.T) {
+	t.Helper()
+	// A path 0-1-2, and an isolated node 3.
+	adj := Matf64FromData([][]float64{{0, 1, 0, 0}, {1, 0, 2, 0}, {0, 2, 0, 0}, {0, 0, 0, 0}})
+	l := Laplacianf64(adj, false)
+	want := [][]float64{{1, -1, 0, 0}, {-1, 3, -2, 0}, {0, -2, 2, 0}, {0, 0, 0, 0}}
+	assert.Equal(t, want, l.ToSlice2D(), "should be equal")
+	l = Laplacianf64(adj, true)
+	want = [][]float64{
+		{1, -1 / math.Sqrt(3), 0, 0},
+		{-1 / math.Sqrt(3), 1, -2 / math.Sqrt(6), 0},
+		{0, -2 / math.Sqrt(6), 1, 0},
+		{0, 0, 0, 0},
+	}
+	assert.InDeltaSlice(t, Matf64FromData(want).vals, l.vals, 1e-15, "should be equal")
+
+	// Two triangles joined by one edge are split by the Fiedler vector.
+	adj = Newf64(6, 6)
+	for _, e := range [][2]int{{0, 1}, {1, 2}, {0, 2}, {3, 4}, {4, 5}, {3, 5}, {2, 3}} {
+		adj.Set(e[0], e[1], 1).Set(e[1], e[0], 1)
+	}
+	vals, vecs, err := Laplacianf64(adj, true).Lanczos(2, EigenSmallest)
+	assert.Nil(t, err, "should not fail")
+	assert.InDelta(t, 0, vals[0], 1e-12, "should be equal")
+	f := vecs.Col(1).vals
+	for i := 1; i < 3; i++ {
+		assert.True(t, f[i]*f[0] > 0, "should be in the same cluster")
+		assert.True(t, f[i+3]*f[0] < 0, "should be in the other cluster")
+	}
+	assert.True(t, f[3]*f[0] < 0, "should be in the other cluster")
+}
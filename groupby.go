@@ -0,0 +1,148 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+Agg is an aggregation computed by GroupBy over the rows of each group.
+*/
+type Agg int
+
+const (
+	// AggSum is the sum of the values of a column within a group.
+	AggSum Agg = iota
+	// AggMean is the mean of the values of a column within a group.
+	AggMean
+	// AggCount is the number of values of a column within a group.
+	AggCount
+	// AggMin is the smallest value of a column within a group.
+	AggMin
+	// AggMax is the largest value of a column within a group.
+	AggMax
+)
+
+/*
+GroupBy groups the rows of the mat by the values of the key column col, and
+computes the given aggregations over the other columns of each group, which
+is the equivalent of "GROUP BY" for a table loaded with Matf64FromCSV. For
+example, with a mat whose first column holds a category and whose other two
+columns hold measurements,
+
+	g := m.GroupBy(0, matrix.AggCount, matrix.AggMean)
+
+returns a mat with one row per category, sorted by category. Its first
+column holds the category, the next two the number of measurements of each
+column, and the last two their means. In general, each aggregation adds as
+many columns as m has columns other than col, in the order the aggregations
+are given. When no aggregation is given, AggMean is used.
+
+Rows are grouped by the exact value of their key, so continuous keys should
+be discretized first, for instance with Map and math.Floor. Rows whose key
+is NaN form a single group, placed last. Just as with ImputeNaN, the NaNs
+of the other columns are ignored: they are not counted, and a group whose
+values are all NaN has a NaN mean, min and max. col may be negative, in
+which case it counts from the right.
+*/
+func (m *Matf64) GroupBy(col int, aggs ...Agg) *Matf64 {
+	col = normIndex("GroupBy()", "column", col, m.c)
+	if len(aggs) == 0 {
+		aggs = []Agg{AggMean}
+	}
+	for _, a := range aggs {
+		if a < AggSum || a > AggMax {
+			s := "\nIn %s, %d is not a valid aggregation.\n"
+			s = fmt.Sprintf(s, "GroupBy()", a)
+			printErr(s)
+		}
+	}
+
+	// Find the distinct keys, and the group of each row.
+	index := make(map[float64]int)
+	keys := []float64{}
+	nan := false
+	for i := 0; i < m.r; i++ {
+		k := m.vals[i*m.c+col]
+		if math.IsNaN(k) {
+			nan = true
+			continue
+		}
+		if k == 0 {
+			k = 0 // Merges -0 with 0.
+		}
+		if _, ok := index[k]; !ok {
+			index[k] = len(keys)
+			keys = append(keys, k)
+		}
+	}
+	sort.Float64s(keys)
+	for i, k := range keys {
+		index[k] = i
+	}
+	if nan {
+		keys = append(keys, math.NaN())
+	}
+
+	// Accumulate the statistics of every column of every group.
+	w := m.c - 1
+	n := len(keys) * w
+	sum, cnt := make([]float64, n), make([]float64, n)
+	min, max := make([]float64, n), make([]float64, n)
+	for i := range min {
+		min[i], max[i] = math.Inf(1), math.Inf(-1)
+	}
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		g := len(keys) - 1
+		if !math.IsNaN(row[col]) {
+			g = index[row[col]]
+		}
+		for j, x := range row {
+			if j == col || math.IsNaN(x) {
+				continue
+			}
+			if j > col {
+				j--
+			}
+			k := g*w + j
+			sum[k] += x
+			cnt[k]++
+			min[k] = math.Min(min[k], x)
+			max[k] = math.Max(max[k], x)
+		}
+	}
+
+	o := Newf64(len(keys), 1+len(aggs)*w)
+	for g, key := range keys {
+		row := o.vals[g*o.c : (g+1)*o.c]
+		row[0] = key
+		for a, agg := range aggs {
+			for j := 0; j < w; j++ {
+				k := g*w + j
+				x := math.NaN()
+				switch agg {
+				case AggSum:
+					x = sum[k]
+				case AggCount:
+					x = cnt[k]
+				case AggMean:
+					if cnt[k] > 0 {
+						x = sum[k] / cnt[k]
+					}
+				case AggMin:
+					if cnt[k] > 0 {
+						x = min[k]
+					}
+				case AggMax:
+					if cnt[k] > 0 {
+						x = max[k]
+					}
+				}
+				row[1+a*w+j] = x
+			}
+		}
+	}
+	return o
+}
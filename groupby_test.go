@@ -0,0 +1,38 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{2, 1, 10},
+		{1, 2, 20},
+		{2, 3, math.NaN()},
+		{1, 4, 40},
+		{2, 5, 50},
+	})
+	g := m.GroupBy(0, AggCount, AggSum, AggMean)
+	want := Matf64FromData([][]float64{
+		{1, 2, 2, 6, 60, 3, 30},
+		{2, 3, 2, 9, 60, 3, 30},
+	})
+	assert.True(t, want.Equals(g), "should be equal")
+
+	g = m.GroupBy(-1, AggMin, AggMax)
+	assert.Equal(t, 5, g.r, "should be equal")
+	assert.Equal(t, []float64{10, 2, 1, 2, 1}, g.Row(0).vals, "should be equal")
+	assert.True(t, math.IsNaN(g.Get(-1, 0)), "NaN keys should be last")
+	assert.Equal(t, []float64{2, 3, 2, 3}, g.Row(-1).vals[1:], "should be equal")
+}
+
+func TestGroupByDefaultf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{0, 1}, {1, 5}, {0, 3}})
+	want := Matf64FromData([][]float64{{0, 2}, {1, 5}})
+	assert.True(t, want.Equals(m.GroupBy(0)), "should use the mean")
+}
@@ -0,0 +1,81 @@
+package matrix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+)
+
+// gzipMagic are the first two bytes of any gzip stream.
+const gzipMagic = "\x1f\x8b"
+
+// gzipWriter compresses the data written to a file.
+type gzipWriter struct {
+	*gzip.Writer
+	f *os.File
+}
+
+func (g *gzipWriter) Close() error {
+	err := g.Writer.Close()
+	if ferr := g.f.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// gzipReader decompresses the data read from a file.
+type gzipReader struct {
+	*gzip.Reader
+	f *os.File
+}
+
+func (g *gzipReader) Close() error {
+	err := g.Reader.Close()
+	if ferr := g.f.Close(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// createFile creates the named file. If the name ends with ".gz", everything
+// written to the returned file is compressed with gzip.
+func createFile(name string) (io.WriteCloser, error) {
+	f, err := os.Create(name)
+	if err != nil || !strings.HasSuffix(name, ".gz") {
+		return f, err
+	}
+	return &gzipWriter{gzip.NewWriter(f), f}, nil
+}
+
+// openFile opens the named file for reading. If the name ends with ".gz",
+// the content of the file is decompressed with gzip.
+func openFile(name string) (io.ReadCloser, error) {
+	f, err := os.Open(name)
+	if err != nil || !strings.HasSuffix(name, ".gz") {
+		return f, err
+	}
+	z, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &gzipReader{z, f}, nil
+}
+
+// decompress returns a reader that yields the content of r, decompressed
+// with gzip if r starts with a gzip header. Apart from the gzip stream
+// itself, nothing is read from r beyond the data that is returned.
+func decompress(r io.Reader) (io.Reader, error) {
+	prefix := make([]byte, len(gzipMagic))
+	n, err := io.ReadFull(r, prefix)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	r = io.MultiReader(bytes.NewReader(prefix[:n]), r)
+	if string(prefix[:n]) != gzipMagic {
+		return r, nil
+	}
+	return gzip.NewReader(r)
+}
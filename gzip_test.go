@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSVGzip(t *testing.T) {
+	t.Helper()
+	m := Newf64(23, 17)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	filename := "tocsv_test.csv.gz"
+	m.ToCSV(filename)
+	defer os.Remove(filename)
+	b, err := ioutil.ReadFile(filename)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, gzipMagic, string(b[:2]), "should be compressed")
+	n := Matf64FromCSV(filename)
+	assert.True(t, m.Equals(n), "should be equal")
+}
+
+func TestBinaryGzip(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(31, 7)
+	filename := "binary_test.bin.gz"
+	f, err := os.Create(filename)
+	assert.Nil(t, err, "should not fail")
+	defer os.Remove(filename)
+	m.WriteBinary(f)
+	f.Close()
+
+	f, err = os.Open(filename)
+	assert.Nil(t, err, "should not fail")
+	defer f.Close()
+	n := Newf64().ReadBinary(f)
+	assert.True(t, m.Equals(n), "should be equal")
+
+	var b bytes.Buffer
+	z := gzip.NewWriter(&b)
+	m.WriteBinary(z)
+	z.Close()
+	n = Newf64().ReadBinary(&b)
+	assert.True(t, m.Equals(n), "should detect gzip streams")
+}
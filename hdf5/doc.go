@@ -0,0 +1,14 @@
+/*
+Package hdf5 reads and writes 2D datasets of HDF5 files as matrix.Matf64
+objects.
+
+This package is a thin layer over gonum.org/v1/hdf5, which relies on cgo and
+on the HDF5 C library. To keep the matrix package free of these requirements,
+the functions of this package are only compiled with the hdf5 build tag:
+
+	go get gonum.org/v1/hdf5
+	go build -tags hdf5
+
+Without the tag, this package is empty.
+*/
+package hdf5
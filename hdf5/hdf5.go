@@ -0,0 +1,81 @@
+//go:build hdf5
+// +build hdf5
+
+package hdf5
+
+import (
+	"fmt"
+
+	"github.com/gocrunch/matrix"
+	h5 "gonum.org/v1/hdf5"
+)
+
+/*
+ReadDataset reads the dataset with the given name from the HDF5 file at path.
+The dataset must be one or two dimensional, and one dimensional datasets are
+returned as row vectors. Whatever the type of the stored values, they are
+converted to float64 by the HDF5 library. For example:
+
+	m, err := hdf5.ReadDataset("run42.h5", "/detector/counts")
+*/
+func ReadDataset(path, name string) (*matrix.Matf64, error) {
+	f, err := h5.OpenFile(path, h5.F_ACC_RDONLY)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	ds, err := f.OpenDataset(name)
+	if err != nil {
+		return nil, err
+	}
+	defer ds.Close()
+	space := ds.Space()
+	defer space.Close()
+	dims, _, err := space.SimpleExtentDims()
+	if err != nil {
+		return nil, err
+	}
+	var r, c int
+	switch len(dims) {
+	case 1:
+		r, c = 1, int(dims[0])
+	case 2:
+		r, c = int(dims[0]), int(dims[1])
+	default:
+		return nil, fmt.Errorf("hdf5: dataset %s has %d dimensions, expected 1 or 2", name, len(dims))
+	}
+	vals := make([]float64, r*c)
+	if err = ds.Read(&vals); err != nil {
+		return nil, err
+	}
+	return matrix.Matf64FromData(vals, r, c), nil
+}
+
+/*
+WriteDataset writes m as a 2D dataset of doubles with the given name in the
+HDF5 file at path. The file is created if it does not exist, and any group in
+the name of the dataset must already exist.
+*/
+func WriteDataset(path, name string, m *matrix.Matf64) error {
+	f, err := h5.OpenFile(path, h5.F_ACC_RDWR)
+	if err != nil {
+		f, err = h5.CreateFile(path, h5.F_ACC_EXCL)
+		if err != nil {
+			return err
+		}
+	}
+	defer f.Close()
+	r, c := m.Shape()
+	space, err := h5.CreateSimpleDataspace([]uint{uint(r), uint(c)}, nil)
+	if err != nil {
+		return err
+	}
+	defer space.Close()
+	ds, err := f.CreateDataset(name, h5.T_NATIVE_DOUBLE, space)
+	if err != nil {
+		return err
+	}
+	defer ds.Close()
+	vals := m.ToSlice1D()
+	return ds.Write(&vals)
+}
@@ -0,0 +1,27 @@
+//go:build hdf5
+// +build hdf5
+
+package hdf5
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gocrunch/matrix"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataset(t *testing.T) {
+	t.Helper()
+	filename := "dataset_test.h5"
+	defer os.Remove(filename)
+	m := matrix.RandMatf64(7, 3)
+	if err := WriteDataset(filename, "m", m); err != nil {
+		t.Fatal(err)
+	}
+	n, err := ReadDataset(filename, "m")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, m.Equals(n), "should be equal")
+}
@@ -0,0 +1,178 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Side selects whether ApplyHouseholder and ApplyGivens multiply the mat from
+the left, acting on its rows, or from the right, acting on its columns.
+*/
+type Side int
+
+const (
+	// SideLeft replaces the mat m by P*m, combining rows of m.
+	SideLeft Side = iota
+	// SideRight replaces the mat m by m*P, combining columns of m.
+	SideRight
+)
+
+/*
+HouseholderVector returns the Householder vector v and the scalar beta such
+that the reflection P = I - beta*v*vᵀ maps x to |x|*e1, that is the vector of
+the same length as x whose first element is the norm of x and whose other
+elements are 0. v[0] is always 1, so the rest of v can be stored in the
+elements that the reflection zeroes, as LAPACK does. beta is 0 when x is
+already a nonnegative multiple of e1. x is left intact.
+
+With ApplyHouseholder, this is the building block of QR factorizations and
+reductions to Hessenberg or bidiagonal form. For example, one step of a QR
+factorization of m zeroes the subdiagonal of its first column with:
+
+	v, beta := matrix.HouseholderVector(m.Col(0).Squeeze())
+	m.ApplyHouseholder(matrix.SideLeft, v, beta, 0, 0)
+*/
+func HouseholderVector(x []float64) ([]float64, float64) {
+	if len(x) == 0 {
+		s := "\nIn %s, the vector cannot be empty.\n"
+		s = fmt.Sprintf(s, "HouseholderVector()")
+		printErr(s)
+	}
+	v := make([]float64, len(x))
+	v[0] = 1
+	copy(v[1:], x[1:])
+	sigma := dotf64Helper(x[1:], x[1:])
+	x0 := x[0]
+	switch {
+	case sigma == 0 && x0 >= 0:
+		return v, 0
+	case sigma == 0:
+		return v, 2
+	}
+	mu := math.Sqrt(x0*x0 + sigma)
+	// The first element is computed without cancellation when x0 > 0.
+	var v0 float64
+	if x0 <= 0 {
+		v0 = x0 - mu
+	} else {
+		v0 = -sigma / (x0 + mu)
+	}
+	beta := 2 * v0 * v0 / (sigma + v0*v0)
+	for i := 1; i < len(v); i++ {
+		v[i] /= v0
+	}
+	return v, beta
+}
+
+/*
+ApplyHouseholder multiplies part of the mat in place by the reflection
+P = I - beta*v*vᵀ, as returned by HouseholderVector. With SideLeft, the
+len(v) rows starting at row r0 are combined, in the columns from c0 on.
+With SideRight, the len(v) columns starting at column c0 are combined, in
+the rows from r0 on. Restricting the columns (or rows) to the ones that are
+not already zero saves most of the work in factorizations. The cost is
+O(len(v)) per affected column (or row), and P is never formed.
+*/
+func (m *Matf64) ApplyHouseholder(side Side, v []float64, beta float64, r0, c0 int) *Matf64 {
+	checkApply("ApplyHouseholder()", m, side, len(v), r0, c0)
+	m.materialize()
+	if beta == 0 {
+		return m
+	}
+	if side == SideLeft {
+		// m = m - beta*v*(vᵀ*m)
+		for j := c0; j < m.c; j++ {
+			w := 0.0
+			for i, x := range v {
+				w += x * m.vals[(r0+i)*m.c+j]
+			}
+			w *= beta
+			for i, x := range v {
+				m.vals[(r0+i)*m.c+j] -= w * x
+			}
+		}
+		return m
+	}
+	// m = m - beta*(m*v)*vᵀ
+	for i := r0; i < m.r; i++ {
+		row := m.vals[i*m.c+c0 : i*m.c+c0+len(v)]
+		w := beta * dotf64Helper(row, v)
+		for j, x := range v {
+			row[j] -= w * x
+		}
+	}
+	return m
+}
+
+/*
+GivensRotation returns c, s and r such that the rotation
+
+	[ c  s ] [ a ]   [ r ]
+	[-s  c ] [ b ] = [ 0 ]
+
+zeroes b, with c² + s² = 1 and r = ±hypot(a, b). When both a and b are 0,
+the rotation is the identity. With ApplyGivens, this zeroes single elements,
+which is cheaper than Householder reflections for mats that are already
+nearly triangular, such as Hessenberg mats.
+*/
+func GivensRotation(a, b float64) (c, s, r float64) {
+	if b == 0 {
+		return 1, 0, a
+	}
+	r = math.Hypot(a, b)
+	return a / r, b / r, r
+}
+
+/*
+ApplyGivens applies the rotation by c and s returned by GivensRotation to the
+mat in place. With SideLeft the rows i and k are combined:
+
+	row i = c*(row i) + s*(row k)
+	row k = -s*(row i) + c*(row k)
+
+so that if c and s were computed from m[i][j] and m[k][j], m[k][j] becomes 0.
+With SideRight the columns i and k are combined in the same way, so that if
+c and s were computed from m[j][i] and m[j][k], m[j][k] becomes 0. Negative
+indices count from the end.
+*/
+func (m *Matf64) ApplyGivens(side Side, c, s float64, i, k int) *Matf64 {
+	checkApply("ApplyGivens()", m, side, 0, 0, 0)
+	m.materialize()
+	if side == SideLeft {
+		i = normIndex("ApplyGivens()", "row", i, m.r)
+		k = normIndex("ApplyGivens()", "row", k, m.r)
+		a, b := m.vals[i*m.c:(i+1)*m.c], m.vals[k*m.c:(k+1)*m.c]
+		for j := range a {
+			a[j], b[j] = c*a[j]+s*b[j], -s*a[j]+c*b[j]
+		}
+		return m
+	}
+	i = normIndex("ApplyGivens()", "column", i, m.c)
+	k = normIndex("ApplyGivens()", "column", k, m.c)
+	for r := 0; r < m.r; r++ {
+		a, b := m.vals[r*m.c+i], m.vals[r*m.c+k]
+		m.vals[r*m.c+i], m.vals[r*m.c+k] = c*a+s*b, -s*a+c*b
+	}
+	return m
+}
+
+// checkApply checks the side passed to ApplyHouseholder and ApplyGivens, and
+// that n rows (or columns) starting at r0 (or c0) fit in the mat.
+func checkApply(fn string, m *Matf64, side Side, n, r0, c0 int) {
+	if side != SideLeft && side != SideRight {
+		s := "\nIn %s, %d is not a valid side.\n"
+		s = fmt.Sprintf(s, fn, side)
+		printHelperErr(s)
+	}
+	rn, cn := n, 0
+	if side == SideRight {
+		rn, cn = 0, n
+	}
+	if r0 < 0 || c0 < 0 || r0+rn > m.r || c0+cn > m.c {
+		s := "\nIn %s, %d elements starting at row %d and column %d do not\n"
+		s += "fit in a %dX%d mat.\n"
+		s = fmt.Sprintf(s, fn, n, r0, c0, m.r, m.c)
+		printHelperErr(s)
+	}
+}
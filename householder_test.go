@@ -0,0 +1,66 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHouseholderVector(t *testing.T) {
+	t.Helper()
+	for _, x := range [][]float64{{3, 4}, {-3, 4, 12}, {2, 0, 0}, {-2, 0}, {0, 1}} {
+		v, beta := HouseholderVector(x)
+		assert.Equal(t, 1.0, v[0], "should be equal")
+		p := Identityf64(len(x)).Sub(Matf64FromData(v, len(v), 1).Outer(Matf64FromData(v)).Mul(beta))
+		want := make([]float64, len(x))
+		want[0] = math.Sqrt(dotf64Helper(x, x))
+		assert.InDeltaSlice(t, want, p.Dot(Matf64FromData(x, len(x), 1)).vals, 1e-14, "should be equal")
+	}
+}
+
+func TestApplyHouseholderf64(t *testing.T) {
+	t.Helper()
+	// A QR factorization with Householder reflections.
+	a := RandMatf64(5, 3)
+	r := a.Copy()
+	q := Identityf64(5)
+	for j := 0; j < 3; j++ {
+		x := make([]float64, 5-j)
+		for i := range x {
+			x[i] = r.Get(j+i, j)
+		}
+		v, beta := HouseholderVector(x)
+		r.ApplyHouseholder(SideLeft, v, beta, j, j)
+		q.ApplyHouseholder(SideRight, v, beta, 0, j)
+	}
+	for i := 1; i < 5; i++ {
+		for j := 0; j < i && j < 3; j++ {
+			assert.InDelta(t, 0, r.Get(i, j), 1e-14, "should be zero")
+		}
+	}
+	assert.InDeltaSlice(t, a.vals, q.Dot(r).vals, 1e-14, "should be equal")
+	assert.InDeltaSlice(t, Identityf64(5).vals, q.DotT(q).vals, 1e-14, "should be orthogonal")
+}
+
+func TestGivensf64(t *testing.T) {
+	t.Helper()
+	c, s, r := GivensRotation(3, 4)
+	assert.Equal(t, []float64{0.6, 0.8, 5}, []float64{c, s, r}, "should be equal")
+	c, s, r = GivensRotation(-2, 0)
+	assert.Equal(t, []float64{1, 0, -2}, []float64{c, s, r}, "should be equal")
+
+	m := Matf64FromData([][]float64{{3, 1, 2}, {4, 5, 6}, {0, 7, 8}})
+	c, s, _ = GivensRotation(m.Get(0, 0), m.Get(1, 0))
+	m.ApplyGivens(SideLeft, c, s, 0, 1)
+	assert.InDeltaSlice(t, []float64{5, 0, 0}, m.Col(0).vals, 1e-14, "should be equal")
+	c, s, _ = GivensRotation(m.Get(1, 1), m.Get(2, 1))
+	m.ApplyGivens(SideLeft, c, s, -2, -1)
+	assert.InDelta(t, 0, m.Get(2, 1), 1e-14, "should be zero")
+
+	n := Matf64FromData([][]float64{{1, 1}, {2, 3}})
+	c, s, _ = GivensRotation(n.Get(0, 0), n.Get(0, 1))
+	n.ApplyGivens(SideRight, c, s, 0, 1)
+	assert.InDeltaSlice(t, []float64{math.Sqrt2, 0}, n.Row(0).vals, 1e-14, "should be equal")
+	assert.InDeltaSlice(t, []float64{5 / math.Sqrt2, 1 / math.Sqrt2}, n.Row(1).vals, 1e-14, "should be equal")
+}
@@ -0,0 +1,76 @@
+package matrix
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+/*
+Matf64FromImage converts an image to a mat holding the grayscale intensity
+of each of its pixels, as a float64 between 0.0 (black) and 1.0 (white). The
+mat has one row per line of pixels, and one column per pixel of each line,
+so that the pixel at (x, y) of the bounds of img is stored in row y and
+column x, relative to the top left corner. Colored images are converted to
+grayscale in the same way as color.Gray16Model.
+*/
+func Matf64FromImage(img image.Image) *Matf64 {
+	b := img.Bounds()
+	m := Newf64(b.Dy(), b.Dx())
+	idx := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			g := color.Gray16Model.Convert(img.At(x, y)).(color.Gray16)
+			m.vals[idx] = float64(g.Y) / math.MaxUint16
+			idx++
+		}
+	}
+	return m
+}
+
+/*
+ToGray converts the mat to an 8 bit grayscale image, and is the inverse of
+Matf64FromImage. Each element is the intensity of a pixel, where 0.0 is
+black and 1.0 is white. Values outside of this range are clipped, and NaNs
+are black. To display a mat with an arbitrary range of values, scale it
+first, for instance with:
+
+	_, lo := m.Min()
+	_, hi := m.Max()
+	img := m.Copy().Sub(lo).Div(hi - lo).ToGray()
+*/
+func (m *Matf64) ToGray() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, m.c, m.r))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			img.Pix[i*img.Stride+j] = uint8(math.Floor(intensity(m.vals[i*m.c+j])*math.MaxUint8 + 0.5))
+		}
+	}
+	return img
+}
+
+/*
+ToImage converts the mat to a 16 bit grayscale image, following the same
+rules as ToGray. The extra precision makes the conversion with
+Matf64FromImage lossless for intensities that are multiples of 1/65535.
+*/
+func (m *Matf64) ToImage() image.Image {
+	img := image.NewGray16(image.Rect(0, 0, m.c, m.r))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			img.SetGray16(j, i, color.Gray16{uint16(math.Floor(intensity(m.vals[i*m.c+j])*math.MaxUint16 + 0.5))})
+		}
+	}
+	return img
+}
+
+// intensity clips v to [0, 1], with NaNs becoming 0.
+func intensity(v float64) float64 {
+	if !(v > 0.0) {
+		return 0.0
+	}
+	if v > 1.0 {
+		return 1.0
+	}
+	return v
+}
@@ -0,0 +1,39 @@
+package matrix
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromImage(t *testing.T) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(10, 20, 13, 22))
+	img.Set(10, 20, color.White)
+	img.Set(12, 21, color.RGBA{255, 255, 255, 255})
+	img.Set(11, 20, color.Black)
+	m := Matf64FromImage(img)
+	r, c := m.Shape()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be white")
+	assert.Equal(t, 1.0, m.Get(1, 2), "should be white")
+	assert.Equal(t, 0.0, m.Get(0, 1), "should be black")
+}
+
+func TestToImagef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{0, 0.5, 1}, {-1, 2, math.NaN()}})
+	g := m.ToGray()
+	assert.Equal(t, image.Rect(0, 0, 3, 2), g.Bounds(), "should be equal")
+	assert.Equal(t, []uint8{0, 128, 255, 0, 255, 0}, g.Pix, "should be equal")
+
+	m = RandMatf64(7, 5)
+	m.Map(func(v *float64) {
+		*v = math.Floor(*v*math.MaxUint16+0.5) / math.MaxUint16
+	})
+	assert.True(t, m.Equals(Matf64FromImage(m.ToImage())), "should be lossless")
+}
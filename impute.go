@@ -0,0 +1,101 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Imputation is a strategy used by ImputeNaN to replace missing values.
+*/
+type Imputation int
+
+const (
+	// ImputeMean replaces the NaNs of each column with the mean of the other
+	// values of that column.
+	ImputeMean Imputation = iota
+	// ImputeMedian replaces the NaNs of each column with the median of the
+	// other values of that column, which is robust to outliers.
+	ImputeMedian
+	// ImputeConstant replaces all the NaNs with a given value, just like
+	// FillNaN.
+	ImputeConstant
+)
+
+/*
+FillNaN replaces every NaN in the mat with v, in place, and returns the mat
+so that calls can be chained.
+*/
+func (m *Matf64) FillNaN(v float64) *Matf64 {
+	m.materialize()
+	for i := range m.vals {
+		if math.IsNaN(m.vals[i]) {
+			m.vals[i] = v
+		}
+	}
+	return m
+}
+
+/*
+ImputeNaN replaces the NaNs of the mat, in place, following the given
+strategy. It is typically used to clean up a mat loaded with Matf64FromCSV,
+where every cell that could not be parsed is a NaN. For example:
+
+	m.ImputeNaN(matrix.ImputeMean)
+	m.ImputeNaN(matrix.ImputeMedian)
+	m.ImputeNaN(matrix.ImputeConstant, -1)
+
+The column strategies only use the values of each column that are not NaN,
+and leave columns that only hold NaNs untouched. ImputeConstant requires
+the value to fill with, while the other strategies take no value.
+*/
+func (m *Matf64) ImputeNaN(strategy Imputation, args ...float64) *Matf64 {
+	if strategy == ImputeConstant {
+		if len(args) != 1 {
+			s := "\nIn %s, ImputeConstant expects the value to fill with, but\n"
+			s += "%d values were received.\n"
+			s = fmt.Sprintf(s, "ImputeNaN()", len(args))
+			printErr(s)
+		}
+		return m.FillNaN(args[0])
+	}
+	if strategy != ImputeMean && strategy != ImputeMedian {
+		s := "\nIn %s, %d is not a valid imputation strategy.\n"
+		s = fmt.Sprintf(s, "ImputeNaN()", strategy)
+		printErr(s)
+	}
+	if len(args) != 0 {
+		s := "\nIn %s, only ImputeConstant takes a value, but %d values were\n"
+		s += "received.\n"
+		s = fmt.Sprintf(s, "ImputeNaN()", len(args))
+		printErr(s)
+	}
+	m.materialize()
+	col := make([]float64, 0, m.r)
+	for j := 0; j < m.c; j++ {
+		col = col[:0]
+		for i := 0; i < m.r; i++ {
+			if v := m.vals[i*m.c+j]; !math.IsNaN(v) {
+				col = append(col, v)
+			}
+		}
+		if len(col) == 0 || len(col) == m.r {
+			continue
+		}
+		var fill float64
+		if strategy == ImputeMean {
+			for _, v := range col {
+				fill += v
+			}
+			fill /= float64(len(col))
+		} else {
+			fill = median(col)
+		}
+		for i := 0; i < m.r; i++ {
+			if math.IsNaN(m.vals[i*m.c+j]) {
+				m.vals[i*m.c+j] = fill
+			}
+		}
+	}
+	return m
+}
@@ -0,0 +1,39 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillNaNf64(t *testing.T) {
+	t.Helper()
+	nan := math.NaN()
+	m := Matf64FromData([]float64{1, nan, 3, nan}).FillNaN(0)
+	assert.Equal(t, []float64{1, 0, 3, 0}, m.vals, "should be equal")
+}
+
+func TestImputeNaNf64(t *testing.T) {
+	t.Helper()
+	nan := math.NaN()
+	data := [][]float64{
+		{1, nan, nan},
+		{nan, 2, nan},
+		{3, 4, nan},
+		{8, 100, nan},
+	}
+	m := Matf64FromData(data).ImputeNaN(ImputeMean)
+	assert.Equal(t, 4.0, m.Get(1, 0), "should be the column mean")
+	assert.Equal(t, 35.3, math.Floor(m.Get(0, 1)*10)/10, "should be the column mean")
+	assert.True(t, math.IsNaN(m.Get(0, 2)), "should leave all NaN columns")
+
+	m = Matf64FromData(data).ImputeNaN(ImputeMedian)
+	assert.Equal(t, 3.0, m.Get(1, 0), "should be the column median")
+	assert.Equal(t, 4.0, m.Get(0, 1), "should be the column median")
+
+	m = Matf64FromData(data).ImputeNaN(ImputeConstant, -1)
+	assert.Equal(t, -1.0, m.Get(1, 0), "should be equal")
+	assert.Equal(t, -1.0, m.Get(3, 2), "should be equal")
+	assert.Equal(t, 100.0, m.Get(3, 1), "should be equal")
+}
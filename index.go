@@ -0,0 +1,22 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+// normIndex returns the index x into an axis of length n, where negative
+// values count from the end as in Python, so that -1 is the last element.
+// Every method taking a row or column index goes through it, so that they
+// all accept the same range, [-n, n). what names the axis in the error
+// printed when x is outside of that range.
+func normIndex(fn, what string, x, n int) int {
+	if (x >= n) || (x < -n) {
+		s := "\nIn %s, %s %d is outside of the bounds [-%d, %d)\n"
+		s = fmt.Sprintf(s, fn, what, x, n, n)
+		printHelperErr(s)
+	}
+	if x < 0 {
+		x += n
+	}
+	return x
+}
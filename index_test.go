@@ -0,0 +1,48 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormIndex(t *testing.T) {
+	t.Helper()
+	assert.Equal(t, 0, normIndex("test", "row", 0, 3), "should be equal")
+	assert.Equal(t, 2, normIndex("test", "row", 2, 3), "should be equal")
+	assert.Equal(t, 2, normIndex("test", "row", -1, 3), "should be equal")
+	assert.Equal(t, 0, normIndex("test", "row", -3, 3), "should be equal")
+}
+
+func TestNegativeIndexf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Equal(t, 6.0, m.Get(-1, -1), "should be equal")
+	assert.Equal(t, m.Get(0, 2), m.Get(-2, -1), "should be equal")
+	assert.Equal(t, m.Sum(0, 1), m.Sum(0, -1), "should be equal")
+	assert.Equal(t, m.Avg(1, 0), m.Avg(1, -3), "should be equal")
+	assert.Equal(t, m.Prd(0, 0), m.Prd(0, -2), "should be equal")
+	assert.Equal(t, m.Std(1, 2), m.Std(1, -1), "should be equal")
+	idx, val := m.Max(1, -1)
+	assert.Equal(t, 1, idx, "should be equal")
+	assert.Equal(t, 6.0, val, "should be equal")
+	idx, val = m.Min(0, -1)
+	assert.Equal(t, 0, idx, "should be equal")
+	assert.Equal(t, 4.0, val, "should be equal")
+	assert.Equal(t, 5.0, m.Median(0, -1), "should be equal")
+	m.Set(-1, 0, 9)
+	assert.Equal(t, 9.0, m.Get(1, 0), "should be equal")
+	m.DeleteCol(-1)
+	assert.Equal(t, []float64{1, 2, 9, 5}, m.vals, "should be equal")
+}
+
+func TestNegativeIndexf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{{1, 2, 3}, {4, 5, 6}})
+	assert.Equal(t, float32(6), m.Get(-1, -1), "should be equal")
+	assert.Equal(t, m.Sum(1, 2), m.Sum(1, -1), "should be equal")
+	_, val := m.Max(0, -2)
+	assert.Equal(t, float32(3), val, "should be equal")
+	m.Set(-2, -2, 7)
+	assert.Equal(t, float32(7), m.Get(0, 1), "should be equal")
+}
@@ -0,0 +1,73 @@
+//go:build go1.23
+
+package matrix
+
+import (
+	"iter"
+)
+
+/*
+Elements returns an iterator over the values of the mat, in row major order,
+along with their row and column:
+
+	for idx, v := range m.Elements() {
+		fmt.Println(idx[0], idx[1], v)
+	}
+
+It is named Elements rather than All, since All already checks a predicate
+against every value. The iterator is only available with Go 1.23 or later.
+*/
+func (m *Matf64) Elements() iter.Seq2[[2]int, float64] {
+	return func(yield func([2]int, float64) bool) {
+		for i := 0; i < m.r; i++ {
+			for j := 0; j < m.c; j++ {
+				if !yield([2]int{i, j}, m.vals[i*m.c+j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+/*
+Rows returns an iterator over the rows of the mat, along with their index:
+
+	for i, row := range m.Rows() {
+		row[0] = float64(i)
+	}
+
+No values are copied: each row aliases the values of the mat, so writing to
+it changes the mat. The rows must not be kept after the loop, and the shape
+of the mat must not change while iterating.
+*/
+func (m *Matf64) Rows() iter.Seq2[int, []float64] {
+	return func(yield func(int, []float64) bool) {
+		m.materialize()
+		for i := 0; i < m.r; i++ {
+			end := (i + 1) * m.c
+			if !yield(i, m.vals[i*m.c:end:end]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Cols returns an iterator over the columns of the mat, along with their index.
+Since the values of a column are not contiguous, each column is copied into
+a slice that is reused from one iteration to the next, so writing to it does
+not change the mat, and it must be copied to be kept after the iteration.
+*/
+func (m *Matf64) Cols() iter.Seq2[int, []float64] {
+	return func(yield func(int, []float64) bool) {
+		col := make([]float64, m.r)
+		for j := 0; j < m.c; j++ {
+			for i := range col {
+				col[i] = m.vals[i*m.c+j]
+			}
+			if !yield(j, col) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+//go:build go1.23
+
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	sum := 0.0
+	for idx, v := range m.Elements() {
+		assert.Equal(t, m.Get(idx[0], idx[1]), v, "should be equal")
+		sum += v
+	}
+	assert.Equal(t, 10.0, sum, "should be equal")
+	n := 0
+	for range m.Elements() {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n, "should stop early")
+}
+
+func TestRowsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	n := m.CloneCOW()
+	for i, row := range n.Rows() {
+		row[0] = float64(i * 10)
+	}
+	assert.Equal(t, []float64{0, 2, 10, 4}, n.vals, "should write through")
+	assert.Equal(t, []float64{1, 2, 3, 4}, m.vals, "should be left intact")
+}
+
+func TestColsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	var cols [][]float64
+	for _, col := range m.Cols() {
+		cols = append(cols, append([]float64(nil), col...))
+	}
+	assert.Equal(t, [][]float64{{1, 3}, {2, 4}}, cols, "should be equal")
+}
@@ -0,0 +1,114 @@
+package matrix
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonMatf64 and jsonMatf32 define the JSON representation of the mat
+// objects, which is {"rows":r,"cols":c,"data":[...]}, where data contains
+// the r*c values of the mat in row-major order. A Matf64 with named rows or
+// columns also has a rowNames or colNames list.
+type jsonMatf64 struct {
+	Rows     int               `json:"rows"`
+	Cols     int               `json:"cols"`
+	Data     []float64         `json:"data"`
+	RowNames []string          `json:"rowNames,omitempty"`
+	ColNames []string          `json:"colNames,omitempty"`
+	Meta     map[string]string `json:"meta,omitempty"`
+}
+
+type jsonMatf32 struct {
+	Rows int       `json:"rows"`
+	Cols int       `json:"cols"`
+	Data []float32 `json:"data"`
+}
+
+/*
+MarshalJSON implements the json.Marshaler interface. A Matf64 is encoded as
+
+	{"rows":2,"cols":3,"data":[1,2,3,4,5,6]}
+
+where data lists the values of the mat row by row. The names of the rows and
+columns, if any, are listed in rowNames and colNames, and the metadata
+attached with SetMeta in meta. Note that, like any
+other float64 handled by the encoding/json package, NaN and infinite values
+cannot be encoded.
+*/
+func (m *Matf64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMatf64{
+		Rows:     m.r,
+		Cols:     m.c,
+		Data:     m.vals,
+		RowNames: m.RowNames(),
+		ColNames: m.ColNames(),
+		Meta:     m.meta,
+	})
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface, and is the inverse
+of MarshalJSON. The number of values in data must be equal to rows*cols,
+and the names, when present, must match the number of rows and columns.
+*/
+func (m *Matf64) UnmarshalJSON(b []byte) error {
+	var j jsonMatf64
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if !jsonShapeOK(j.Rows, j.Cols, len(j.Data)) {
+		return fmt.Errorf(wrongLength, "UnmarshalJSON()", j.Rows, j.Cols, len(j.Data))
+	}
+	if (j.RowNames != nil && len(j.RowNames) != j.Rows) ||
+		(j.ColNames != nil && len(j.ColNames) != j.Cols) {
+		s := "In %s: %d row names and %d column names for a %dx%d mat"
+		return fmt.Errorf(s, "UnmarshalJSON()", len(j.RowNames), len(j.ColNames), j.Rows, j.Cols)
+	}
+	vals := make([]float64, len(j.Data), 2*len(j.Data))
+	copy(vals, j.Data)
+	*m = Matf64{r: j.Rows, c: j.Cols, vals: vals}
+	m.editNames(j.RowNames, j.ColNames)
+	for k, v := range j.Meta {
+		m.SetMeta(k, v)
+	}
+	return nil
+}
+
+/*
+MarshalJSON implements the json.Marshaler interface, using the same format
+as Matf64.
+*/
+func (m *Matf32) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMatf32{m.r, m.c, m.vals})
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface, and is the inverse
+of MarshalJSON. The number of values in data must be equal to rows*cols.
+*/
+func (m *Matf32) UnmarshalJSON(b []byte) error {
+	var j jsonMatf32
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	if !jsonShapeOK(j.Rows, j.Cols, len(j.Data)) {
+		return fmt.Errorf(wrongLength, "UnmarshalJSON()", j.Rows, j.Cols, len(j.Data))
+	}
+	vals := make([]float32, len(j.Data), 2*len(j.Data))
+	copy(vals, j.Data)
+	*m = Matf32{r: j.Rows, c: j.Cols, vals: vals}
+	return nil
+}
+
+// jsonShapeOK reports whether a rowsXcols mat holds exactly n values. The
+// product is checked by division, so that a forged shape whose product
+// overflows cannot match n.
+func jsonShapeOK(rows, cols, n int) bool {
+	if rows < 0 || cols < 0 {
+		return false
+	}
+	if cols == 0 {
+		return n == 0
+	}
+	return n%cols == 0 && rows == n/cols
+}
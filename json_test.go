@@ -0,0 +1,54 @@
+package matrix
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 3)
+	for i := range m.vals {
+		m.vals[i] = float64(i) + 0.5
+	}
+	b, err := json.Marshal(m)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, `{"rows":2,"cols":3,"data":[0.5,1.5,2.5,3.5,4.5,5.5]}`, string(b))
+
+	n := Newf64()
+	assert.Nil(t, json.Unmarshal(b, n), "should not fail")
+	assert.True(t, m.Equals(n), "should be equal")
+
+	var payload struct {
+		Weights *Matf64 `json:"weights"`
+	}
+	err = json.Unmarshal([]byte(`{"weights":{"rows":1,"cols":2,"data":[1,2]}}`), &payload)
+	assert.Nil(t, err, "should not fail")
+	assert.True(t, payload.Weights.Equals(Matf64FromData([]float64{1, 2})))
+
+	err = json.Unmarshal([]byte(`{"rows":2,"cols":2,"data":[1,2]}`), n)
+	assert.NotNil(t, err, "should fail on a size mismatch")
+
+	err = json.Unmarshal([]byte(`{"rows":4611686018427387904,"cols":4,"data":[]}`), n)
+	assert.NotNil(t, err, "should fail on an overflowing shape")
+	err = json.Unmarshal([]byte(`{"rows":-2,"cols":-1,"data":[1,2]}`), n)
+	assert.NotNil(t, err, "should fail on a negative shape")
+}
+
+func TestJSONf32(t *testing.T) {
+	t.Helper()
+	m := Matf32FromData([][]float32{{1, 2}, {3, 4}})
+	b, err := json.Marshal(m)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, `{"rows":2,"cols":2,"data":[1,2,3,4]}`, string(b))
+
+	n := Newf32()
+	assert.Nil(t, json.Unmarshal(b, n), "should not fail")
+	assert.True(t, m.Equals(n), "should be equal")
+	assert.Equal(t, 8, cap(n.vals), "should have twice the capacity, as Matf64")
+
+	err = json.Unmarshal([]byte(`{"rows":4611686018427387904,"cols":4,"data":[]}`), n)
+	assert.NotNil(t, err, "should fail on an overflowing shape")
+}
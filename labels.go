@@ -0,0 +1,194 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+// matNames holds the optional names of the rows and columns of a mat. It is
+// never modified once created, so that mats can share it freely.
+type matNames struct {
+	rows, cols []string
+}
+
+/*
+SetColNames names the columns of the mat, giving it a lightweight header, as
+in a data frame:
+
+	m.SetColNames([]string{"height", "weight"})
+	w := m.Col(m.ColIndex("weight"))
+
+The names are kept by Copy, CloneCOW, Row, Col, T, Concat and by the methods
+that add, delete or reorder rows and columns, such as AppendCol, DeleteCol
+or SortRowsBy. They are also used as the header of the file written by
+ToCSV, and are encoded by MarshalJSON. Mats built from scratch by other
+methods, such as Dot, do not have names. There must be exactly one name per
+column, and passing nil removes the names. The names are copied.
+*/
+func (m *Matf64) SetColNames(names []string) *Matf64 {
+	m.setNames("SetColNames()", m.RowNames(), names)
+	return m
+}
+
+/*
+SetRowNames names the rows of the mat, following the same rules as
+SetColNames. Row names are not written by ToCSV.
+*/
+func (m *Matf64) SetRowNames(names []string) *Matf64 {
+	m.setNames("SetRowNames()", names, m.ColNames())
+	return m
+}
+
+/*
+ColNames returns a copy of the names of the columns of the mat, or nil if
+they are not named.
+*/
+func (m *Matf64) ColNames() []string {
+	if m.names == nil || len(m.names.cols) != m.c {
+		return nil
+	}
+	return copyNames(m.names.cols)
+}
+
+/*
+RowNames returns a copy of the names of the rows of the mat, or nil if they
+are not named.
+*/
+func (m *Matf64) RowNames() []string {
+	if m.names == nil || len(m.names.rows) != m.r {
+		return nil
+	}
+	return copyNames(m.names.rows)
+}
+
+/*
+ColIndex returns the index of the first column with the given name, or -1 if
+there is no such column.
+*/
+func (m *Matf64) ColIndex(name string) int {
+	return nameIndex(m.ColNames(), name)
+}
+
+/*
+RowIndex returns the index of the first row with the given name, or -1 if
+there is no such row.
+*/
+func (m *Matf64) RowIndex(name string) int {
+	return nameIndex(m.RowNames(), name)
+}
+
+func nameIndex(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// setNames replaces the names of m, after checking that there is one name per
+// row and per column. Either slice may be nil.
+func (m *Matf64) setNames(fn string, rows, cols []string) {
+	if rows != nil && len(rows) != m.r {
+		s := "\nIn %s, %d row names were received for %d rows.\n"
+		s = fmt.Sprintf(s, fn, len(rows), m.r)
+		printHelperErr(s)
+	}
+	if cols != nil && len(cols) != m.c {
+		s := "\nIn %s, %d column names were received for %d columns.\n"
+		s = fmt.Sprintf(s, fn, len(cols), m.c)
+		printHelperErr(s)
+	}
+	m.editNames(copyNames(rows), copyNames(cols))
+}
+
+// editNames stores the given row and column names, which must match the
+// current shape of m, without copying them. Methods changing the shape of m
+// read the old names with RowNames and ColNames before changing it, and store
+// the edited names with editNames afterwards.
+func (m *Matf64) editNames(rows, cols []string) {
+	if rows == nil && cols == nil {
+		m.names = nil
+		return
+	}
+	m.names = &matNames{rows: rows, cols: cols}
+}
+
+// copyNames returns a copy of names, or nil if names is nil.
+func copyNames(names []string) []string {
+	if names == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(names)), names...)
+}
+
+// insertName returns a copy of names with name inserted at index x, or nil if
+// names is nil.
+func insertName(names []string, x int, name string) []string {
+	if names == nil {
+		return nil
+	}
+	n := make([]string, 0, len(names)+1)
+	n = append(append(append(n, names[:x]...), name), names[x:]...)
+	return n
+}
+
+// deleteName returns a copy of names without the name at index x, or nil if
+// names is nil.
+func deleteName(names []string, x int) []string {
+	if names == nil {
+		return nil
+	}
+	n := make([]string, 0, len(names)-1)
+	return append(append(n, names[:x]...), names[x+1:]...)
+}
+
+// resizeNames returns a copy of names truncated or padded with empty names
+// to n names, or nil if names is nil.
+func resizeNames(names []string, n int) []string {
+	if names == nil {
+		return nil
+	}
+	if n <= len(names) {
+		return copyNames(names[:n])
+	}
+	return append(copyNames(names), make([]string, n-len(names))...)
+}
+
+// permuteNames returns names reordered so that the ith name is names[perm[i]],
+// or nil if names is nil.
+func permuteNames(names []string, perm []int) []string {
+	if names == nil {
+		return nil
+	}
+	n := make([]string, len(names))
+	for i, p := range perm {
+		n[i] = names[p]
+	}
+	return n
+}
+
+// rollNames returns names shifted k places to the right, as in Roll, or nil
+// if names is nil.
+func rollNames(names []string, k int) []string {
+	if names == nil {
+		return nil
+	}
+	n := len(names)
+	return append(names[n-k:], names[:n-k]...)
+}
+
+// concatNames returns the column names of a mat with na columns named a
+// concatenated with a mat with nb columns named b. Unnamed columns are given
+// empty names, unless neither mat is named.
+func concatNames(a, b []string, na, nb int) []string {
+	if a == nil && b == nil {
+		return nil
+	}
+	if a == nil {
+		a = make([]string, na)
+	}
+	if b == nil {
+		b = make([]string, nb)
+	}
+	return append(a, b...)
+}
@@ -0,0 +1,98 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColNamesf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Nil(t, m.ColNames(), "should not be named")
+	m.SetColNames([]string{"a", "b", "c"}).SetRowNames([]string{"x", "y"})
+	assert.Equal(t, []string{"a", "b", "c"}, m.ColNames(), "should be equal")
+	assert.Equal(t, 1, m.ColIndex("b"), "should be equal")
+	assert.Equal(t, -1, m.ColIndex("z"), "should be equal")
+	assert.Equal(t, 1, m.RowIndex("y"), "should be equal")
+
+	assert.Equal(t, []string{"a", "b", "c"}, m.Row(1).ColNames(), "should be kept by Row")
+	assert.Equal(t, []string{"y"}, m.Row(1).RowNames(), "should be kept by Row")
+	assert.Equal(t, []string{"c"}, m.Col(-1).ColNames(), "should be kept by Col")
+	assert.Equal(t, []string{"x", "y"}, m.Copy().RowNames(), "should be kept by Copy")
+	assert.Equal(t, []string{"a", "b", "c"}, m.CloneCOW().ColNames(), "should be kept by CloneCOW")
+
+	n := m.Copy().T()
+	assert.Equal(t, []string{"a", "b", "c"}, n.RowNames(), "should be swapped by T")
+	assert.Equal(t, []string{"x", "y"}, n.ColNames(), "should be swapped by T")
+
+	m.SetColNames(nil)
+	assert.Nil(t, m.ColNames(), "should be removed")
+	assert.Equal(t, []string{"x", "y"}, m.RowNames(), "should be kept")
+}
+
+func TestNamesShapef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.SetColNames([]string{"a", "b"}).SetRowNames([]string{"x", "y"})
+	m.DeleteCol(0).AppendCol([]float64{5, 6}).InsertCol(0, []float64{7, 8})
+	assert.Equal(t, []string{"", "b", ""}, m.ColNames(), "should be equal")
+	m.AppendRow([]float64{0, 0, 0}).DeleteRow(0).InsertRow(0, []float64{1, 1, 1})
+	assert.Equal(t, []string{"", "y", ""}, m.RowNames(), "should be equal")
+
+	m = Matf64FromData([][]float64{{3, 1}, {1, 2}, {2, 3}})
+	m.SetRowNames([]string{"c", "a", "b"}).SetColNames([]string{"k", "v"})
+	m.SortRowsBy(0, false)
+	assert.Equal(t, []string{"a", "b", "c"}, m.RowNames(), "should follow the rows")
+	m.PermuteCols([]int{1, 0})
+	assert.Equal(t, []string{"v", "k"}, m.ColNames(), "should follow the columns")
+	m.Roll(1, 0)
+	assert.Equal(t, []string{"c", "a", "b"}, m.RowNames(), "should follow the rows")
+	assert.Equal(t, 3.0, m.Get(m.RowIndex("c"), m.ColIndex("k")), "should be equal")
+	m.ShuffleRows(nil)
+	assert.Equal(t, 3.0, m.Get(m.RowIndex("c"), m.ColIndex("k")), "should be equal")
+
+	n := Matf64FromData([][]float64{{0}, {0}, {0}}).SetColNames([]string{"w"})
+	m.Concat(n)
+	assert.Equal(t, []string{"v", "k", "w"}, m.ColNames(), "should be equal")
+	m.Reshape(1, 9)
+	assert.Nil(t, m.RowNames(), "should be dropped")
+	assert.Nil(t, m.ColNames(), "should be dropped")
+}
+
+func TestNamesCSVf64(t *testing.T) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "names")
+	assert.Nil(t, err, "should not fail")
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "m.csv")
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.SetColNames([]string{"height", "weight, kg"})
+	m.ToCSV(name)
+	n := Matf64FromCSV(name, "weight, kg", "height")
+	assert.Equal(t, []string{"weight, kg", "height"}, n.ColNames(), "should be equal")
+	assert.Equal(t, []float64{2, 1, 4, 3}, n.vals, "should be equal")
+
+	n = Matf64FromCSVReader(strings.NewReader("1,2\n3,4\n"))
+	assert.Nil(t, n.ColNames(), "should not be named")
+}
+
+func TestNamesJSONf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}}).SetColNames([]string{"a", "b"})
+	b, err := json.Marshal(m)
+	assert.Nil(t, err, "should not fail")
+	assert.True(t, bytes.Contains(b, []byte(`"colNames":["a","b"]`)), "should be encoded")
+	assert.False(t, bytes.Contains(b, []byte(`rowNames`)), "should be omitted")
+	n := Newf64()
+	assert.Nil(t, json.Unmarshal(b, n), "should not fail")
+	assert.Equal(t, []string{"a", "b"}, n.ColNames(), "should be equal")
+	err = json.Unmarshal([]byte(`{"rows":1,"cols":1,"data":[1],"colNames":["a","b"]}`), n)
+	assert.NotNil(t, err, "should fail")
+}
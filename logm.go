@@ -0,0 +1,169 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Logm returns the principal logarithm of the square mat m, that is the mat L
+whose eigenvalues have imaginary parts in (-π, π) and such that the matrix
+exponential of L is m. This is useful to interpolate between transformations,
+with (Logm(m) * t) exponentiated for 0 <= t <= 1, or to recover the generator
+Q of a continuous time Markov chain from its transition mat P = exp(Q*t).
+
+The logarithm is computed by inverse scaling and squaring: square roots of
+m are taken until it is close to the identity, the logarithm of the result is
+computed with a Padé approximant, and it is then scaled back by a power of
+2. A real logarithm only exists when m is invertible and its negative
+eigenvalues come in pairs, and this method returns an error when m is
+singular, or when the square roots do not converge, which happens when m has
+negative real eigenvalues. The mat is left intact.
+*/
+func (m *Matf64) Logm() (*Matf64, error) {
+	if m.r != m.c {
+		s := "\nIn %s, the mat must be square, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, "Logm()", m.r, m.c)
+		printErr(s)
+	}
+	n := m.r
+	a := m.Copy()
+	a.names, a.meta = nil, nil
+	if _, err := a.inverse("Logm()"); err != nil {
+		return nil, err
+	}
+	id := Identityf64(n)
+	scale := 1.0
+	report := progressFunc()
+	for dist := a.Copy().Sub(id).norm1(); dist > 0.25; dist = a.Copy().Sub(id).norm1() {
+		if scale > 1<<40 {
+			return nil, fmt.Errorf("In %s: the square roots do not approach the identity", "Logm()")
+		}
+		var ok bool
+		if a, ok = a.sqrtm(); !ok {
+			return nil, fmt.Errorf("In %s: the square root does not converge, the mat may have negative real eigenvalues", "Logm()")
+		}
+		scale *= 2
+		if report != nil {
+			report("Logm()", int(math.Log2(scale)), -1)
+		}
+	}
+	// log(I+x) = integral from 0 to 1 of x*(I+t*x)^-1 dt, and an n point
+	// Gauss-Legendre rule for the integral is the [n/n] Padé approximant.
+	x := a.Sub(id)
+	l := Newf64(n, n)
+	nodes, weights := gaussLegendre(8)
+	for j, t := range nodes {
+		inv, err := id.Copy().Add(x.Copy().Mul(t)).inverse("Logm()")
+		if err != nil {
+			return nil, err
+		}
+		l.Add(x.Dot(inv).Mul(weights[j]))
+	}
+	return l.Mul(scale), nil
+}
+
+// sqrtm returns the principal square root of m with the product form of the
+// Denman-Beavers iteration, and false if the iteration does not converge.
+func (m *Matf64) sqrtm() (*Matf64, bool) {
+	y, z := m.Copy(), Identityf64(m.r)
+	for iter := 0; iter < 100; iter++ {
+		yi, err := y.inverse("Logm()")
+		if err != nil {
+			return nil, false
+		}
+		zi, err := z.inverse("Logm()")
+		if err != nil {
+			return nil, false
+		}
+		next := y.Copy().Add(zi).Mul(0.5)
+		z.Add(yi).Mul(0.5)
+		diff := next.Copy().Sub(y).norm1()
+		y = next
+		if diff <= 1e-14*y.norm1() {
+			return y, true
+		}
+	}
+	return nil, false
+}
+
+// inverse returns the inverse of the square mat m by Gauss-Jordan elimination
+// with partial pivoting, or an error naming fn if m is singular.
+func (m *Matf64) inverse(fn string) (*Matf64, error) {
+	n := m.r
+	a := m.Copy()
+	inv := Identityf64(n)
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if math.Abs(a.vals[i*n+j]) > math.Abs(a.vals[p*n+j]) {
+				p = i
+			}
+		}
+		if a.vals[p*n+j] == 0 {
+			return nil, fmt.Errorf("In %s: the mat is singular", fn)
+		}
+		if p != j {
+			for k := 0; k < n; k++ {
+				a.vals[j*n+k], a.vals[p*n+k] = a.vals[p*n+k], a.vals[j*n+k]
+				inv.vals[j*n+k], inv.vals[p*n+k] = inv.vals[p*n+k], inv.vals[j*n+k]
+			}
+		}
+		d := a.vals[j*n+j]
+		for k := 0; k < n; k++ {
+			a.vals[j*n+k] /= d
+			inv.vals[j*n+k] /= d
+		}
+		for i := 0; i < n; i++ {
+			if f := a.vals[i*n+j]; i != j && f != 0 {
+				for k := 0; k < n; k++ {
+					a.vals[i*n+k] -= f * a.vals[j*n+k]
+					inv.vals[i*n+k] -= f * inv.vals[j*n+k]
+				}
+			}
+		}
+	}
+	return inv, nil
+}
+
+// norm1 returns the 1-norm of m, the largest sum of the absolute values of
+// the elements of a column.
+func (m *Matf64) norm1() float64 {
+	sums := make([]float64, m.c)
+	for i, x := range m.vals {
+		sums[i%m.c] += math.Abs(x)
+	}
+	max := 0.0
+	for _, s := range sums {
+		max = math.Max(max, s)
+	}
+	return max
+}
+
+// gaussLegendre returns the nodes and weights of the n point Gauss-Legendre
+// quadrature rule on [0, 1].
+func gaussLegendre(n int) ([]float64, []float64) {
+	nodes := make([]float64, n)
+	weights := make([]float64, n)
+	for i := 0; i < n; i++ {
+		// Newton's method on the Legendre polynomial P_n, from an
+		// approximation of its ith root on [-1, 1].
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, x
+			for k := 2; k <= n; k++ {
+				p0, p1 = p1, ((2*float64(k)-1)*x*p1-(float64(k)-1)*p0)/float64(k)
+			}
+			dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-16 {
+				break
+			}
+		}
+		nodes[i] = (1 - x) / 2
+		weights[i] = 1 / ((1 - x*x) * dp * dp)
+	}
+	return nodes, weights
+}
@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// expmTest returns the matrix exponential of m with a Taylor series and
+// scaling and squaring, to check Logm against.
+func expmTest(m *Matf64) *Matf64 {
+	n, _ := m.Shape()
+	a := m.Copy().Mul(1.0 / 1024)
+	e, term := Identityf64(n), Identityf64(n)
+	for k := 1; k < 30; k++ {
+		term = term.Dot(a).Mul(1 / float64(k))
+		e.Add(term)
+	}
+	for i := 0; i < 10; i++ {
+		e = e.Dot(e)
+	}
+	return e
+}
+
+func TestLogmf64(t *testing.T) {
+	t.Helper()
+	m := Diagf64([]float64{math.E, math.E * math.E})
+	l, err := m.Logm()
+	assert.Nil(t, err, "should not fail")
+	assert.InDeltaSlice(t, []float64{1, 0, 0, 2}, l.vals, 1e-13, "should be equal")
+
+	l, err = Identityf64(3).Logm()
+	assert.Nil(t, err, "should not fail")
+	assert.InDeltaSlice(t, make([]float64, 9), l.vals, 1e-15, "should be equal")
+
+	// The logarithm of a rotation by 3 radians is the generator of the
+	// rotation.
+	c, s := math.Cos(3), math.Sin(3)
+	l, err = Matf64FromData([][]float64{{c, -s}, {s, c}}).Logm()
+	assert.Nil(t, err, "should not fail")
+	assert.InDeltaSlice(t, []float64{0, -3, 3, 0}, l.vals, 1e-12, "should be equal")
+
+	// The generator of a continuous time Markov chain, from its transition
+	// mat.
+	q := Matf64FromData([][]float64{{-0.5, 0.3, 0.2}, {0.1, -0.4, 0.3}, {0.05, 0.05, -0.1}})
+	p := expmTest(q.Copy().Mul(2.0))
+	l, err = p.Logm()
+	assert.Nil(t, err, "should not fail")
+	assert.InDeltaSlice(t, q.Copy().Mul(2.0).vals, l.vals, 1e-12, "should be equal")
+	assert.InDeltaSlice(t, p.vals, expmTest(l).vals, 1e-12, "should be equal")
+
+	_, err = Matf64FromData([][]float64{{1, 2}, {2, 4}}).Logm()
+	assert.EqualError(t, err, "In Logm(): the mat is singular")
+	_, err = Diagf64([]float64{-1, 2}).Logm()
+	assert.EqualError(t, err, "In Logm(): the square root does not converge, the mat may have negative real eigenvalues")
+}
@@ -0,0 +1,44 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+IsNaN returns a new mat of the same shape as m, holding 1.0 where the
+element of m is NaN and 0.0 everywhere else. The mask can be summed to count
+the bad cells, or multiplied with other mats to select them. The original mat
+is left intact.
+*/
+func (m *Matf64) IsNaN() *Matf64 {
+	return m.mask(func(v float64) bool { return math.IsNaN(v) })
+}
+
+/*
+IsInf returns a new mat of the same shape as m, holding 1.0 where the
+element of m is +Inf or -Inf and 0.0 everywhere else (see IsNaN).
+*/
+func (m *Matf64) IsInf() *Matf64 {
+	return m.mask(func(v float64) bool { return math.IsInf(v, 0) })
+}
+
+/*
+IsFinite returns a new mat of the same shape as m, holding 1.0 where the
+element of m is neither NaN nor infinite and 0.0 everywhere else (see
+IsNaN). A mat is free of bad cells if its mask sums to its number of
+elements.
+*/
+func (m *Matf64) IsFinite() *Matf64 {
+	return m.mask(func(v float64) bool { return !math.IsNaN(v) && !math.IsInf(v, 0) })
+}
+
+// mask returns a mat holding 1.0 where f is true and 0.0 elsewhere.
+func (m *Matf64) mask(f func(float64) bool) *Matf64 {
+	n := Newf64(m.r, m.c)
+	for i, v := range m.vals {
+		if f(v) {
+			n.vals[i] = 1.0
+		}
+	}
+	return n
+}
@@ -0,0 +1,23 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasksf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, math.NaN(), 3},
+		{math.Inf(1), 0, math.Inf(-1)},
+	})
+	want := Matf64FromData([][]float64{{0, 1, 0}, {0, 0, 0}})
+	assert.True(t, want.Equals(m.IsNaN()), "should be equal")
+	want = Matf64FromData([][]float64{{0, 0, 0}, {1, 0, 1}})
+	assert.True(t, want.Equals(m.IsInf()), "should be equal")
+	want = Matf64FromData([][]float64{{1, 0, 1}, {0, 1, 0}})
+	assert.True(t, want.Equals(m.IsFinite()), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 1)), "should leave the original intact")
+}
@@ -0,0 +1,405 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+/*
+MatBig is a mat of arbitrary precision floating point numbers, backed by a
+[]*big.Float in row major order. Every element has the precision of the mat,
+in bits of mantissa, and every operation rounds its results to that
+precision. A float64 has 53 bits, so a MatBig with a precision of a few
+hundred bits can solve systems so ill-conditioned that float64 arithmetic
+returns noise, at the cost of being orders of magnitude slower.
+
+As with Matf64, the fields are not accessible, and most methods modify the
+receiver in place and return it, so that calls can be chained.
+*/
+type MatBig struct {
+	r, c int
+	prec uint
+	vals []*big.Float
+}
+
+/*
+NewBig returns a rXc MatBig of zeros with prec bits of precision, which must
+be positive.
+*/
+func NewBig(prec uint, r, c int) *MatBig {
+	if prec == 0 || prec > big.MaxPrec {
+		s := "\nIn %s, the precision must be between 1 and %d, but %d was\n"
+		s += "received.\n"
+		s = fmt.Sprintf(s, "NewBig()", uint(big.MaxPrec), prec)
+		printErr(s)
+	}
+	if r < 0 || c < 0 {
+		s := "\nIn %s, the shape %dX%d is negative.\n"
+		s = fmt.Sprintf(s, "NewBig()", r, c)
+		printErr(s)
+	}
+	m := &MatBig{r: r, c: c, prec: prec, vals: make([]*big.Float, r*c)}
+	for i := range m.vals {
+		m.vals[i] = m.newFloat()
+	}
+	return m
+}
+
+/*
+IdentityBig returns the nXn identity mat with prec bits of precision.
+*/
+func IdentityBig(prec uint, n int) *MatBig {
+	m := NewBig(prec, n, n)
+	for i := 0; i < n; i++ {
+		m.vals[i*n+i].SetInt64(1)
+	}
+	return m
+}
+
+/*
+MatBigFromMatf64 returns a MatBig with prec bits of precision holding the
+values of m. Since every float64 is exactly representable with 53 bits, no
+rounding happens for precisions of at least 53. NaNs cannot be represented
+by a big.Float, and infinities would make later products and sums panic
+with a big.ErrNaN, as in Inf*0 or Inf-Inf, so both are rejected, as in
+MatRatFromMatf64.
+*/
+func MatBigFromMatf64(m *Matf64, prec uint) *MatBig {
+	n := NewBig(prec, m.r, m.c)
+	for i, x := range m.vals {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			s := "\nIn %s, the element at index %d is %v, which cannot be\n"
+			s += "represented.\n"
+			s = fmt.Sprintf(s, "MatBigFromMatf64()", i, x)
+			printErr(s)
+		}
+		n.vals[i].SetFloat64(x)
+	}
+	return n
+}
+
+/*
+MatBigFromStrings returns a MatBig with prec bits of precision holding the
+numbers in rows, which must all have the same length. Each number is parsed
+with big.Float.Parse, so values such as "0.1" or "1e-400" are rounded once
+to the precision of the mat rather than going through a float64.
+*/
+func MatBigFromStrings(prec uint, rows [][]string) (*MatBig, error) {
+	c := 0
+	if len(rows) > 0 {
+		c = len(rows[0])
+	}
+	m := NewBig(prec, len(rows), c)
+	for i, row := range rows {
+		if len(row) != c {
+			return nil, fmt.Errorf("In %s: row %d has %d elements, but row 0 has %d", "MatBigFromStrings()", i, len(row), c)
+		}
+		for j, x := range row {
+			if _, _, err := m.vals[i*c+j].Parse(x, 0); err != nil {
+				return nil, fmt.Errorf("In %s: cannot parse %q at row %d and column %d: %v", "MatBigFromStrings()", x, i, j, err)
+			}
+		}
+	}
+	return m, nil
+}
+
+// newFloat returns a zero with the precision of the mat.
+func (m *MatBig) newFloat() *big.Float {
+	return new(big.Float).SetPrec(m.prec)
+}
+
+/*
+Prec returns the precision of the mat, in bits.
+*/
+func (m *MatBig) Prec() uint {
+	return m.prec
+}
+
+/*
+SetPrec changes the precision of the mat to prec bits, rounding every
+element if the precision is reduced.
+*/
+func (m *MatBig) SetPrec(prec uint) *MatBig {
+	if prec == 0 || prec > big.MaxPrec {
+		s := "\nIn %s, the precision must be between 1 and %d, but %d was\n"
+		s += "received.\n"
+		s = fmt.Sprintf(s, "SetPrec()", uint(big.MaxPrec), prec)
+		printErr(s)
+	}
+	m.prec = prec
+	for _, x := range m.vals {
+		x.SetPrec(prec)
+	}
+	return m
+}
+
+/*
+Shape returns the number of rows and columns of the mat.
+*/
+func (m *MatBig) Shape() (int, int) {
+	return m.r, m.c
+}
+
+/*
+Get returns a copy of the element at row r and column c. Negative indices
+count from the end.
+*/
+func (m *MatBig) Get(r, c int) *big.Float {
+	r = normIndex("Get()", "row", r, m.r)
+	c = normIndex("Get()", "column", c, m.c)
+	return m.newFloat().Set(m.vals[r*m.c+c])
+}
+
+/*
+Set sets the element at row r and column c to x, rounded to the precision
+of the mat. Negative indices count from the end.
+*/
+func (m *MatBig) Set(r, c int, x *big.Float) *MatBig {
+	r = normIndex("Set()", "row", r, m.r)
+	c = normIndex("Set()", "column", c, m.c)
+	m.vals[r*m.c+c].Set(x)
+	return m
+}
+
+/*
+ToMatf64 returns the values of the mat rounded to the nearest float64s.
+Values too large for a float64 become infinities.
+*/
+func (m *MatBig) ToMatf64() *Matf64 {
+	n := Newf64(m.r, m.c)
+	for i, x := range m.vals {
+		n.vals[i], _ = x.Float64()
+	}
+	return n
+}
+
+/*
+Copy returns a deep copy of the mat, with the same precision.
+*/
+func (m *MatBig) Copy() *MatBig {
+	n := &MatBig{r: m.r, c: m.c, prec: m.prec, vals: make([]*big.Float, len(m.vals))}
+	for i, x := range m.vals {
+		n.vals[i] = m.newFloat().Set(x)
+	}
+	return n
+}
+
+/*
+Equals reports whether two mats have the same shape and equal elements,
+regardless of their precisions.
+*/
+func (m *MatBig) Equals(n *MatBig) bool {
+	if m.r != n.r || m.c != n.c {
+		return false
+	}
+	for i, x := range m.vals {
+		if x.Cmp(n.vals[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+T transposes the mat in place, and returns it.
+*/
+func (m *MatBig) T() *MatBig {
+	vals := make([]*big.Float, len(m.vals))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			vals[j*m.r+i] = m.vals[i*m.c+j]
+		}
+	}
+	m.r, m.c, m.vals = m.c, m.r, vals
+	return m
+}
+
+/*
+Add adds to each element of the receiver either a scalar, given as a float64
+or a *big.Float, or the corresponding element of a *MatBig of the same
+shape. The receiver is modified in place.
+*/
+func (m *MatBig) Add(scalarOrMatBig interface{}) *MatBig {
+	m.elementwise("Add()", scalarOrMatBig, (*big.Float).Add)
+	return m
+}
+
+/*
+Sub subtracts from each element of the receiver either a scalar or the
+corresponding element of a *MatBig of the same shape, as in Add.
+*/
+func (m *MatBig) Sub(scalarOrMatBig interface{}) *MatBig {
+	m.elementwise("Sub()", scalarOrMatBig, (*big.Float).Sub)
+	return m
+}
+
+/*
+Mul multiplies each element of the receiver by either a scalar or the
+corresponding element of a *MatBig of the same shape, as in Add. For the
+matrix product, see Dot.
+*/
+func (m *MatBig) Mul(scalarOrMatBig interface{}) *MatBig {
+	m.elementwise("Mul()", scalarOrMatBig, (*big.Float).Mul)
+	return m
+}
+
+/*
+Div divides each element of the receiver by either a scalar or the
+corresponding element of a *MatBig of the same shape, as in Add. Dividing a
+nonzero element by zero gives an infinity, and dividing zero by zero
+panics with a big.ErrNaN, as big.Float does.
+*/
+func (m *MatBig) Div(scalarOrMatBig interface{}) *MatBig {
+	m.elementwise("Div()", scalarOrMatBig, (*big.Float).Quo)
+	return m
+}
+
+// elementwise sets each element x of m to op(x, y), where y is the scalar or
+// the corresponding element of the *MatBig v.
+func (m *MatBig) elementwise(fn string, v interface{}, op func(z, x, y *big.Float) *big.Float) {
+	switch v := v.(type) {
+	case float64:
+		y := m.newFloat().SetFloat64(v)
+		for _, x := range m.vals {
+			op(x, x, y)
+		}
+	case *big.Float:
+		for _, x := range m.vals {
+			op(x, x, v)
+		}
+	case *MatBig:
+		if v.r != m.r || v.c != m.c {
+			s := "\nIn %s, the receiver is %dX%d but the passed mat is %dX%d.\n"
+			s += "They must have the same shape.\n"
+			s = fmt.Sprintf(s, fn, m.r, m.c, v.r, v.c)
+			printHelperErr(s)
+		}
+		for i, x := range m.vals {
+			op(x, x, v.vals[i])
+		}
+	default:
+		s := "\nIn %s, the passed value must be a float64, *big.Float or\n"
+		s += "*MatBig. However, value of type \"%v\" was received.\n"
+		s = fmt.Sprintf(s, fn, reflect.TypeOf(v))
+		printHelperErr(s)
+	}
+}
+
+/*
+Dot returns the matrix product of m and n, a new mat with the precision of
+m. The number of columns of m must equal the number of rows of n. Both mats
+are left intact.
+*/
+func (m *MatBig) Dot(n *MatBig) *MatBig {
+	if m.c != n.r {
+		s := "\nIn %s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", m.c, n.r)
+		printErr(s)
+	}
+	o := NewBig(m.prec, m.r, n.c)
+	p := m.newFloat()
+	for i := 0; i < m.r; i++ {
+		for k := 0; k < m.c; k++ {
+			a := m.vals[i*m.c+k]
+			if a.Sign() == 0 {
+				continue
+			}
+			for j := 0; j < n.c; j++ {
+				p.Mul(a, n.vals[k*n.c+j])
+				o.vals[i*n.c+j].Add(o.vals[i*n.c+j], p)
+			}
+		}
+	}
+	return o
+}
+
+/*
+Solve returns the solution x of m * x = b, where m is square, by Gaussian
+elimination with partial pivoting carried out with the precision of m. b may
+have several columns, each of which is solved for. An error is returned if
+m is singular at that precision. Both mats are left intact.
+*/
+func (m *MatBig) Solve(b *MatBig) (*MatBig, error) {
+	if m.r != m.c || b.r != m.r {
+		s := "\nIn %s, the mat must be square and have as many rows as the\n"
+		s += "right hand side, but they are %dX%d and %dX%d.\n"
+		s = fmt.Sprintf(s, "Solve()", m.r, m.c, b.r, b.c)
+		printErr(s)
+	}
+	n, k := m.r, b.c
+	a := m.Copy()
+	x := b.Copy().SetPrec(m.prec)
+	f, t := m.newFloat(), m.newFloat()
+	for j := 0; j < n; j++ {
+		p := j
+		for i := j + 1; i < n; i++ {
+			if t.Abs(a.vals[i*n+j]).Cmp(f.Abs(a.vals[p*n+j])) > 0 {
+				p = i
+			}
+		}
+		if a.vals[p*n+j].Sign() == 0 {
+			return nil, fmt.Errorf("In %s: the mat is singular", "Solve()")
+		}
+		if p != j {
+			for l := 0; l < n; l++ {
+				a.vals[j*n+l], a.vals[p*n+l] = a.vals[p*n+l], a.vals[j*n+l]
+			}
+			for l := 0; l < k; l++ {
+				x.vals[j*k+l], x.vals[p*k+l] = x.vals[p*k+l], x.vals[j*k+l]
+			}
+		}
+		for i := j + 1; i < n; i++ {
+			if a.vals[i*n+j].Sign() == 0 {
+				continue
+			}
+			f.Quo(a.vals[i*n+j], a.vals[j*n+j])
+			for l := j; l < n; l++ {
+				a.vals[i*n+l].Sub(a.vals[i*n+l], t.Mul(f, a.vals[j*n+l]))
+			}
+			for l := 0; l < k; l++ {
+				x.vals[i*k+l].Sub(x.vals[i*k+l], t.Mul(f, x.vals[j*k+l]))
+			}
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for l := 0; l < k; l++ {
+			s := x.vals[i*k+l]
+			for j := i + 1; j < n; j++ {
+				s.Sub(s, t.Mul(a.vals[i*n+j], x.vals[j*k+l]))
+			}
+			s.Quo(s, a.vals[i*n+i])
+		}
+	}
+	return x, nil
+}
+
+/*
+String returns the elements of the mat as Text(16) does.
+*/
+func (m *MatBig) String() string {
+	return m.Text(16)
+}
+
+/*
+Text returns the elements of the mat with the given number of significant
+digits, one row per line, separated by spaces. Use it rather than String to
+see the extra digits of high precision mats.
+*/
+func (m *MatBig) Text(digits int) string {
+	var b bytes.Buffer
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(m.vals[i*m.c+j].Text('g', digits))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
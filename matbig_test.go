@@ -0,0 +1,54 @@
+package matrix
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatBig(t *testing.T) {
+	t.Helper()
+	m, err := MatBigFromStrings(200, [][]string{{"0.1", "2"}, {"3", "4"}})
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, uint(200), m.Prec(), "should be equal")
+	n := MatBigFromMatf64(Matf64FromData([][]float64{{1, 0}, {0, 1}}), 200)
+	assert.True(t, m.Copy().Dot(n).Equals(m), "should be equal")
+	o := m.Copy().Add(1.0).Sub(n).Mul(2.0)
+	assert.Equal(t, "0.2 6\n8 8\n", o.String(), "should be equal")
+	assert.Equal(t, "0.1 3\n2 4\n", m.Copy().T().String(), "should be equal")
+	assert.Equal(t, 0.1, m.ToMatf64().Get(0, 0), "should be equal")
+	assert.Equal(t, "0.1000000000000000000000000000000000000000", m.Get(0, 0).Text('f', 40), "should be exact")
+	m.Set(-1, -1, big.NewFloat(5))
+	assert.Equal(t, "0.1 2\n3 5\n", m.String(), "should be equal")
+	assert.Equal(t, "0.025 0.5\n0.75 1.25\n", m.Copy().Add(-0.1).Add(0.1).Div(m.Get(1, 1).Mul(m.Get(1, 1), big.NewFloat(0.8))).Text(3), "should be equal")
+
+	_, err = MatBigFromStrings(64, [][]string{{"1", "x"}})
+	assert.EqualError(t, err, `In MatBigFromStrings(): cannot parse "x" at row 0 and column 1: number has no digits`)
+	_, err = MatBigFromStrings(64, [][]string{{"1", "2"}, {"3"}})
+	assert.EqualError(t, err, "In MatBigFromStrings(): row 1 has 1 elements, but row 0 has 2")
+}
+
+func TestSolveBig(t *testing.T) {
+	t.Helper()
+	// The 14X14 Hilbert mat has a condition number of about 1e19, so float64
+	// arithmetic cannot solve it.
+	n := 14
+	h := NewBig(256, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			h.vals[i*n+j].Quo(big.NewFloat(1), big.NewFloat(float64(i+j+1)))
+		}
+	}
+	ones := NewBig(256, n, 1).Add(1.0)
+	x, err := h.Solve(h.Dot(ones))
+	assert.Nil(t, err, "should not fail")
+	tol := big.NewFloat(1e-30)
+	for _, v := range x.vals {
+		d := new(big.Float).Sub(v, big.NewFloat(1))
+		assert.True(t, d.Abs(d).Cmp(tol) < 0, "should be accurate")
+	}
+
+	_, err = MatBigFromMatf64(Matf64FromData([][]float64{{1, 2}, {2, 4}}), 64).Solve(NewBig(64, 2, 1))
+	assert.EqualError(t, err, "In Solve(): the mat is singular")
+}
@@ -0,0 +1,405 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+/*
+Matc64 is a mat of single precision complex numbers, backed by a []complex64
+in row major order, as Matf32 is for float32s. It takes half the memory of
+complex128 values, which matters for large signals and spectra where the
+precision of complex64 is enough.
+
+The fields of this struct are not directly accessible, and they may only
+change by the use of the various methods in this library.
+*/
+type Matc64 struct {
+	r, c int
+	vals []complex64
+}
+
+/*
+Newc64 is the constructor of Matc64, and takes 0 to 2 integers like Newf32:
+no argument gives an empty mat, x gives an xXx mat and x, y gives an xXy
+mat, all filled with zeros.
+*/
+func Newc64(dims ...int) *Matc64 {
+	m := &Matc64{}
+	switch len(dims) {
+	case 0:
+		m.vals = make([]complex64, 0)
+	case 1:
+		m.r, m.c = dims[0], dims[0]
+		m.vals = make([]complex64, dims[0]*dims[0])
+	case 2:
+		m.r, m.c = dims[0], dims[1]
+		m.vals = make([]complex64, dims[0]*dims[1])
+	default:
+		s := "\nIn matrix.%s, expected 0 to 2 arguments, but received %d arguments.\n"
+		s = fmt.Sprintf(s, "Newc64()", len(dims))
+		printErr(s)
+	}
+	return m
+}
+
+/*
+Identityc64 returns the nXn identity matrix, with ones on its main diagonal
+and zeros everywhere else.
+*/
+func Identityc64(n int) *Matc64 {
+	m := Newc64(n)
+	for i := 0; i < n; i++ {
+		m.vals[i*n+i] = 1
+	}
+	return m
+}
+
+/*
+Matc64FromData creates a mat from a []complex64, which gives a row vector,
+or from a [][]complex64, which must not be jagged, as Matf32FromData does.
+*/
+func Matc64FromData(oneOrTwoDSlice interface{}) *Matc64 {
+	switch v := oneOrTwoDSlice.(type) {
+	case []complex64:
+		m := Newc64(1, len(v))
+		copy(m.vals, v)
+		return m
+	case [][]complex64:
+		c := 0
+		if len(v) > 0 {
+			c = len(v[0])
+		}
+		m := Newc64(len(v), c)
+		for i := range v {
+			copy(m.vals[i*c:(i+1)*c], v[i])
+		}
+		return m
+	default:
+		s := "\nIn matrix.%s, expected input data of type []complex64 or\n"
+		s += "[][]complex64, However, data of type \"%v\" was received.\n"
+		s = fmt.Sprintf(s, "Matc64FromData()", reflect.TypeOf(v))
+		printErr(s)
+	}
+	return nil
+}
+
+/*
+Matc64FromParts returns the mat re + i*im. im may be nil, in which case the
+imaginary parts are zero. Otherwise, re and im must have the same shape.
+*/
+func Matc64FromParts(re, im *Matf32) *Matc64 {
+	if im != nil && (im.r != re.r || im.c != re.c) {
+		s := "\nIn matrix.%s, the real parts are %dX%d but the imaginary parts\n"
+		s += "are %dX%d. They must have the same shape.\n"
+		s = fmt.Sprintf(s, "Matc64FromParts()", re.r, re.c, im.r, im.c)
+		printErr(s)
+	}
+	m := Newc64(re.r, re.c)
+	for i, x := range re.vals {
+		if im != nil {
+			m.vals[i] = complex(x, im.vals[i])
+		} else {
+			m.vals[i] = complex(x, 0)
+		}
+	}
+	return m
+}
+
+/*
+MemBytes returns the number of bytes of the slice backing the mat.
+*/
+func (m *Matc64) MemBytes() int {
+	return 8 * cap(m.vals)
+}
+
+/*
+Shape returns the number of rows and columns of a mat object.
+*/
+func (m *Matc64) Shape() (int, int) {
+	return m.r, m.c
+}
+
+/*
+ToSlice1D returns the values contained in a mat object as a 1D slice of
+complex64s.
+*/
+func (m *Matc64) ToSlice1D() []complex64 {
+	s := make([]complex64, len(m.vals))
+	copy(s, m.vals)
+	return s
+}
+
+/*
+Get returns the value stored in the given row and column. Negative indices
+count from the end.
+*/
+func (m *Matc64) Get(r, c int) complex64 {
+	r = normIndex("Get()", "row", r, m.r)
+	c = normIndex("Get()", "column", c, m.c)
+	return m.vals[r*m.c+c]
+}
+
+/*
+Set sets the value of a mat at a given row and column to a given value.
+Negative indices count from the end.
+*/
+func (m *Matc64) Set(r, c int, val complex64) *Matc64 {
+	r = normIndex("Set()", "row", r, m.r)
+	c = normIndex("Set()", "column", c, m.c)
+	m.vals[r*m.c+c] = val
+	return m
+}
+
+/*
+Real returns the real parts of the elements of the mat, as a new Matf32.
+*/
+func (m *Matc64) Real() *Matf32 {
+	n := Newf32(m.r, m.c)
+	for i, x := range m.vals {
+		n.vals[i] = real(x)
+	}
+	return n
+}
+
+/*
+Imag returns the imaginary parts of the elements of the mat, as a new
+Matf32.
+*/
+func (m *Matc64) Imag() *Matf32 {
+	n := Newf32(m.r, m.c)
+	for i, x := range m.vals {
+		n.vals[i] = imag(x)
+	}
+	return n
+}
+
+/*
+Abs returns the moduli of the elements of the mat, as a new Matf32. For a
+spectrum, this is the magnitude of each frequency.
+*/
+func (m *Matc64) Abs() *Matf32 {
+	n := Newf32(m.r, m.c)
+	for i, x := range m.vals {
+		n.vals[i] = float32(math.Hypot(float64(real(x)), float64(imag(x))))
+	}
+	return n
+}
+
+/*
+Conj replaces each element of the mat by its complex conjugate, in place.
+*/
+func (m *Matc64) Conj() *Matc64 {
+	for i, x := range m.vals {
+		m.vals[i] = complex(real(x), -imag(x))
+	}
+	return m
+}
+
+/*
+Equals checks if two mats have the same shape and the same values.
+*/
+func (m *Matc64) Equals(n *Matc64) bool {
+	if m.r != n.r || m.c != n.c {
+		return false
+	}
+	for i, x := range m.vals {
+		if x != n.vals[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+Copy returns a duplicate of a mat object.
+*/
+func (m *Matc64) Copy() *Matc64 {
+	n := Newc64(m.r, m.c)
+	copy(n.vals, m.vals)
+	return n
+}
+
+/*
+T transposes the mat in place, without conjugating its elements. For the
+conjugate transpose, see H.
+*/
+func (m *Matc64) T() *Matc64 {
+	vals := make([]complex64, len(m.vals))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			vals[j*m.r+i] = m.vals[i*m.c+j]
+		}
+	}
+	m.r, m.c, m.vals = m.c, m.r, vals
+	return m
+}
+
+/*
+H replaces the mat by its conjugate transpose, in place.
+*/
+func (m *Matc64) H() *Matc64 {
+	return m.T().Conj()
+}
+
+/*
+Add adds to each element of the receiver either a scalar, given as a
+complex64, complex128, float32 or float64, or the corresponding element of a
+*Matc64 of the same shape. The receiver is modified in place.
+*/
+func (m *Matc64) Add(scalarOrMatc64 interface{}) *Matc64 {
+	m.elementwise("Add()", scalarOrMatc64, func(x, y complex64) complex64 { return x + y })
+	return m
+}
+
+/*
+Sub subtracts from each element of the receiver either a scalar or the
+corresponding element of a *Matc64 of the same shape, as in Add.
+*/
+func (m *Matc64) Sub(scalarOrMatc64 interface{}) *Matc64 {
+	m.elementwise("Sub()", scalarOrMatc64, func(x, y complex64) complex64 { return x - y })
+	return m
+}
+
+/*
+Mul multiplies each element of the receiver by either a scalar or the
+corresponding element of a *Matc64 of the same shape, as in Add. For the
+matrix product, see Dot.
+*/
+func (m *Matc64) Mul(scalarOrMatc64 interface{}) *Matc64 {
+	m.elementwise("Mul()", scalarOrMatc64, func(x, y complex64) complex64 { return x * y })
+	return m
+}
+
+/*
+Div divides each element of the receiver by either a scalar or the
+corresponding element of a *Matc64 of the same shape, as in Add.
+*/
+func (m *Matc64) Div(scalarOrMatc64 interface{}) *Matc64 {
+	m.elementwise("Div()", scalarOrMatc64, func(x, y complex64) complex64 { return x / y })
+	return m
+}
+
+// elementwise sets each element x of m to op(x, y), where y is the scalar or
+// the corresponding element of the *Matc64 v.
+func (m *Matc64) elementwise(fn string, v interface{}, op func(x, y complex64) complex64) {
+	var y complex64
+	switch v := v.(type) {
+	case complex64:
+		y = v
+	case complex128:
+		y = complex64(v)
+	case float32:
+		y = complex(v, 0)
+	case float64:
+		y = complex(float32(v), 0)
+	case *Matc64:
+		if v.r != m.r || v.c != m.c {
+			s := "\nIn %s, the receiver is %dX%d but the passed mat is %dX%d.\n"
+			s += "They must have the same shape.\n"
+			s = fmt.Sprintf(s, fn, m.r, m.c, v.r, v.c)
+			printHelperErr(s)
+		}
+		for i, x := range m.vals {
+			m.vals[i] = op(x, v.vals[i])
+		}
+		return
+	default:
+		s := "\nIn %s, the passed value must be a complex or float scalar, or a *Matc64.\n"
+		s += "However, value of type \"%v\" was received.\n"
+		s = fmt.Sprintf(s, fn, reflect.TypeOf(v))
+		printHelperErr(s)
+	}
+	for i, x := range m.vals {
+		m.vals[i] = op(x, y)
+	}
+}
+
+/*
+Dot returns the matrix product of m and n, as a new mat. The number of
+columns of m must equal the number of rows of n. The sums are accumulated in
+complex128, so that long products do not lose more precision than the
+rounding of the result to complex64.
+*/
+func (m *Matc64) Dot(n *Matc64) *Matc64 {
+	if m.c != n.r {
+		s := "\nIn %s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", m.c, n.r)
+		printErr(s)
+	}
+	o := Newc64(m.r, n.c)
+	sums := make([]complex128, n.c)
+	for i := 0; i < m.r; i++ {
+		for j := range sums {
+			sums[j] = 0
+		}
+		for k := 0; k < m.c; k++ {
+			a := complex128(m.vals[i*m.c+k])
+			for j, b := range n.vals[k*n.c : (k+1)*n.c] {
+				sums[j] += a * complex128(b)
+			}
+		}
+		for j, x := range sums {
+			o.vals[i*n.c+j] = complex64(x)
+		}
+	}
+	return o
+}
+
+/*
+FFT2D returns the 2D discrete Fourier transform of the mat, as a new mat.
+It uses the same transform as Matf64's FFT2D, computed in double precision
+and rounded back to complex64. The mat is left intact.
+*/
+func (m *Matc64) FFT2D() *Matc64 {
+	return m.fft2D(false)
+}
+
+/*
+IFFT2D returns the inverse 2D discrete Fourier transform of the mat, scaled
+by 1/(rows*cols), so that m.FFT2D().IFFT2D() gives back m up to rounding.
+The mat is left intact.
+*/
+func (m *Matc64) IFFT2D() *Matc64 {
+	return m.fft2D(true)
+}
+
+// fft2D transforms a widened copy of m with the shared complex128 kernel.
+func (m *Matc64) fft2D(inverse bool) *Matc64 {
+	x := make([]complex128, len(m.vals))
+	for i, v := range m.vals {
+		x[i] = complex128(v)
+	}
+	fft2D(x, m.r, m.c, inverse)
+	scale := complex(1, 0)
+	if inverse && len(x) > 0 {
+		scale = complex(1/float64(len(x)), 0)
+	}
+	o := Newc64(m.r, m.c)
+	for i, v := range x {
+		o.vals[i] = complex64(v * scale)
+	}
+	return o
+}
+
+/*
+String returns the elements of the mat, one row per line, separated by
+spaces.
+*/
+func (m *Matc64) String() string {
+	var b bytes.Buffer
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprint(&b, m.vals[i*m.c+j])
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
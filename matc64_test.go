@@ -0,0 +1,54 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatc64(t *testing.T) {
+	t.Helper()
+	m := Matc64FromData([][]complex64{{1 + 2i, 3}, {-1i, 2 - 1i}})
+	assert.Equal(t, "(1+2i) (3+0i)\n(0-1i) (2-1i)\n", m.String(), "should be equal")
+	assert.Equal(t, complex64(2-1i), m.Get(-1, -1), "should be equal")
+	assert.Equal(t, 32, m.MemBytes(), "should be equal")
+	assert.True(t, m.Equals(Matc64FromParts(m.Real(), m.Imag())), "should be equal")
+	assert.Equal(t, []float32{3, 0}, Matc64FromParts(Matf32FromData([]float32{3, 0}), nil).Abs().ToSlice1D(), "should be equal")
+
+	h := m.Copy().H()
+	assert.Equal(t, Matc64FromData([][]complex64{{1 - 2i, 1i}, {3, 2 + 1i}}), h, "should be equal")
+	assert.Equal(t, complex64(3), m.Copy().T().Get(1, 0), "should not be conjugated")
+
+	o := m.Copy().Add(1.0).Sub(complex128(1i)).Mul(m).Div(2.0)
+	assert.Equal(t, complex64((2+1i)*(1+2i)/2), o.Get(0, 0), "should be equal")
+	o = m.Copy().Add(float32(1)).Sub(complex64(1i)).Mul(m).Div(float32(2))
+	assert.Equal(t, complex64((2+1i)*(1+2i)/2), o.Get(0, 0), "should accept single precision scalars")
+	var x complex64 = 5 - 1i
+	assert.Equal(t, x, m.Copy().Set(0, 1, x).Get(0, 1), "should be equal")
+
+	d := m.Dot(Identityc64(2))
+	assert.True(t, d.Equals(m), "should be equal")
+	// m * mᴴ is Hermitian, with a real diagonal.
+	g := m.Dot(m.Copy().H())
+	assert.Equal(t, complex64(14), g.Get(0, 0), "should be equal")
+	assert.Equal(t, g.Get(0, 1), complex64(complex(real(g.Get(1, 0)), -imag(g.Get(1, 0)))), "should be equal")
+
+	// A NaN in the argument reaches the result even against a zero, as in
+	// Matf64.Dot.
+	nan := complex64(complex(float32(math.NaN()), 0))
+	p := Matc64FromData([]complex64{0}).Dot(Matc64FromData([]complex64{nan}))
+	assert.True(t, math.IsNaN(float64(real(p.Get(0, 0)))), "should be NaN")
+}
+
+func TestFFT2DMatc64(t *testing.T) {
+	t.Helper()
+	m := Matc64FromData([][]complex64{{1, 2, 3}, {4 + 1i, 5, 6}})
+	f := m.FFT2D()
+	assert.Equal(t, complex64(21+1i), f.Get(0, 0), "should be the sum")
+	back := f.IFFT2D()
+	for i, x := range back.vals {
+		assert.InDelta(t, real(m.vals[i]), real(x), 1e-5, "should be equal")
+		assert.InDelta(t, imag(m.vals[i]), imag(x), 1e-5, "should be equal")
+	}
+}
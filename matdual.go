@@ -0,0 +1,268 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+/*
+MatDual is a mat of dual numbers, for forward mode automatic
+differentiation. Each element carries a value and the derivative of that
+value with respect to some scalar parameter t, stored as two Matf64s of the
+same shape. The arithmetic methods apply the rules of differentiation along
+with the operation, so that evaluating an expression on MatDuals gives both
+its value and its exact derivative, without deriving it by hand.
+
+For example, the derivative of sum(x . x) with respect to x[0][1] is
+obtained by seeding x with a derivative of 1 at that element:
+
+	x := matrix.DualSeed(m, 0, 1)
+	_, d := x.Copy().Mul(x).Sum()
+
+Gradient does this for every element. Like Matf64, the arithmetic methods
+modify the receiver in place and return it.
+*/
+type MatDual struct {
+	v, d *Matf64
+}
+
+/*
+NewDual returns a MatDual with the values of val and the derivatives of der,
+which must have the same shape as val. If der is nil, the derivatives are
+zero, which makes the mat a constant. Both mats are copied.
+*/
+func NewDual(val, der *Matf64) *MatDual {
+	if der == nil {
+		return &MatDual{v: val.Copy(), d: Newf64(val.r, val.c)}
+	}
+	if der.r != val.r || der.c != val.c {
+		s := "\nIn %s, the values are %dX%d but the derivatives are %dX%d.\n"
+		s += "They must have the same shape.\n"
+		s = fmt.Sprintf(s, "NewDual()", val.r, val.c, der.r, der.c)
+		printErr(s)
+	}
+	return &MatDual{v: val.Copy(), d: der.Copy()}
+}
+
+/*
+DualSeed returns a MatDual with the values of val, and derivatives that are
+1 at row r and column c and 0 elsewhere, that is the derivatives of val with
+respect to its element at r and c. Negative indices count from the end.
+*/
+func DualSeed(val *Matf64, r, c int) *MatDual {
+	m := NewDual(val, nil)
+	m.d.Set(r, c, 1)
+	return m
+}
+
+/*
+Gradient returns the gradient of f at x, that is the mat of the derivatives
+of the sum of the elements of f(x) with respect to each element of x. f is
+evaluated once per element of x, on a MatDual seeded by DualSeed, so this
+suits functions of few variables. f may modify its argument.
+*/
+func Gradient(f func(x *MatDual) *MatDual, x *Matf64) *Matf64 {
+	g := Newf64(x.r, x.c)
+	for i := 0; i < x.r; i++ {
+		for j := 0; j < x.c; j++ {
+			_, d := f(DualSeed(x, i, j)).Sum()
+			g.vals[i*x.c+j] = d
+		}
+	}
+	return g
+}
+
+/*
+Value returns a copy of the values of the mat.
+*/
+func (m *MatDual) Value() *Matf64 {
+	return m.v.Copy()
+}
+
+/*
+Deriv returns a copy of the derivatives of the mat.
+*/
+func (m *MatDual) Deriv() *Matf64 {
+	return m.d.Copy()
+}
+
+/*
+Shape returns the number of rows and columns of the mat.
+*/
+func (m *MatDual) Shape() (int, int) {
+	return m.v.r, m.v.c
+}
+
+/*
+Copy returns a deep copy of the mat.
+*/
+func (m *MatDual) Copy() *MatDual {
+	return &MatDual{v: m.v.Copy(), d: m.d.Copy()}
+}
+
+/*
+T transposes the mat in place, and returns it.
+*/
+func (m *MatDual) T() *MatDual {
+	m.v.T()
+	m.d.T()
+	return m
+}
+
+/*
+Sum returns the sum of the values of the mat, and its derivative.
+*/
+func (m *MatDual) Sum() (float64, float64) {
+	return m.v.Sum(), m.d.Sum()
+}
+
+/*
+Add adds to the receiver a constant, given as a float64 or a *Matf64, or a
+*MatDual of the same shape. The receiver is modified in place.
+*/
+func (m *MatDual) Add(constOrMatDual interface{}) *MatDual {
+	switch v := constOrMatDual.(type) {
+	case float64, *Matf64:
+		m.v.Add(v)
+	case *MatDual:
+		m.checkShape("Add()", v)
+		m.v.Add(v.v)
+		m.d.Add(v.d)
+	default:
+		m.badType("Add()", v)
+	}
+	return m
+}
+
+/*
+Sub subtracts from the receiver a constant or a *MatDual, as in Add.
+*/
+func (m *MatDual) Sub(constOrMatDual interface{}) *MatDual {
+	switch v := constOrMatDual.(type) {
+	case float64, *Matf64:
+		m.v.Sub(v)
+	case *MatDual:
+		m.checkShape("Sub()", v)
+		m.v.Sub(v.v)
+		m.d.Sub(v.d)
+	default:
+		m.badType("Sub()", v)
+	}
+	return m
+}
+
+/*
+Mul multiplies the receiver elementwise by a constant or a *MatDual, as in
+Add, with the product rule (uv)' = u'v + uv'. For the matrix product, see
+Dot.
+*/
+func (m *MatDual) Mul(constOrMatDual interface{}) *MatDual {
+	switch v := constOrMatDual.(type) {
+	case float64, *Matf64:
+		m.v.Mul(v)
+		m.d.Mul(v)
+	case *MatDual:
+		m.checkShape("Mul()", v)
+		vd := v.d.Copy()
+		m.d.Mul(v.v).Add(vd.Mul(m.v))
+		m.v.Mul(v.v)
+	default:
+		m.badType("Mul()", v)
+	}
+	return m
+}
+
+/*
+Div divides the receiver elementwise by a constant or a *MatDual, as in Add,
+with the quotient rule (u/v)' = (u' - (u/v)v')/v.
+*/
+func (m *MatDual) Div(constOrMatDual interface{}) *MatDual {
+	switch v := constOrMatDual.(type) {
+	case float64, *Matf64:
+		m.v.Div(v)
+		m.d.Div(v)
+	case *MatDual:
+		m.checkShape("Div()", v)
+		m.v.Div(v.v)
+		m.d.Sub(v.d.Copy().Mul(m.v)).Div(v.v)
+	default:
+		m.badType("Div()", v)
+	}
+	return m
+}
+
+/*
+Dot returns the matrix product of m and n as a new MatDual, with the product
+rule (mn)' = m'n + mn'. Both mats are left intact.
+*/
+func (m *MatDual) Dot(n *MatDual) *MatDual {
+	v := m.v.Dot(n.v)
+	d := m.d.Dot(n.v).Add(m.v.Dot(n.d))
+	return &MatDual{v: v, d: d}
+}
+
+/*
+Map applies the differentiable function f, whose derivative is df, to each
+element of the mat in place, with the chain rule f(u)' = df(u)u'. For
+example, the logistic function is applied with:
+
+	m.Map(func(x float64) float64 {
+		return 1 / (1 + math.Exp(-x))
+	}, func(x float64) float64 {
+		s := 1 / (1 + math.Exp(-x))
+		return s * (1 - s)
+	})
+*/
+func (m *MatDual) Map(f, df func(x float64) float64) *MatDual {
+	m.v.materialize()
+	m.d.materialize()
+	for i, x := range m.v.vals {
+		m.d.vals[i] *= df(x)
+		m.v.vals[i] = f(x)
+	}
+	return m
+}
+
+/*
+Exp replaces each element of the mat by its exponential, in place.
+*/
+func (m *MatDual) Exp() *MatDual {
+	return m.Map(math.Exp, math.Exp)
+}
+
+/*
+Log replaces each element of the mat by its natural logarithm, in place.
+*/
+func (m *MatDual) Log() *MatDual {
+	return m.Map(math.Log, func(x float64) float64 { return 1 / x })
+}
+
+/*
+Pow raises each element of the mat to the constant power p, in place.
+*/
+func (m *MatDual) Pow(p float64) *MatDual {
+	return m.Map(func(x float64) float64 {
+		return math.Pow(x, p)
+	}, func(x float64) float64 {
+		return p * math.Pow(x, p-1)
+	})
+}
+
+// checkShape checks that n has the same shape as m.
+func (m *MatDual) checkShape(fn string, n *MatDual) {
+	if n.v.r != m.v.r || n.v.c != m.v.c {
+		s := "\nIn %s, the receiver is %dX%d but the passed mat is %dX%d.\n"
+		s += "They must have the same shape.\n"
+		s = fmt.Sprintf(s, fn, m.v.r, m.v.c, n.v.r, n.v.c)
+		printHelperErr(s)
+	}
+}
+
+// badType reports a value of the wrong type passed to an arithmetic method.
+func (m *MatDual) badType(fn string, v interface{}) {
+	s := "\nIn %s, the passed value must be a float64, *Matf64 or *MatDual.\n"
+	s += "However, value of type \"%v\" was received.\n"
+	s = fmt.Sprintf(s, fn, reflect.TypeOf(v))
+	printHelperErr(s)
+}
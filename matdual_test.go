@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatDual(t *testing.T) {
+	t.Helper()
+	x := DualSeed(Matf64FromData([][]float64{{1, 2}, {3, 4}}), 0, 1)
+	v, d := x.Copy().Mul(x).Sum()
+	assert.Equal(t, 30.0, v, "should be equal")
+	assert.Equal(t, 4.0, d, "should be equal")
+
+	y := x.Copy().Add(1.0).Div(x).Sub(Onesf64(2, 2)).Mul(2.0)
+	// 2*((x+1)/x - 1) = 2/x, whose derivative is -2/x².
+	assert.InDelta(t, 1.0, y.Value().Get(0, 1), 1e-15, "should be equal")
+	assert.InDelta(t, -0.5, y.Deriv().Get(0, 1), 1e-15, "should be equal")
+	assert.Equal(t, 0.0, y.Deriv().Get(1, 0), "should be equal")
+
+	c := NewDual(Onesf64(2, 3), nil)
+	assert.True(t, c.Deriv().Equals(Zerosf64(2, 3)), "should be constant")
+	r, cols := c.T().Shape()
+	assert.Equal(t, []int{3, 2}, []int{r, cols}, "should be equal")
+}
+
+func TestGradient(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1, -2}, {0.5, 3}, {2, 1}})
+	x := Matf64FromData([][]float64{{0.3, -1}, {2, 0.7}})
+	f := func(x *MatDual) *MatDual {
+		// sum(log(1 + exp(a x x)))
+		y := NewDual(a, nil).Dot(x).Dot(x)
+		return y.Exp().Add(1.0).Log()
+	}
+	g := Gradient(f, x)
+
+	h := 1e-6
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			xp, xm := x.Copy(), x.Copy()
+			xp.Set(i, j, x.Get(i, j)+h)
+			xm.Set(i, j, x.Get(i, j)-h)
+			fp, _ := f(NewDual(xp, nil)).Sum()
+			fm, _ := f(NewDual(xm, nil)).Sum()
+			assert.InDelta(t, (fp-fm)/(2*h), g.Get(i, j), 1e-6, "should be equal")
+		}
+	}
+
+	p := DualSeed(Matf64FromData([]float64{4}), 0, 0).Pow(1.5)
+	assert.Equal(t, 8.0, p.Value().Get(0, 0), "should be equal")
+	assert.Equal(t, 3.0, p.Deriv().Get(0, 0), "should be equal")
+	e := DualSeed(Matf64FromData([]float64{0}), 0, 0).Map(math.Sin, math.Cos)
+	assert.Equal(t, 1.0, e.Deriv().Get(0, 0), "should be equal")
+}
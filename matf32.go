@@ -72,12 +72,33 @@ func Newf32(dims ...int) *Matf32 {
 }
 
 /*
-Eyef32 returns the identity matrix
+Identityf32 returns the nXn identity matrix, with ones on its main diagonal
+and zeros everywhere else.
 */
-func Eyef32(x int) *Matf32 {
-	m := Newf32(x)
-	for i := 1; i < x; i++ {
-		m.vals[i*i-1] = float32(1.0)
+func Identityf32(n int) *Matf32 {
+	return Eyef32(n)
+}
+
+/*
+Eyef32 returns an nXn mat with ones on its kth diagonal and zeros everywhere
+else. It behaves exactly like Eyef64.
+*/
+func Eyef32(n int, k ...int) *Matf32 {
+	off := 0
+	switch len(k) {
+	case 0:
+	case 1:
+		off = k[0]
+	default:
+		s := "\nIn matrix.%s, expected 1 or 2 arguments, but received %d arguments."
+		s = fmt.Sprintf(s, "Eyef32()", len(k)+1)
+		printErr(s)
+	}
+	m := Newf32(n)
+	for i := 0; i < n; i++ {
+		if j := i + off; j >= 0 && j < n {
+			m.vals[i*n+j] = 1.0
+		}
 	}
 	return m
 }
@@ -138,17 +159,111 @@ func matf32FromTwoDSliceHelper(v [][]float32) *Matf32 {
 }
 
 /*
-RandMatf32 returns a Matf32 whose elements have random values. For example:
+RandMatf32 returns a Matf32 whose elements have random values. There are 3 ways
+to call RandMatf32:
 
 	m := matrix.RandMatf32(2, 3)
 
-m is a 2X3 Matf32 whose elements have values randomly selected from the range
-(0, 1], (includes 0, but excludes 1).
+With this call, m is a 2X3 Matf32 whose elements have values randomly selected
+from the range (0, 1], (includes 0, but excludes 1).
+
+	m := matrix.RandMatf32(2, 3, x)
+
+With this call, m is a 2X3 Matf32 whose elements have values randomly selected
+from the range (0, x], (includes 0, but excludes x).
+
+	m := matrix.RandMatf32(2, 3, x, y)
+
+With this call, m is a 2X3 Matf32 whose elements have values randomly selected
+from the range (x, y], (includes x, but excludes y). In this case, x must be
+strictly less than y.
+*/
+func RandMatf32(r, c int, args ...float32) *Matf32 {
+	return RandMatf32With(nil, r, c, args...)
+}
+
+/*
+RandMatf32With is like RandMatf32, but draws its values from rng, or from the
+package source if rng is nil (see SetRandSource).
+*/
+func RandMatf32With(rng *rand.Rand, r, c int, args ...float32) *Matf32 {
+	rng = randOrDefault(rng)
+	m := Newf32(r, c)
+	switch len(args) {
+	case 0:
+		for i := range m.vals {
+			m.vals[i] = rng.Float32()
+		}
+	case 1:
+		to := args[0]
+		for i := range m.vals {
+			m.vals[i] = rng.Float32() * to
+		}
+	case 2:
+		from := args[0]
+		to := args[1]
+		if !(from < to) {
+			s := "\nIn matrix.%s the first argument, %f, is not less than the\n"
+			s += "second argument, %f. The first argument must be strictly\n"
+			s += "less than the second.\n"
+			s = fmt.Sprintf(s, "RandMatf32()", from, to)
+			printErr(s)
+		}
+		for i := range m.vals {
+			m.vals[i] = rng.Float32()*(to-from) + from
+		}
+	default:
+		s := "\nIn matrix.%s expected 0 to 2 arguments, but received %d."
+		s = fmt.Sprintf(s, "RandMatf32()", len(args))
+		printErr(s)
+	}
+	return m
+}
+
+/*
+RandnMatf32 returns a mat whose elements are drawn from a normal
+distribution. It can be called in the following ways:
+
+	m := matrix.RandnMatf32(2, 3)
+
+With this call, m is a 2X3 Matf32 whose elements are drawn from the
+standard normal distribution, with a mean of 0 and a standard deviation of 1.
+
+	m := matrix.RandnMatf32(2, 3, mean, std)
+
+With this call, m is a 2X3 Matf32 whose elements are drawn from a normal
+distribution with the given mean and standard deviation. std must not be
+negative.
+*/
+func RandnMatf32(r, c int, args ...float32) *Matf32 {
+	return RandnMatf32With(nil, r, c, args...)
+}
+
+/*
+RandnMatf32With is like RandnMatf32, but draws its values from rng, or from
+the package source if rng is nil (see SetRandSource).
 */
-func RandMatf32(r, c int) *Matf32 {
+func RandnMatf32With(rng *rand.Rand, r, c int, args ...float32) *Matf32 {
+	rng = randOrDefault(rng)
+	var mean, std float32 = 0.0, 1.0
+	switch len(args) {
+	case 0:
+	case 2:
+		mean, std = args[0], args[1]
+		if std < 0 {
+			s := "\nIn matrix.%s, the standard deviation must not be negative,\n"
+			s += "but received %f.\n"
+			s = fmt.Sprintf(s, "RandnMatf32()", std)
+			printErr(s)
+		}
+	default:
+		s := "\nIn matrix.%s expected 0 or 2 arguments, but received %d."
+		s = fmt.Sprintf(s, "RandnMatf32()", len(args))
+		printErr(s)
+	}
 	m := Newf32(r, c)
 	for i := range m.vals {
-		m.vals[i] = rand.Float32()
+		m.vals[i] = float32(rng.NormFloat64())*std + mean
 	}
 	return m
 }
@@ -166,6 +281,15 @@ func (m *Matf32) Reshape(rows, cols int) *Matf32 {
 	return m
 }
 
+/*
+MemBytes returns the number of bytes of the slice backing the mat, including
+the spare capacity kept to append rows without reallocating, which is
+usually as large as the values themselves.
+*/
+func (m *Matf32) MemBytes() int {
+	return 4 * cap(m.vals)
+}
+
 /*
 Shape returns the number of rows and columns of a mat object.
 */
@@ -225,6 +349,8 @@ func (m *Matf32) ToSlice2Df64() [][]float64 {
 Get returns a pointer to the float32 stored in the given row and column.
 */
 func (m *Matf32) Get(r, c int) float32 {
+	r = normIndex("Get()", "row", r, m.r)
+	c = normIndex("Get()", "column", c, m.c)
 	return m.vals[r*m.c+c]
 }
 
@@ -233,6 +359,8 @@ Set sets the value of a mat at a given row and column to a given
 value.
 */
 func (m *Matf32) Set(r, c int, val float64) *Matf32 {
+	r = normIndex("Set()", "row", r, m.r)
+	c = normIndex("Set()", "column", c, m.c)
 	m.vals[r*m.c+c] = float32(val)
 	return m
 }
@@ -282,33 +410,19 @@ in this case, the length of the passed slice must match exactly the number of
 elements in m's column, i.e. the number of rows of m.
 */
 func (m *Matf32) SetCol(col int, floatOrSlice interface{}) *Matf32 {
+	col = normIndex("SetCol()", "column", col, m.c)
 	switch val := floatOrSlice.(type) {
 	case float64:
-		if (col >= m.c) || (col < -m.c) {
-			printErr(fmt.Sprintf(colOutOfBound, "SetCol()", col, m.c, m.c))
-		}
 		val32 := float32(val)
-		if col >= 0 {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+col] = val32
-			}
-		} else {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+(m.c+col)] = val32
-			}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val32
 		}
 	case []float32:
 		if len(val) != m.r {
 			printErr(fmt.Sprintf(sizeMismatch, "SetCol()", len(val), 1, m.r, m.c))
 		}
-		if col >= 0 {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+col] = val[r]
-			}
-		} else {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+(m.c+col)] = val[r]
-			}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val[r]
 		}
 	default:
 		printErr(fmt.Sprintf(wrongArgType, "SetCol()", "float32 or []float32", val))
@@ -333,34 +447,18 @@ in this case, the length of the passed slice must match exactly the number of
 elements in m's row, i.e. the number of cols of m.
 */
 func (m *Matf32) SetRow(row int, floatOrSlice interface{}) *Matf32 {
+	row = normIndex("SetRow()", "row", row, m.r)
 	switch val := floatOrSlice.(type) {
 	case float64:
-		if (row >= m.r) || (row < -m.r) {
-			printErr(fmt.Sprintf(rowOutOfBound, "SetRow()", row, m.r, m.r))
-		}
 		val32 := float32(val)
-		if row >= 0 {
-			for r := 0; r < m.c; r++ {
-				m.vals[row*m.c+r] = val32
-			}
-		} else {
-			for r := 0; r < m.c; r++ {
-				m.vals[(m.r+row)*m.c+r] = val32
-			}
+		for r := 0; r < m.c; r++ {
+			m.vals[row*m.c+r] = val32
 		}
 	case []float32:
 		if len(val) != m.c {
 			printErr(fmt.Sprintf(sizeMismatch, "SetRow()", 1, len(val), m.r, m.c))
 		}
-		if row >= 0 {
-			for r := 0; r < m.c; r++ {
-				m.vals[row*m.c+r] = val[r]
-			}
-		} else {
-			for r := 0; r < m.c; r++ {
-				m.vals[(m.r+row)*m.c+r] = val[r]
-			}
-		}
+		copy(m.vals[row*m.c:(row+1)*m.c], val)
 	default:
 		printErr(fmt.Sprintf(wrongArgType, "SetRow()", "float32 or []float32", val))
 	}
@@ -379,18 +477,10 @@ This function supports negative indexing. For example,
 returns the last column of m.
 */
 func (m *Matf32) Col(x int) *Matf32 {
-	if (x >= m.c) || (x < -m.c) {
-		printErr(fmt.Sprintf(colOutOfBound, "Col()", x, m.c, m.c))
-	}
+	x = normIndex("Col()", "column", x, m.c)
 	v := Newf32(m.r, 1)
-	if x >= 0 {
-		for r := 0; r < m.r; r++ {
-			v.vals[r] = m.vals[r*m.c+x]
-		}
-	} else {
-		for r := 0; r < m.r; r++ {
-			v.vals[r] = m.vals[r*m.c+(m.c+x)]
-		}
+	for r := 0; r < m.r; r++ {
+		v.vals[r] = m.vals[r*m.c+x]
 	}
 	return v
 }
@@ -407,19 +497,9 @@ This function supports negative indexing. For example,
 returns the last row of m.
 */
 func (m *Matf32) Row(x int) *Matf32 {
-	if (x >= m.r) || (x < -m.r) {
-		printErr(fmt.Sprintf(rowOutOfBound, "Row()", x, m.r, m.r))
-	}
+	x = normIndex("Row()", "row", x, m.r)
 	v := Newf32(1, m.c)
-	if x >= 0 {
-		for r := 0; r < m.c; r++ {
-			v.vals[r] = m.vals[x*m.c+r]
-		}
-	} else {
-		for r := 0; r < m.c; r++ {
-			v.vals[r] = m.vals[(m.r+x)*m.c+r]
-		}
-	}
+	copy(v.vals, m.vals[x*m.c:(x+1)*m.c])
 	return v
 }
 
@@ -436,11 +516,10 @@ values:
 	idx, val := m.Min(0, 3) // Get the min index and value of the 4th row
 	idx, val := m.Min(1, 2) // Get the min index and value of the 3rd column
 
-Note that negative index values are not supported at this time. Also note that
-in the case where multiple values are the maximum, the index of the first
-encountered value is returned.
+Negative indices count from the end, as with Row and Col, so m.Min(1, -1)
+looks at the last column. Also note that in the case where multiple values
+are the maximum, the index of the first encountered value is returned.
 */
-// TODO: support negative index, and fix errors.
 func (m *Matf32) Min(args ...int) (index int, minVal float32) {
 	switch len(args) {
 	case 0:
@@ -455,11 +534,7 @@ func (m *Matf32) Min(args ...int) (index int, minVal float32) {
 		axis, slice := args[0], args[1]
 		switch axis {
 		case 0:
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Min()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Min()", "row", slice, m.r)
 			minVal = m.vals[slice*m.c]
 			for i := 1; i < m.c; i++ {
 				if m.vals[slice*m.c+i] < minVal {
@@ -468,11 +543,7 @@ func (m *Matf32) Min(args ...int) (index int, minVal float32) {
 				}
 			}
 		case 1:
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Min()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Min()", "column", slice, m.c)
 			minVal = m.vals[slice]
 			for i := 1; i < m.r; i++ {
 				if m.vals[i*m.c+slice] < minVal {
@@ -507,11 +578,10 @@ values:
 	idx, val := m.Max(0, 3) // Get the max index and value of the 4th row
 	idx, val := m.Max(1, 2) // Get the max index and value of the 3rd column
 
-Note that negative index values are not supported at this time. Also note that
-in the case where multiple values are the maximum, the index of the first
-encountered value is returned.
+Negative indices count from the end, as with Row and Col, so m.Max(1, -1)
+looks at the last column. Also note that in the case where multiple values
+are the maximum, the index of the first encountered value is returned.
 */
-// TODO: support negative index, and fix errors.
 func (m *Matf32) Max(args ...int) (index int, maxVal float32) {
 	switch len(args) {
 	case 0:
@@ -526,11 +596,7 @@ func (m *Matf32) Max(args ...int) (index int, maxVal float32) {
 		axis, slice := args[0], args[1]
 		switch axis {
 		case 0:
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Max()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Max()", "row", slice, m.r)
 			maxVal = m.vals[slice*m.c]
 			for i := 1; i < m.c; i++ {
 				if m.vals[slice*m.c+i] > maxVal {
@@ -539,11 +605,7 @@ func (m *Matf32) Max(args ...int) (index int, maxVal float32) {
 				}
 			}
 		case 1:
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Max()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Max()", "column", slice, m.c)
 			maxVal = m.vals[slice]
 			for i := 1; i < m.r; i++ {
 				if m.vals[i*m.c+slice] > maxVal {
@@ -903,8 +965,8 @@ row or column. For example:
 	m.Sum(0, 2) // Returns the sum of the 3rd row
 	m.Sum(1, 0) // Returns the sum of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Sum(0, -1) uses the last row.
 */
 func (m *Matf32) Sum(args ...int) float32 {
 	var sum float32
@@ -917,20 +979,12 @@ func (m *Matf32) Sum(args ...int) float32 {
 		axis, slice := args[0], args[1]
 		switch axis {
 		case 0:
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Sum()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Sum()", "row", slice, m.r)
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
 			}
 		case 1:
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Sum()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Sum()", "column", slice, m.c)
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
 			}
@@ -960,8 +1014,8 @@ specifying the row or column. For example:
 	m.Avg(0, 2) // Returns the average of the 3rd row
 	m.Avg(1, 0) // Returns the average of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Avg(0, -1) uses the last row.
 */
 func (m *Matf32) Avg(args ...int) float32 {
 	var sum float32
@@ -974,21 +1028,13 @@ func (m *Matf32) Avg(args ...int) float32 {
 	case 2:
 		axis, slice := args[0], args[1]
 		if axis == 0 {
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Avg()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Avg()", "row", slice, m.r)
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
 			}
 			sum /= float32(m.c)
 		} else if axis == 1 {
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Avg()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Avg()", "column", slice, m.c)
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
 			}
@@ -1020,8 +1066,8 @@ specifying the row or column. For example:
 	m.Prd(0, 2) // Returns the product of the 3rd row
 	m.Prd(1, 0) // Returns the product of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Prd(0, -1) uses the last row.
 */
 func (m *Matf32) Prd(args ...int) float32 {
 	prd := float32(1.0)
@@ -1033,20 +1079,12 @@ func (m *Matf32) Prd(args ...int) float32 {
 	case 2:
 		axis, slice := args[0], args[1]
 		if axis == 0 {
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Prd()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Prd()", "row", slice, m.r)
 			for i := 0; i < m.c; i++ {
 				prd *= m.vals[slice*m.c+i]
 			}
 		} else if axis == 1 {
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Prd()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Prd()", "column", slice, m.c)
 			for i := 0; i < m.r; i++ {
 				prd *= m.vals[i*m.c+slice]
 			}
@@ -1077,8 +1115,8 @@ specifying the row or column. For example:
 	m.Std(0, 2) // Returns the standard deviation of the 3rd row
 	m.Std(1, 0) // Returns the standard deviation of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Std(0, -1) uses the last row.
 */
 func (m *Matf32) Std(args ...int) float32 {
 	var std float32
@@ -1093,27 +1131,19 @@ func (m *Matf32) Std(args ...int) float32 {
 	case 2:
 		axis, slice := args[0], args[1]
 		if axis == 0 {
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Std()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Std()", "row", slice, m.r)
 			avg := m.Avg(axis, slice)
 			for i := 0; i < m.c; i++ {
 				sum += ((avg - m.vals[slice*m.c+i]) * (avg - m.vals[slice*m.c+i]))
 			}
-			std = float32(math.Sqrt(float64(sum) / float64(len(m.vals))))
+			std = float32(math.Sqrt(float64(sum) / float64(m.c)))
 		} else if axis == 1 {
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Std()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Std()", "column", slice, m.c)
 			avg := m.Avg(axis, slice)
 			for i := 0; i < m.r; i++ {
 				sum += ((avg - m.vals[i*m.c+slice]) * (avg - m.vals[i*m.c+slice]))
 			}
-			std = float32(math.Sqrt(float64(sum) / float64(len(m.vals))))
+			std = float32(math.Sqrt(float64(sum) / float64(m.r)))
 		} else {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
@@ -1142,6 +1172,9 @@ then
 is a 5 by 10 mat whose element at row i and column j is given by:
 
 	Sum(m.Row(i).Mul(n.col(j))
+
+Both mats are left intact, so m and n may be the same mat. To multiply by a
+transpose without transposing either mat, see TDot and DotT.
 */
 func (m *Matf32) Dot(n *Matf32) *Matf32 {
 	if m.c != n.r {
@@ -1151,16 +1184,61 @@ func (m *Matf32) Dot(n *Matf32) *Matf32 {
 		s = fmt.Sprintf(s, "Dot()", m.c, n.r)
 		printErr(s)
 	}
-
 	o := Newf32(m.r, n.c)
+	for i := 0; i < m.r; i++ {
+		orow := o.vals[i*n.c : (i+1)*n.c]
+		for k, a := range m.vals[i*m.c : (i+1)*m.c] {
+			for j, b := range n.vals[k*n.c : (k+1)*n.c] {
+				orow[j] += a * b
+			}
+		}
+	}
+	return o
+}
+
+/*
+TDot returns the product of the transpose of m with n, that is m.T().Dot(n),
+without transposing m. It behaves exactly like the TDot of Matf64.
+*/
+func (m *Matf32) TDot(n *Matf32) *Matf32 {
+	if m.r != n.r {
+		s := "\nIn %s the number of rows of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "TDot()", m.r, n.r)
+		printErr(s)
+	}
+	o := Newf32(m.c, n.c)
+	for k := 0; k < m.r; k++ {
+		nrow := n.vals[k*n.c : (k+1)*n.c]
+		for i, a := range m.vals[k*m.c : (k+1)*m.c] {
+			orow := o.vals[i*n.c : (i+1)*n.c]
+			for j, b := range nrow {
+				orow[j] += a * b
+			}
+		}
+	}
+	return o
+}
 
-	n.T()
-	defer n.T()
+/*
+DotT returns the product of m with the transpose of n, that is
+m.Dot(n.T()), without transposing n. It behaves exactly like the DotT of
+Matf64.
+*/
+func (m *Matf32) DotT(n *Matf32) *Matf32 {
+	if m.c != n.c {
+		s := "\nIn %s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of columns of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "DotT()", m.c, n.c)
+		printErr(s)
+	}
+	o := Newf32(m.r, n.r)
 	for i := 0; i < m.r; i++ {
-		imc := i * m.c
+		mrow := m.vals[i*m.c : (i+1)*m.c]
 		for j := 0; j < n.r; j++ {
-			jnc := j * n.c
-			o.vals[i*n.r+j] = dotf32Helper(m.vals[imc:imc+m.c], n.vals[jnc:jnc+n.c])
+			o.vals[i*n.r+j] = dotf32Helper(mrow, n.vals[j*n.c:(j+1)*n.c])
 		}
 	}
 	return o
@@ -1186,22 +1264,35 @@ func (m *Matf32) AppendCol(v []float32) *Matf32 {
 		s = fmt.Sprintf(s, "AppendCol()", m.r, len(v))
 		printErr(s)
 	}
-	// TODO: redo this by hand, instead of taking this shortcut... or check if
-	// this is a huge bottleneck
-	q := m.ToSlice2D()
-	for i := range q {
-		q[i] = append(q[i], v[i])
-	}
-	m.c++
-	m.vals = append(m.vals, v...)
+	m.widen(1)
 	for i := 0; i < m.r; i++ {
-		for j := 0; j < m.c; j++ {
-			m.vals[i*m.c+j] = q[i][j]
-		}
+		m.vals[i*m.c+m.c-1] = v[i]
 	}
 	return m
 }
 
+// widen adds k columns to the right side of m. The existing values are
+// shifted in place, starting from the last row so that no row is overwritten
+// before it is moved, and the new columns are left with stale values which
+// the caller is expected to fill.
+func (m *Matf32) widen(k int) {
+	n := m.r * (m.c + k)
+	if cap(m.vals) < n {
+		newVals := make([]float32, n, 2*n)
+		for i := 0; i < m.r; i++ {
+			copy(newVals[i*(m.c+k):], m.vals[i*m.c:(i+1)*m.c])
+		}
+		m.vals = newVals
+		m.c += k
+		return
+	}
+	m.vals = m.vals[:n]
+	for i := m.r - 1; i > 0; i-- {
+		copy(m.vals[i*(m.c+k):i*(m.c+k)+m.c], m.vals[i*m.c:(i+1)*m.c])
+	}
+	m.c += k
+}
+
 /*
 AppendRow appends a row to the bottom of a Matf32.
 */
@@ -1239,7 +1330,8 @@ For example:
 	m.Concat(n)
 	fmt.Println(m) // [[2.0, 2.0, 3.0, 3.0, 3.0]]
 
-Note that in the current implementation this is a somewhat expensive function.
+The receiver is widened in place, and a new underlying slice is only
+allocated when its capacity is exceeded.
 */
 func (m *Matf32) Concat(n *Matf32) *Matf32 {
 	if m.r != n.r {
@@ -1248,18 +1340,13 @@ func (m *Matf32) Concat(n *Matf32) *Matf32 {
 		s = fmt.Sprintf(s, "Concat()", m.r, n.r)
 		printErr(s)
 	}
-	q := m.ToSlice2D()
-	t := n.ToSlice1D()
-	r := n.ToSlice2D()
-	m.vals = append(m.vals, t...)
-	for i := range q {
-		q[i] = append(q[i], r[i]...)
+	if n == m {
+		n = m.Copy()
 	}
-	m.c += n.c
+	oldC := m.c
+	m.widen(n.c)
 	for i := 0; i < m.r; i++ {
-		for j := 0; j < m.c; j++ {
-			m.vals[i*m.c+j] = q[i][j]
-		}
+		copy(m.vals[i*m.c+oldC:(i+1)*m.c], n.vals[i*n.c:(i+1)*n.c])
 	}
 	return m
 }
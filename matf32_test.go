@@ -1,6 +1,7 @@
 package matrix
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -31,6 +32,15 @@ func TestNewf32(t *testing.T) {
 	// assert.Panics(t, func() { Newf32(1, 2, 3, 4) }, "should panic with 3+ args")
 }
 
+func TestEyef32(t *testing.T) {
+	t.Helper()
+	want := Matf32FromData([][]float32{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+	assert.True(t, want.Equals(Eyef32(3)), "should be the identity")
+	assert.True(t, want.Equals(Identityf32(3)), "should be the identity")
+	want = Matf32FromData([][]float32{{0, 0, 0}, {1, 0, 0}, {0, 1, 0}})
+	assert.True(t, want.Equals(Eyef32(3, -1)), "should be equal")
+}
+
 func TestMatf32FromData(t *testing.T) {
 	t.Helper()
 	rows := 50
@@ -89,6 +99,28 @@ func TestRandf32(t *testing.T) {
 			t.Errorf("at index %d, expected [0, 1.0), got %f", i, m.vals[i])
 		}
 	}
+	m = RandMatf32(rows, cols, 100.0)
+	for i := 0; i < rows*cols; i++ {
+		if m.vals[i] < 0.0 || m.vals[i] >= 100.0 {
+			t.Errorf("at index %d, expected [0, 100.0), got %f", i, m.vals[i])
+		}
+	}
+	m = RandMatf32(rows, cols, -12.0, 2.0)
+	for i := 0; i < rows*cols; i++ {
+		if m.vals[i] < -12.0 || m.vals[i] >= 2.0 {
+			t.Errorf("at index %d, expected [-12.0, 2.0), got %f", i, m.vals[i])
+		}
+	}
+}
+
+func TestRandnf32(t *testing.T) {
+	t.Helper()
+	m := RandnMatf32(200, 100, 5.0, 0.5)
+	var sum float64
+	for _, v := range m.vals {
+		sum += float64(v)
+	}
+	assert.InDelta(t, 5.0, sum/float64(len(m.vals)), 0.02, "mean should be close to 5")
 }
 
 func TestReshapef32(t *testing.T) {
@@ -682,6 +714,10 @@ func TestStdf32(t *testing.T) {
 	for i := 0; i < col; i++ {
 		assert.Equal(t, float32(0.0), m.Std(1, i), "should be equal")
 	}
+	m = Matf32FromData([][]float32{{2, 4, 4, 4}, {5, 5, 7, 9}})
+	assert.Equal(t, float32(2.0), m.Std(), "should be equal")
+	assert.Equal(t, float32(math.Sqrt(0.75)), m.Std(0, 0), "should divide by the row length")
+	assert.Equal(t, float32(1.5), m.Std(1, 0), "should divide by the column length")
 }
 
 func TestDotf32(t *testing.T) {
@@ -727,6 +763,19 @@ func TestDotf32(t *testing.T) {
 	assert.True(t, x.Equals(z), "A times I should equal A")
 }
 
+func TestTDotDotTf32(t *testing.T) {
+	t.Helper()
+	a := Matf32FromData([][]float32{{1, 2, 3}, {4, 5, 6}})
+	c := Matf32FromData([][]float32{{2, 0, 1}, {1, 1, 1}, {0, 3, -1}, {1, 0, 0}})
+	assert.True(t, a.Copy().T().Dot(a).Equals(a.TDot(a)), "should be equal")
+	assert.True(t, a.Dot(c.Copy().T()).Equals(a.DotT(c)), "should be equal")
+	assert.Equal(t, [][]float32{{1, 2, 3}, {4, 5, 6}}, a.ToSlice2D(), "should be left intact")
+
+	// Dot does not transpose its argument, so a square mat can be squared.
+	s := Matf32FromData([][]float32{{1, 2}, {3, 4}})
+	assert.Equal(t, [][]float32{{7, 10}, {15, 22}}, s.Dot(s).ToSlice2D(), "should be equal")
+}
+
 func BenchmarkDotf32(b *testing.B) {
 	m := Newf32(10)
 	n := Newf32(10)
@@ -782,6 +831,29 @@ func TestAppendColf32(t *testing.T) {
 	assert.Equal(t, col+2, m.c, "should have two more columns")
 	m.AppendCol(v)
 	assert.Equal(t, col+3, m.c, "should have three more columns")
+	for i := 0; i < row; i++ {
+		for j := 0; j < col; j++ {
+			assert.Equal(t, float32(i*col+j), m.Get(i, j), "should be equal")
+		}
+		for j := col; j < col+3; j++ {
+			assert.Equal(t, float32(0.0), m.Get(i, j), "should be zero")
+		}
+	}
+}
+
+func TestAppendColGrowthf32(t *testing.T) {
+	t.Helper()
+	m := Newf32(10, 1)
+	v := make([]float32, 10)
+	reallocs := 0
+	for i := 0; i < 100; i++ {
+		c := cap(m.vals)
+		m.AppendCol(v)
+		if cap(m.vals) != c {
+			reallocs++
+		}
+	}
+	assert.True(t, reallocs < 10, "should grow the capacity geometrically")
 }
 
 func TestAppendRowf32(t *testing.T) {
@@ -837,4 +909,10 @@ func TestConcatf32(t *testing.T) {
 			idx2++
 		}
 	}
+	m = Newf32(2, 3).SetAll(1.0)
+	m.Concat(m)
+	assert.Equal(t, 6, m.c, "should have twice the columns")
+	for i := range m.vals {
+		assert.Equal(t, float32(1.0), m.vals[i], "should be equal")
+	}
 }
@@ -6,9 +6,10 @@ import (
 	"io"
 	"math"
 	"math/rand"
-	"os"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync/atomic"
 
 	"github.com/gorgonia/vecf64"
 )
@@ -24,8 +25,11 @@ The fields of this struct are not directly accessible, and they may only
 change by the use of the various methods in this library.
 */
 type Matf64 struct {
-	r, c int
-	vals []float64
+	r, c  int
+	vals  []float64
+	cow   *cowRef
+	names *matNames
+	meta  map[string]string
 }
 
 /*
@@ -53,21 +57,21 @@ func Newf64(dims ...int) *Matf64 {
 	switch len(dims) {
 	case 0:
 		m = &Matf64{
-			0,
-			0,
-			make([]float64, 0),
+			r:    0,
+			c:    0,
+			vals: make([]float64, 0),
 		}
 	case 1:
 		m = &Matf64{
-			dims[0],
-			dims[0],
-			make([]float64, dims[0]*dims[0], 2*dims[0]*dims[0]),
+			r:    dims[0],
+			c:    dims[0],
+			vals: make([]float64, dims[0]*dims[0], 2*dims[0]*dims[0]),
 		}
 	case 2:
 		m = &Matf64{
-			dims[0],
-			dims[1],
-			make([]float64, dims[0]*dims[1], 2*dims[0]*dims[1]),
+			r:    dims[0],
+			c:    dims[1],
+			vals: make([]float64, dims[0]*dims[1], 2*dims[0]*dims[1]),
 		}
 	default:
 		s := "\nIn matrix.%s, expected 0 to 2 arguments, but received %d arguments."
@@ -78,16 +82,162 @@ func Newf64(dims ...int) *Matf64 {
 }
 
 /*
-Eyef64 returns the identity matrix
+Identityf64 returns the nXn identity matrix, with ones on its main diagonal
+and zeros everywhere else.
 */
-func Eyef64(x int) *Matf64 {
-	m := Newf64(x)
-	for i := 1; i < x; i++ {
-		m.vals[i*i-1] = 1.0
+func Identityf64(n int) *Matf64 {
+	return Eyef64(n)
+}
+
+/*
+Eyef64 returns an nXn mat with ones on one of its diagonals and zeros
+everywhere else, just like numpy.eye. It can be called in two ways:
+
+	m := matrix.Eyef64(n)
+
+m is the nXn identity matrix, just like Identityf64(n).
+
+	m := matrix.Eyef64(n, k)
+
+m has ones on its kth diagonal, where the main diagonal is k = 0, diagonals
+above it have k > 0, and diagonals below it have k < 0. If k is outside of
+the range (-n, n), m is all zeros.
+*/
+func Eyef64(n int, k ...int) *Matf64 {
+	off := 0
+	switch len(k) {
+	case 0:
+	case 1:
+		off = k[0]
+	default:
+		s := "\nIn matrix.%s, expected 1 or 2 arguments, but received %d arguments."
+		s = fmt.Sprintf(s, "Eyef64()", len(k)+1)
+		printErr(s)
+	}
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		if j := i + off; j >= 0 && j < n {
+			m.vals[i*n+j] = 1.0
+		}
+	}
+	return m
+}
+
+/*
+Zerosf64 returns an rXc mat filled with zeros. It is equivalent to
+Newf64(r, c), but states the intent of the caller more clearly.
+*/
+func Zerosf64(r, c int) *Matf64 {
+	return Newf64(r, c)
+}
+
+/*
+Onesf64 returns an rXc mat filled with ones.
+*/
+func Onesf64(r, c int) *Matf64 {
+	return Fullf64(r, c, 1.0)
+}
+
+/*
+Fullf64 returns an rXc mat with every element set to v.
+*/
+func Fullf64(r, c int, v float64) *Matf64 {
+	m := Newf64(r, c)
+	for i := range m.vals {
+		m.vals[i] = v
+	}
+	return m
+}
+
+/*
+ZerosLikef64 returns a mat filled with zeros, with the same shape as m.
+*/
+func ZerosLikef64(m *Matf64) *Matf64 {
+	return Newf64(m.r, m.c)
+}
+
+/*
+OnesLikef64 returns a mat filled with ones, with the same shape as m.
+*/
+func OnesLikef64(m *Matf64) *Matf64 {
+	return Fullf64(m.r, m.c, 1.0)
+}
+
+/*
+Diagf64 returns a square mat with the values of v on its main diagonal, and
+zeros everywhere else. The number of rows and columns is the length of v.
+*/
+func Diagf64(v []float64) *Matf64 {
+	n := len(v)
+	m := Newf64(n)
+	for i := 0; i < n; i++ {
+		m.vals[i*n+i] = v[i]
+	}
+	return m
+}
+
+/*
+PermutationMatf64 returns the permutation matrix of perm, which must hold
+each of the integers from 0 to len(perm)-1 exactly once. Multiplying a mat by
+it on the left reorders the rows of that mat, so that row i of
+
+	matrix.PermutationMatf64(perm).Dot(m)
+
+is row perm[i] of m. Similarly, multiplying by its transpose on the right
+reorders the columns.
+*/
+func PermutationMatf64(perm []int) *Matf64 {
+	checkPerm("PermutationMatf64()", perm, len(perm))
+	n := len(perm)
+	m := Newf64(n)
+	for i, p := range perm {
+		m.vals[i*n+p] = 1.0
 	}
 	return m
 }
 
+/*
+RandPermMatf64 returns a random nXn permutation matrix (see
+PermutationMatf64), drawn uniformly among all the permutations of n
+elements.
+*/
+func RandPermMatf64(n int) *Matf64 {
+	return RandPermMatf64With(nil, n)
+}
+
+/*
+RandPermMatf64With is like RandPermMatf64, but draws its permutation from
+rng, or from the package source if rng is nil (see SetRandSource).
+*/
+func RandPermMatf64With(rng *rand.Rand, n int) *Matf64 {
+	rng = randOrDefault(rng)
+	if n < 0 {
+		s := "\nIn matrix.%s, the size must be non-negative, but received %d.\n"
+		s = fmt.Sprintf(s, "RandPermMatf64()", n)
+		printErr(s)
+	}
+	return PermutationMatf64(rng.Perm(n))
+}
+
+// checkPerm makes sure that perm is a permutation of the integers from 0 to
+// n-1.
+func checkPerm(fn string, perm []int, n int) {
+	if len(perm) != n {
+		s := "\nIn %s, the permutation must have %d elements, but it has %d.\n"
+		s = fmt.Sprintf(s, fn, n, len(perm))
+		printHelperErr(s)
+	}
+	seen := make([]bool, n)
+	for _, p := range perm {
+		if p < 0 || p >= n || seen[p] {
+			s := "\nIn %s, %v is not a permutation of the integers from 0 to %d.\n"
+			s = fmt.Sprintf(s, fn, perm, n-1)
+			printHelperErr(s)
+		}
+		seen[p] = true
+	}
+}
+
 /*
 Matf64FromData creates a mat object from a []float64 or a [][]float64 slice.
 This function is designed to do the "right thing" based on the type of
@@ -119,7 +269,7 @@ In this case, x.Dims() is (a, b), and the values in x are the same as
 the values in v. Note that a*b must be equal to len(v). Also note that
 this is equivalent to:
 
-    x := matrix.Matf64FromData(v).reshape(a,b)
+	x := matrix.Matf64FromData(v).reshape(a,b)
 
 This function can also be invoked with data that is stored in a 2D
 slice ([][]float64). Just as the []float64 case, there are three
@@ -261,6 +411,23 @@ func matf64FromTwoDSliceHelper(v [][]float64, dims []int) *Matf64 {
 	return m
 }
 
+/*
+Matf64FromRawData creates an r by c mat backed by vals, which holds the
+values in row major order. Unlike Matf64FromData nothing is copied, so the
+mat and vals alias each other until an operation such as AppendRow needs to
+reallocate the values. The length of vals must be exactly r*c.
+*/
+func Matf64FromRawData(vals []float64, r, c int) *Matf64 {
+	if r < 0 || c < 0 || r*c != len(vals) {
+		s := "\nIn matrix.%s, a %dX%d mat cannot be backed by %d values.\n"
+		s = fmt.Sprintf(s, "Matf64FromRawData()", r, c, len(vals))
+		printErr(s)
+	}
+	m := Newf64()
+	m.r, m.c, m.vals = r, c, vals
+	return m
+}
+
 /*
 Matf64FromCSV creates a mat object from a CSV (comma separated values) file. Here, we
 assume that the number of rows of the resultant mat object is equal to the
@@ -268,6 +435,34 @@ number of lines, and the number of columns is equal to the number of entries
 in each line. As before, we make sure that each line contains the same number
 of elements.
 
+When called with only a file name, every column is loaded:
+
+	m := matrix.Matf64FromCSV("data.csv")
+
+If none of the entries of the first line is a number, the line is a header,
+such as the one written by ToCSV for a mat with named columns, and it is not
+part of the mat.
+
+To skip columns that do not hold numbers (IDs, labels, free text...), the
+columns to load can be chosen explicitly, either by their index (negative
+indices are allowed), or by their name, in which case the first line of the
+file is treated as a header, and is not part of the mat:
+
+	m := matrix.Matf64FromCSV("data.csv", 0, 2, -1)
+	m := matrix.Matf64FromCSV("data.csv", "height", "weight")
+
+When the columns are chosen by index only, the first line is a header if none
+of its entries in those columns is a number.
+
+When a header is read, the names of the loaded columns become the column
+names of the mat, see SetColNames.
+
+In all cases, entries of the loaded columns that cannot be converted to a
+float64 (for instance empty cells or "NA") are set to NaN.
+
+If the name of the file ends with ".gz", it is decompressed with gzip while
+it is being read.
+
 The file to be read is assumed to be very large, and hence it is read one line
 at a time. This results in some major inefficiencies, and it is recommended
 that this function be used sparingly, and not as a major component of your
@@ -277,14 +472,28 @@ Unlike other mat creation functions in this package, the capacity of the mat
 object created here is the same as its length since we assume the mat to
 be very large.
 */
-func Matf64FromCSV(filename string) *Matf64 {
-	f, err := os.Open(filename)
+func Matf64FromCSV(filename string, intsOrStrings ...interface{}) *Matf64 {
+	f, err := openFile(filename)
 	if err != nil {
 		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
 		s = fmt.Sprintf(s, "Matf64FromCSV()", filename, err)
 		printErr(s)
 	}
 	defer f.Close()
+	return matf64FromCSVReader(f, filename, "Matf64FromCSV()", intsOrStrings)
+}
+
+/*
+Matf64FromCSVReader is the same as Matf64FromCSV, but reads the CSV data from
+r instead of a file. The columns to load can be chosen in the same way.
+*/
+func Matf64FromCSVReader(r io.Reader, intsOrStrings ...interface{}) *Matf64 {
+	return matf64FromCSVReader(r, "the reader", "Matf64FromCSVReader()", intsOrStrings)
+}
+
+// matf64FromCSVReader reads CSV data from f, where name describes the source
+// of the data, and fn is the name of the calling function, for error messages.
+func matf64FromCSVReader(f io.Reader, name, fn string, intsOrStrings []interface{}) *Matf64 {
 	r := csv.NewReader(f)
 	// I am going with the assumption that a mat loaded from a CSV is going to
 	// be large. So, we are going to read one line, and determine the number
@@ -294,39 +503,119 @@ func Matf64FromCSV(filename string) *Matf64 {
 	str, err := r.Read()
 	if err != nil {
 		s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
-		s = fmt.Sprintf(s, "Matf64FromCSV()", filename, err)
-		printErr(s)
+		s = fmt.Sprintf(s, fn, name, err)
+		printHelperErr(s)
+	}
+	cols, header := csvColumns(str, intsOrStrings)
+	first := str
+	if header {
+		str, err = r.Read()
 	}
-	// Start with one row, and set the number of entries per row
 	m := Newf64()
-	m.r, m.c = 1, len(str)
-	row := make([]float64, len(str))
-	for {
-		for i := range str {
-			row[i], err = strconv.ParseFloat(str[i], 64)
-			if err != nil {
-				s := "\nIn matrix.%s, item %d in line %d is %s, which cannot\n"
-				s += "be converted to a float64 due to: %v"
-				s = fmt.Sprintf(s, "Matf64FromCSV()", i, m.r, str[i], err)
-				printErr(s)
-			}
+	m.c = len(cols)
+	if header {
+		names := make([]string, len(cols))
+		for i, col := range cols {
+			names[i] = strings.TrimSpace(first[col])
 		}
-		m.vals = append(m.vals, row...)
-		// Read the next line. If there is one, increment the number of rows
-		str, err = r.Read()
+		m.names = &matNames{cols: names}
+	}
+	row := make([]float64, len(cols))
+	report := progressFunc()
+	for {
 		if err != nil {
 			if err == io.EOF {
+				if report != nil {
+					report(fn, m.r, -1)
+				}
 				break
 			}
 			s := "\nIn matrix.%s, cannot read from %s due to error: %v.\n"
-			s = fmt.Sprintf(s, "Matf64FromCSV()", filename, err)
-			printErr(s)
+			s = fmt.Sprintf(s, fn, name, err)
+			printHelperErr(s)
 		}
+		for i, col := range cols {
+			row[i], err = strconv.ParseFloat(strings.TrimSpace(str[col]), 64)
+			if err != nil {
+				row[i] = math.NaN()
+			}
+		}
+		m.vals = append(m.vals, row...)
 		m.r++
+		if report != nil && m.r%4096 == 0 {
+			report(fn, m.r, -1)
+		}
+		// Read the next line, if there is one.
+		str, err = r.Read()
 	}
 	return m
 }
 
+// csvColumns returns the indices of the columns to load from a CSV file
+// whose first line is line, based on the ints or strings that were passed
+// to Matf64FromCSV, and whether the first line is a header. Without ints or
+// strings, all the columns are loaded, and a line with no numbers is a
+// header.
+func csvColumns(line []string, intsOrStrings []interface{}) ([]int, bool) {
+	var cols []int
+	if len(intsOrStrings) == 0 {
+		cols = make([]int, len(line))
+		header := true
+		for i := range line {
+			cols[i] = i
+			if csvIsNumber(line[i]) {
+				header = false
+			}
+		}
+		return cols, header
+	}
+	header, byName := false, false
+	for _, v := range intsOrStrings {
+		switch col := v.(type) {
+		case int:
+			cols = append(cols, normIndex("Matf64FromCSV()", "column", col, len(line)))
+		case string:
+			byName = true
+			found := false
+			for i := range line {
+				if strings.TrimSpace(line[i]) == col {
+					cols = append(cols, i)
+					found = true
+					break
+				}
+			}
+			if !found {
+				s := "\nIn matrix.%s, there is no column named \"%s\" in the header.\n"
+				s = fmt.Sprintf(s, "Matf64FromCSV()", col)
+				printHelperErr(s)
+			}
+		default:
+			s := "\nIn matrix.%s, the columns must be given as ints or strings.\n"
+			s += "However, value of type \"%v\" was received.\n"
+			s = fmt.Sprintf(s, "Matf64FromCSV()", reflect.TypeOf(v))
+			printHelperErr(s)
+		}
+	}
+	if byName {
+		return cols, true
+	}
+	// Columns chosen by index only start with a header if none of them
+	// holds a number on the first line.
+	header = len(cols) > 0
+	for _, col := range cols {
+		if csvIsNumber(line[col]) {
+			header = false
+		}
+	}
+	return cols, header
+}
+
+// csvIsNumber reports whether the CSV entry x is a number.
+func csvIsNumber(x string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+	return err == nil
+}
+
 /*
 RandMatf64 returns a Matf64 whose elements have random values. There are 3 ways to call
 RandMatf64:
@@ -348,16 +637,25 @@ the range (x, y], (includes x, but excludes y). In this case, x must be strictly
 less than y.
 */
 func RandMatf64(r, c int, args ...float64) *Matf64 {
+	return RandMatf64With(nil, r, c, args...)
+}
+
+/*
+RandMatf64With is like RandMatf64, but draws its values from rng, or from the
+package source if rng is nil (see SetRandSource).
+*/
+func RandMatf64With(rng *rand.Rand, r, c int, args ...float64) *Matf64 {
+	rng = randOrDefault(rng)
 	m := Newf64(r, c)
 	switch len(args) {
 	case 0:
 		for i := 0; i < m.r*m.c; i++ {
-			m.vals[i] = rand.Float64()
+			m.vals[i] = rng.Float64()
 		}
 	case 1:
 		to := args[0]
 		for i := 0; i < m.r*m.c; i++ {
-			m.vals[i] = rand.Float64() * to
+			m.vals[i] = rng.Float64() * to
 		}
 	case 2:
 		from := args[0]
@@ -370,7 +668,7 @@ func RandMatf64(r, c int, args ...float64) *Matf64 {
 			printErr(s)
 		}
 		for i := 0; i < m.r*m.c; i++ {
-			m.vals[i] = rand.Float64()*(to-from) + from
+			m.vals[i] = rng.Float64()*(to-from) + from
 		}
 	default:
 		s := "\nIn matrix.%s expected 0 to 2 arguments, but received %d."
@@ -380,6 +678,289 @@ func RandMatf64(r, c int, args ...float64) *Matf64 {
 	return m
 }
 
+/*
+RandnMatf64 returns a mat whose elements are drawn from a normal
+distribution. It can be called in the following ways:
+
+	m := matrix.RandnMatf64(2, 3)
+
+With this call, m is a 2X3 Matf64 whose elements are drawn from the
+standard normal distribution, with a mean of 0 and a standard deviation of 1.
+
+	m := matrix.RandnMatf64(2, 3, mean, std)
+
+With this call, m is a 2X3 Matf64 whose elements are drawn from a normal
+distribution with the given mean and standard deviation. std must not be
+negative.
+*/
+func RandnMatf64(r, c int, args ...float64) *Matf64 {
+	return RandnMatf64With(nil, r, c, args...)
+}
+
+/*
+RandnMatf64With is like RandnMatf64, but draws its values from rng, or from
+the package source if rng is nil (see SetRandSource).
+*/
+func RandnMatf64With(rng *rand.Rand, r, c int, args ...float64) *Matf64 {
+	rng = randOrDefault(rng)
+	mean, std := 0.0, 1.0
+	switch len(args) {
+	case 0:
+	case 2:
+		mean, std = args[0], args[1]
+		if std < 0 {
+			s := "\nIn matrix.%s, the standard deviation must not be negative,\n"
+			s += "but received %f.\n"
+			s = fmt.Sprintf(s, "RandnMatf64()", std)
+			printErr(s)
+		}
+	default:
+		s := "\nIn matrix.%s expected 0 or 2 arguments, but received %d."
+		s = fmt.Sprintf(s, "RandnMatf64()", len(args))
+		printErr(s)
+	}
+	m := Newf64(r, c)
+	for i := range m.vals {
+		m.vals[i] = rng.NormFloat64()*std + mean
+	}
+	return m
+}
+
+/*
+RandExpMatf64 returns an rXc mat whose elements are drawn from an
+exponential distribution with the given rate (the inverse of its mean).
+rate must be strictly positive.
+*/
+func RandExpMatf64(r, c int, rate float64) *Matf64 {
+	return RandExpMatf64With(nil, r, c, rate)
+}
+
+/*
+RandExpMatf64With is like RandExpMatf64, but draws its values from rng, or
+from the package source if rng is nil (see SetRandSource).
+*/
+func RandExpMatf64With(rng *rand.Rand, r, c int, rate float64) *Matf64 {
+	rng = randOrDefault(rng)
+	if !(rate > 0) {
+		s := "\nIn matrix.%s, the rate must be strictly positive, but received %f.\n"
+		s = fmt.Sprintf(s, "RandExpMatf64()", rate)
+		printErr(s)
+	}
+	m := Newf64(r, c)
+	for i := range m.vals {
+		m.vals[i] = rng.ExpFloat64() / rate
+	}
+	return m
+}
+
+/*
+RandPoissonMatf64 returns an rXc mat whose elements are counts drawn from a
+Poisson distribution with mean lambda. lambda must not be negative.
+*/
+func RandPoissonMatf64(r, c int, lambda float64) *Matf64 {
+	return RandPoissonMatf64With(nil, r, c, lambda)
+}
+
+/*
+RandPoissonMatf64With is like RandPoissonMatf64, but draws its values from
+rng, or from the package source if rng is nil (see SetRandSource).
+*/
+func RandPoissonMatf64With(rng *rand.Rand, r, c int, lambda float64) *Matf64 {
+	rng = randOrDefault(rng)
+	if !(lambda >= 0) || math.IsInf(lambda, 0) {
+		s := "\nIn matrix.%s, lambda must be finite and non-negative, but\n"
+		s += "received %f.\n"
+		s = fmt.Sprintf(s, "RandPoissonMatf64()", lambda)
+		printErr(s)
+	}
+	m := Newf64(r, c)
+	for i := range m.vals {
+		m.vals[i] = randPoisson(rng, lambda)
+	}
+	return m
+}
+
+// randPoisson draws from a Poisson distribution by counting uniform draws
+// for small means, and with the PTRS transformed rejection method of
+// Hörmann for larger means, whose cost does not grow with lambda.
+func randPoisson(rng *rand.Rand, lambda float64) float64 {
+	if lambda < 10 {
+		limit, p, k := math.Exp(-lambda), rng.Float64(), 0.0
+		for p > limit {
+			p *= rng.Float64()
+			k++
+		}
+		return k
+	}
+	logLambda := math.Log(lambda)
+	b := 0.931 + 2.53*math.Sqrt(lambda)
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := rng.Float64() - 0.5
+		v := rng.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLambda-lg {
+			return k
+		}
+	}
+}
+
+/*
+RandIntMatf64 returns an rXc mat whose elements are integers drawn uniformly
+from the range [lo, hi), (includes lo, but excludes hi), and stored as
+float64. lo must be strictly less than hi.
+*/
+func RandIntMatf64(r, c, lo, hi int) *Matf64 {
+	return RandIntMatf64With(nil, r, c, lo, hi)
+}
+
+/*
+RandIntMatf64With is like RandIntMatf64, but draws its values from rng, or
+from the package source if rng is nil (see SetRandSource).
+*/
+func RandIntMatf64With(rng *rand.Rand, r, c, lo, hi int) *Matf64 {
+	rng = randOrDefault(rng)
+	if !(lo < hi) {
+		s := "\nIn matrix.%s the lower bound, %d, is not less than the upper\n"
+		s += "bound, %d. The lower bound must be strictly less than the upper.\n"
+		s = fmt.Sprintf(s, "RandIntMatf64()", lo, hi)
+		printErr(s)
+	}
+	m := Newf64(r, c)
+	// The span of the range is computed without overflow as a uint64, and
+	// spans too wide for Int63n are drawn with Uint64, by rejection.
+	n := uint64(hi) - uint64(lo)
+	for i := range m.vals {
+		var x uint64
+		if n <= math.MaxInt64 {
+			x = uint64(rng.Int63n(int64(n)))
+		} else {
+			for x = rng.Uint64(); x >= n; x = rng.Uint64() {
+			}
+		}
+		m.vals[i] = float64(int64(uint64(lo) + x))
+	}
+	return m
+}
+
+/*
+RandSparsef64 returns an rXc mat in which a fraction density of the elements,
+chosen at random, hold values drawn uniformly from the range [0, 1), and all
+the others are zero. density must be between 0 and 1, and the number of
+non-zero elements is density*r*c, rounded to the nearest integer. Values are
+drawn from rng, or from the package source if rng is nil (see
+SetRandSource).
+
+The returned mat is dense: the package has no sparse storage, so this is
+mostly useful to simulate and benchmark sparse problems at moderate sizes.
+*/
+func RandSparsef64(r, c int, density float64, rng *rand.Rand) *Matf64 {
+	rng = randOrDefault(rng)
+	if !(density >= 0 && density <= 1) {
+		s := "\nIn matrix.%s, the density must be between 0 and 1, but received %f.\n"
+		s = fmt.Sprintf(s, "RandSparsef64()", density)
+		printErr(s)
+	}
+	m := Newf64(r, c)
+	n := len(m.vals)
+	k := int(math.Floor(density*float64(n) + 0.5))
+	// A partial Fisher-Yates shuffle picks k distinct positions.
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	for i := 0; i < k; i++ {
+		j := i + rng.Intn(n-i)
+		idx[i], idx[j] = idx[j], idx[i]
+		m.vals[idx[i]] = rng.Float64()
+	}
+	return m
+}
+
+/*
+Linspacef64 returns a 1Xn row vector of n evenly spaced values between start
+and stop, both included. For example:
+
+	m := matrix.Linspacef64(0, 1, 5)
+
+m is now [0, 0.25, 0.5, 0.75, 1]. If n is 1, m only holds start.
+*/
+func Linspacef64(start, stop float64, n int) *Matf64 {
+	if n < 0 {
+		s := "\nIn matrix.%s, the number of values must be non-negative, but\n"
+		s += "received %d.\n"
+		s = fmt.Sprintf(s, "Linspacef64()", n)
+		printErr(s)
+	}
+	m := Newf64(1, n)
+	if n == 0 {
+		return m
+	}
+	m.vals[0] = start
+	if n == 1 {
+		return m
+	}
+	step := (stop - start) / float64(n-1)
+	for i := 1; i < n-1; i++ {
+		m.vals[i] = start + float64(i)*step
+	}
+	m.vals[n-1] = stop
+	return m
+}
+
+/*
+Arangef64 returns a row vector holding the values start, start+step,
+start+2*step, and so on, up to but excluding stop, just like NumPy's arange.
+For example:
+
+	m := matrix.Arangef64(0, 1, 0.25)
+
+m is now [0, 0.25, 0.5, 0.75]. step may be negative to count down, and if
+no value lies between start and stop, the returned row vector is empty. All
+the arguments must be finite, step must not be zero, and the number of
+values must fit in an int.
+*/
+func Arangef64(start, stop, step float64) *Matf64 {
+	for _, v := range []float64{start, stop, step} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			s := "\nIn matrix.%s, all the arguments must be finite, but received\n"
+			s += "start = %f, stop = %f and step = %f.\n"
+			s = fmt.Sprintf(s, "Arangef64()", start, stop, step)
+			printErr(s)
+		}
+	}
+	if step == 0 {
+		s := "\nIn matrix.%s, the step must not be zero.\n"
+		s = fmt.Sprintf(s, "Arangef64()")
+		printErr(s)
+	}
+	n := math.Ceil((stop - start) / step)
+	if n <= 0 {
+		return Newf64(1, 0)
+	}
+	if n > float64(maxInt/2) {
+		s := "\nIn matrix.%s, the range from %f to %f with a step of %f\n"
+		s += "holds too many values to be allocated.\n"
+		s = fmt.Sprintf(s, "Arangef64()", start, stop, step)
+		printErr(s)
+	}
+	m := Newf64(1, int(n))
+	for i := range m.vals {
+		m.vals[i] = start + float64(i)*step
+	}
+	return m
+}
+
 /*
 Reshape changes the row and the columns of the mat object as long as the total
 number of values contained in the mat object remains constant. The order and
@@ -399,6 +980,54 @@ func (m *Matf64) Reshape(rows, cols int) *Matf64 {
 	return m
 }
 
+/*
+Resize changes the number of rows and columns of the mat, in place, keeping
+each existing value at the same row and column. Unlike Reshape, the number
+of elements may change: rows and columns beyond the new shape are dropped,
+and new rows and columns are filled with fill. For example:
+
+	m := matrix.Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.Resize(3, 1, 0) // [[1], [3], [0]]
+
+The names of dropped rows and columns are dropped too, and new rows and
+columns have empty names.
+*/
+func (m *Matf64) Resize(rows, cols int, fill float64) *Matf64 {
+	if rows < 0 || cols < 0 {
+		s := "\nIn %s, the new shape must not be negative, but %dx%d was "
+		s += "received.\n"
+		s = fmt.Sprintf(s, "Resize()", rows, cols)
+		printErr(s)
+	}
+	m.materialize()
+	m.editNames(resizeNames(m.RowNames(), rows), resizeNames(m.ColNames(), cols))
+	n := rows * cols
+	vals := make([]float64, n, 2*n)
+	for i := 0; i < rows; i++ {
+		row := vals[i*cols : (i+1)*cols]
+		k := 0
+		if i < m.r {
+			k = copy(row, m.vals[i*m.c:(i+1)*m.c])
+		}
+		for j := k; j < cols; j++ {
+			row[j] = fill
+		}
+	}
+	m.r, m.c, m.vals = rows, cols, vals
+	return m
+}
+
+/*
+MemBytes returns the number of bytes of the slice backing the mat, including
+the spare capacity kept to append rows without reallocating, which is
+usually as large as the values themselves. Mats sharing their values through
+CloneCOW each report the full size of the shared slice. Compact releases the
+spare capacity, and Reserve and Grow add to it.
+*/
+func (m *Matf64) MemBytes() int {
+	return 8 * cap(m.vals)
+}
+
 /*
 Shape returns the number of rows and columns of a mat object.
 */
@@ -406,6 +1035,24 @@ func (m *Matf64) Shape() (int, int) {
 	return m.r, m.c
 }
 
+/*
+Dims returns the number of rows and columns of a mat object, just like Shape.
+Together with At, it lets a Matf64 be used wherever the method set of
+gonum's mat.Matrix is expected, except for T, which in this package
+transposes the mat in place. The gonum sub-package provides an adapter
+implementing mat.Matrix in full.
+*/
+func (m *Matf64) Dims() (int, int) {
+	return m.r, m.c
+}
+
+/*
+At returns the value at row i and column j of the mat, just like Get.
+*/
+func (m *Matf64) At(i, j int) float64 {
+	return m.Get(i, j)
+}
+
 /*
 ToSlice1D returns the values contained in a mat object as a 1D slice of float64s.
 */
@@ -415,6 +1062,88 @@ func (m *Matf64) ToSlice1D() []float64 {
 	return s
 }
 
+/*
+RawData returns the slice backing the mat, holding its values in row major
+order. Unlike ToSlice1D nothing is copied: the returned slice aliases the
+mat, so writing to it changes the mat, and it should not be used after
+operations that change the shape of the mat, such as AppendRow or Reshape.
+It is meant for handing the values to other libraries without copying them,
+as the gonum sub-package does.
+*/
+func (m *Matf64) RawData() []float64 {
+	m.materialize()
+	return m.vals[:len(m.vals):len(m.vals)]
+}
+
+/*
+RawRow returns the slice of the values backing row i of the mat. As with
+RawData nothing is copied, and the returned slice aliases the mat, so a row
+can be read and updated in place without the allocation of Row and the copy
+of SetRow:
+
+	for i := 0; i < r; i++ {
+		row := m.RawRow(i)
+		for j := range row {
+			row[j] -= mean[j]
+		}
+	}
+
+The slice should not be used after operations that change the shape of the
+mat. Negative indices count from the bottom, just as with Row.
+*/
+func (m *Matf64) RawRow(i int) []float64 {
+	i = normIndex("RawRow()", "row", i, m.r)
+	m.materialize()
+	end := (i + 1) * m.c
+	return m.vals[i*m.c : end : end]
+}
+
+/*
+Order is the layout of the values of a mat in a flat slice.
+*/
+type Order int
+
+const (
+	// RowMajor stores the rows one after the other, as C and NumPy do by
+	// default. It is the layout used by the mats of this package.
+	RowMajor Order = iota
+	// ColMajor stores the columns one after the other, as Fortran, BLAS,
+	// LAPACK and MATLAB do.
+	ColMajor
+)
+
+/*
+Flatten returns a copy of the values of the mat as a 1D slice, in the given
+order. With RowMajor, it is the same as ToSlice1D. With ColMajor, the values
+are laid out column after column, which is what column-major libraries and
+file formats expect:
+
+	m := matrix.Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.Flatten(matrix.RowMajor) // [1, 2, 3, 4]
+	m.Flatten(matrix.ColMajor) // [1, 3, 2, 4]
+*/
+func (m *Matf64) Flatten(order Order) []float64 {
+	switch order {
+	case RowMajor:
+		return m.ToSlice1D()
+	case ColMajor:
+		s := make([]float64, len(m.vals))
+		idx := 0
+		for j := 0; j < m.c; j++ {
+			for i := 0; i < m.r; i++ {
+				s[idx] = m.vals[i*m.c+j]
+				idx++
+			}
+		}
+		return s
+	default:
+		s := "\nIn %s, %d is not a valid order.\n"
+		s = fmt.Sprintf(s, "Flatten()", order)
+		printErr(s)
+	}
+	return nil
+}
+
 /*
 ToSlice2D returns the values of a mat object as a 2D slice of float64s.
 */
@@ -433,41 +1162,36 @@ func (m *Matf64) ToSlice2D() [][]float64 {
 ToCSV creates a file with the passed name, and writes the content of a mat
 object to it, by putting each row in a single comma separated line. The
 number of entries in each line is equal to the columns of the mat object.
+If the columns of the mat are named, the names are written as a header on
+the first line, so that the file can be read back by name with
+Matf64FromCSV. If the name of the file ends with ".gz", the file is
+compressed with gzip. The rows are written one at a time, see CSVAppender.
 */
 func (m *Matf64) ToCSV(fileName string) {
-	f, err := os.Create(fileName)
+	f, err := createFile(fileName)
 	if err != nil {
 		s := "\nIn %s, cannot open %s due to error: %v.\n"
 		s = fmt.Sprintf(s, "ToCSV()", fileName, err)
 		printErr(s)
 	}
-	defer f.Close()
-	str := ""
-	idx := 0
-	for i := 0; i < m.r; i++ {
-		for j := 0; j < m.c; j++ {
-			str += strconv.FormatFloat(m.vals[idx], 'e', 14, 64)
-			if j+1 != m.c {
-				str += ","
-			}
-			idx++
-		}
-		if i+1 != m.r {
-			str += "\n"
-		}
+	a := NewCSVAppender(f)
+	a.name = fileName
+	a.c = f
+	if names := m.ColNames(); names != nil {
+		a.WriteHeader(names)
 	}
-	_, err = f.Write([]byte(str))
-	if err != nil {
-		s := "\nIn %s, cannot write to %s due to error: %v.\n"
-		s = fmt.Sprintf(s, "ToCSV()", fileName, err)
-		printErr(s)
+	for i := 0; i < m.r; i++ {
+		a.AppendRow(m.vals[i*m.c : (i+1)*m.c])
 	}
+	a.Close()
 }
 
 /*
 Get returns a pointer to the float64 stored in the given row and column.
 */
 func (m *Matf64) Get(r, c int) float64 {
+	r = normIndex("Get()", "row", r, m.r)
+	c = normIndex("Get()", "column", c, m.c)
 	return m.vals[r*m.c+c]
 }
 
@@ -476,6 +1200,9 @@ Set sets the value of a mat at a given row and column to a given
 value.
 */
 func (m *Matf64) Set(r, c int, val float64) *Matf64 {
+	r = normIndex("Set()", "row", r, m.r)
+	c = normIndex("Set()", "column", c, m.c)
+	m.materialize()
 	m.vals[r*m.c+c] = val
 	return m
 }
@@ -484,6 +1211,7 @@ func (m *Matf64) Set(r, c int, val float64) *Matf64 {
 SetAll sets all values of a mat to the passed float64 value.
 */
 func (m *Matf64) SetAll(val float64) *Matf64 {
+	m.materialize()
 	for i := range m.vals {
 		m.vals[i] = val
 	}
@@ -501,6 +1229,7 @@ following would do this:
 	})
 */
 func (m *Matf64) Map(f func(*float64)) *Matf64 {
+	m.materialize()
 	for i := range m.vals {
 		f(&m.vals[i])
 	}
@@ -524,21 +1253,12 @@ in this case, the length of the passed slice must match exactly the number of
 elements in m's column, i.e. the number of rows of m.
 */
 func (m *Matf64) SetCol(col int, floatOrSlice interface{}) *Matf64 {
+	col = normIndex("SetCol()", "column", col, m.c)
+	m.materialize()
 	switch val := floatOrSlice.(type) {
 	case float64:
-		if (col >= m.c) || (col < -m.c) {
-			s := "\nIn %s the requested column %d is outside of bounds [%d, %d)\n"
-			s = fmt.Sprintf(s, "SetCol()", col, m.c, m.c)
-			printErr(s)
-		}
-		if col >= 0 {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+col] = val
-			}
-		} else {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+(m.c+col)] = val
-			}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val
 		}
 	case []float64:
 		if len(val) != m.r {
@@ -547,14 +1267,8 @@ func (m *Matf64) SetCol(col int, floatOrSlice interface{}) *Matf64 {
 			s = fmt.Sprintf(s, "SetCol()", len(val), m.r)
 			printErr(s)
 		}
-		if col >= 0 {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+col] = val[r]
-			}
-		} else {
-			for r := 0; r < m.r; r++ {
-				m.vals[r*m.c+(m.c+col)] = val[r]
-			}
+		for r := 0; r < m.r; r++ {
+			m.vals[r*m.c+col] = val[r]
 		}
 	default:
 		s := "\nIn %s, the passed value must be a float64 or []float64.\n"
@@ -582,21 +1296,12 @@ in this case, the length of the passed slice must match exactly the number of
 elements in m's row, i.e. the number of cols of m.
 */
 func (m *Matf64) SetRow(row int, floatOrSlice interface{}) *Matf64 {
+	row = normIndex("SetRow()", "row", row, m.r)
+	m.materialize()
 	switch val := floatOrSlice.(type) {
 	case float64:
-		if (row >= m.r) || (row < -m.r) {
-			s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
-			s = fmt.Sprintf(s, "SetRow()", row, m.r, m.r)
-			printErr(s)
-		}
-		if row >= 0 {
-			for r := 0; r < m.c; r++ {
-				m.vals[row*m.c+r] = val
-			}
-		} else {
-			for r := 0; r < m.c; r++ {
-				m.vals[(m.r+row)*m.c+r] = val
-			}
+		for r := 0; r < m.c; r++ {
+			m.vals[row*m.c+r] = val
 		}
 	case []float64:
 		if len(val) != m.c {
@@ -605,15 +1310,7 @@ func (m *Matf64) SetRow(row int, floatOrSlice interface{}) *Matf64 {
 			s = fmt.Sprintf(s, "SetRow()", len(val), m.c)
 			printErr(s)
 		}
-		if row >= 0 {
-			for r := 0; r < m.c; r++ {
-				m.vals[row*m.c+r] = val[r]
-			}
-		} else {
-			for r := 0; r < m.c; r++ {
-				m.vals[(m.r+row)*m.c+r] = val[r]
-			}
-		}
+		copy(m.vals[row*m.c:(row+1)*m.c], val)
 	default:
 		s := "\nIn %s, the passed value must be a float64 or []float64.\n"
 		s += "However, value of type  %v was received.\n"
@@ -635,20 +1332,15 @@ This function supports negative indexing. For example,
 returns the last column of m.
 */
 func (m *Matf64) Col(x int) *Matf64 {
-	if (x >= m.c) || (x < -m.c) {
-		s := "\nIn %s the requested column %d is outside of bounds [-%d, %d)\n"
-		s = fmt.Sprintf(s, "Col()", x, m.c, m.c)
-		printErr(s)
-	}
+	x = normIndex("Col()", "column", x, m.c)
 	v := Newf64(m.r, 1)
-	if x >= 0 {
-		for r := 0; r < m.r; r++ {
-			v.vals[r] = m.vals[r*m.c+x]
-		}
+	for r := 0; r < m.r; r++ {
+		v.vals[r] = m.vals[r*m.c+x]
+	}
+	if cols := m.ColNames(); cols != nil {
+		v.editNames(m.RowNames(), cols[x:x+1])
 	} else {
-		for r := 0; r < m.r; r++ {
-			v.vals[r] = m.vals[r*m.c+(m.c+x)]
-		}
+		v.editNames(m.RowNames(), nil)
 	}
 	return v
 }
@@ -662,25 +1354,82 @@ This function supports negative indexing. For example,
 
 	v := m.Row(-1)
 
-returns the last row of m.
+returns the last row of m.
+*/
+func (m *Matf64) Row(x int) *Matf64 {
+	x = normIndex("Row()", "row", x, m.r)
+	v := Newf64(1, m.c)
+	copy(v.vals, m.vals[x*m.c:(x+1)*m.c])
+	if rows := m.RowNames(); rows != nil {
+		v.editNames(rows[x:x+1], m.ColNames())
+	} else {
+		v.editNames(nil, m.ColNames())
+	}
+	return v
+}
+
+/*
+ShuffleRows randomly reorders the rows of the mat in place, drawing from rng,
+or from the package source if rng is nil (see SetRandSource). Every ordering
+of the rows is equally likely, which makes it suitable for splitting a
+dataset into random minibatches.
+*/
+func (m *Matf64) ShuffleRows(rng *rand.Rand) *Matf64 {
+	m.materialize()
+	rng = randOrDefault(rng)
+	rows := m.RowNames()
+	tmp := make([]float64, m.c)
+	for i := m.r - 1; i > 0; i-- {
+		j := rng.Intn(i + 1)
+		if i == j {
+			continue
+		}
+		if rows != nil {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+		a, b := m.vals[i*m.c:(i+1)*m.c], m.vals[j*m.c:(j+1)*m.c]
+		copy(tmp, a)
+		copy(a, b)
+		copy(b, tmp)
+	}
+	m.editNames(rows, m.ColNames())
+	return m
+}
+
+/*
+Tril returns a new mat holding the lower triangle of m, where all the
+elements above the kth diagonal are set to zero. The main diagonal is k = 0,
+diagonals above it have k > 0, and diagonals below it have k < 0. For
+example, m.Tril(0) keeps the main diagonal, while m.Tril(-1) excludes it. The
+original mat is left intact.
+*/
+func (m *Matf64) Tril(k int) *Matf64 {
+	n := m.Copy()
+	for i := 0; i < n.r; i++ {
+		for j := i + k + 1; j < n.c; j++ {
+			if j >= 0 {
+				n.vals[i*n.c+j] = 0.0
+			}
+		}
+	}
+	return n
+}
+
+/*
+Triu returns a new mat holding the upper triangle of m, where all the
+elements below the kth diagonal are set to zero (see Tril for the numbering
+of the diagonals). For example, m.Triu(1) keeps the strict upper triangle,
+which is the mask used to hide future positions in causal attention. The
+original mat is left intact.
 */
-func (m *Matf64) Row(x int) *Matf64 {
-	if (x >= m.r) || (x < -m.r) {
-		s := "\nIn %s, row %d is outside of the bounds [-%d, %d)\n"
-		s = fmt.Sprintf(s, "Row()", x, m.r, m.r)
-		printErr(s)
-	}
-	v := Newf64(1, m.c)
-	if x >= 0 {
-		for r := 0; r < m.c; r++ {
-			v.vals[r] = m.vals[x*m.c+r]
-		}
-	} else {
-		for r := 0; r < m.c; r++ {
-			v.vals[r] = m.vals[(m.r+x)*m.c+r]
+func (m *Matf64) Triu(k int) *Matf64 {
+	n := m.Copy()
+	for i := 0; i < n.r; i++ {
+		for j := 0; j < i+k && j < n.c; j++ {
+			n.vals[i*n.c+j] = 0.0
 		}
 	}
-	return v
+	return n
 }
 
 /*
@@ -696,9 +1445,9 @@ values:
 	idx, val := m.Min(0, 3) // Get the min index and value of the 4th row
 	idx, val := m.Min(1, 2) // Get the min index and value of the 3rd column
 
-Note that negative index values are not supported at this time. Also note that
-in the case where multiple values are the maximum, the index of the first
-encountered value is returned.
+Negative indices count from the end, as with Row and Col, so m.Min(1, -1)
+looks at the last column. Also note that in the case where multiple values
+are the maximum, the index of the first encountered value is returned.
 */
 func (m *Matf64) Min(args ...int) (index int, minVal float64) {
 	switch len(args) {
@@ -715,11 +1464,7 @@ func (m *Matf64) Min(args ...int) (index int, minVal float64) {
 		axis, slice := args[0], args[1]
 		switch axis {
 		case 0:
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Min()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Min()", "row", slice, m.r)
 			index = 0
 			minVal = m.vals[slice*m.c]
 			for i := 1; i < m.c; i++ {
@@ -729,11 +1474,7 @@ func (m *Matf64) Min(args ...int) (index int, minVal float64) {
 				}
 			}
 		case 1:
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Min()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Min()", "column", slice, m.c)
 			index = 0
 			minVal = m.vals[slice]
 			for i := 1; i < m.r; i++ {
@@ -769,9 +1510,9 @@ values:
 	idx, val := m.Max(0, 3) // Get the max index and value of the 4th row
 	idx, val := m.Max(1, 2) // Get the max index and value of the 3rd column
 
-Note that negative index values are not supported at this time. Also note that
-in the case where multiple values are the maximum, the index of the first
-encountered value is returned.
+Negative indices count from the end, as with Row and Col, so m.Max(1, -1)
+looks at the last column. Also note that in the case where multiple values
+are the maximum, the index of the first encountered value is returned.
 */
 func (m *Matf64) Max(args ...int) (index int, maxVal float64) {
 	switch len(args) {
@@ -788,11 +1529,7 @@ func (m *Matf64) Max(args ...int) (index int, maxVal float64) {
 		axis, slice := args[0], args[1]
 		switch axis {
 		case 0:
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Max()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Max()", "row", slice, m.r)
 			index = 0
 			maxVal = m.vals[slice*m.c]
 			for i := 1; i < m.c; i++ {
@@ -802,11 +1539,7 @@ func (m *Matf64) Max(args ...int) (index int, maxVal float64) {
 				}
 			}
 		case 1:
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Max()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Max()", "column", slice, m.c)
 			index = 0
 			maxVal = m.vals[slice]
 			for i := 1; i < m.r; i++ {
@@ -856,9 +1589,60 @@ that the object can be manipulated without effecting the original mat object.
 func (m *Matf64) Copy() *Matf64 {
 	n := Newf64(m.r, m.c)
 	copy(n.vals, m.vals)
+	n.names = m.names
+	n.meta = m.meta
 	return n
 }
 
+/*
+CloneCOW returns a copy of a mat object which shares its values with the
+original until either of the two is modified. The first write to either mat
+transparently copies the values, so the clone behaves exactly like one made
+by Copy(), without paying for the duplication when neither side is ever
+modified. This makes it cheap to hand out many read-only copies of a large mat:
+
+	n := m.CloneCOW() // no values are copied
+	n.Set(0, 0, 1.0)  // n gets its own values here, m is left intact
+
+Note that a clone which is never written to still counts as sharing the values,
+so the original will make a copy on its first write even if the clone has
+since been discarded.
+*/
+func (m *Matf64) CloneCOW() *Matf64 {
+	if m.cow == nil {
+		m.cow = &cowRef{n: 1}
+	}
+	atomic.AddInt32(&m.cow.n, 1)
+	return &Matf64{
+		r:     m.r,
+		c:     m.c,
+		vals:  m.vals[:len(m.vals):len(m.vals)],
+		cow:   m.cow,
+		names: m.names,
+		meta:  m.meta,
+	}
+}
+
+// cowRef counts the mats sharing a slice of values after CloneCOW.
+type cowRef struct {
+	n int32
+}
+
+// materialize gives m its own copy of its values if they are shared with
+// another mat through CloneCOW. Every method that modifies the values of a
+// mat must call it before doing so.
+func (m *Matf64) materialize() {
+	if m.cow == nil {
+		return
+	}
+	if atomic.AddInt32(&m.cow.n, -1) > 0 {
+		vals := make([]float64, len(m.vals), 2*len(m.vals))
+		copy(vals, m.vals)
+		m.vals = vals
+	}
+	m.cow = nil
+}
+
 /*
 T returns the transpose of the original matrix. The transpose of a mat object
 is defined in the usual manner, where every value at row x, and column y is
@@ -868,10 +1652,13 @@ respectively. This method creates a new mat object, and the original is
 left intact.
 */
 func (m *Matf64) T() *Matf64 {
+	rows, cols := m.RowNames(), m.ColNames()
+	defer m.editNames(cols, rows)
 	if m.isRowVector() || m.isColVector() {
 		m.r, m.c = m.c, m.r
 		return m
 	}
+	m.materialize()
 	n := f64Pool.get()
 	defer f64Pool.put(n)
 
@@ -962,6 +1749,7 @@ This will result in each element of m being 100.0.
 Note: For the matrix cross product see the Dot() method.
 */
 func (m *Matf64) Mul(float64OrMatf64 interface{}) *Matf64 {
+	m.materialize()
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
@@ -1014,6 +1802,7 @@ that the passed Matf64 must have the same shape as the receiver.
 This will result in each element of m being 20.0.
 */
 func (m *Matf64) Add(float64OrMatf64 interface{}) *Matf64 {
+	m.materialize()
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
@@ -1066,6 +1855,7 @@ that the passed Matf64 must have the same shape as the receiver.
 This will result in each element of m being 0.0.
 */
 func (m *Matf64) Sub(float64OrMatf64 interface{}) *Matf64 {
+	m.materialize()
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
@@ -1122,6 +1912,7 @@ contains any elements which are 0.0.
 This will result in each element of m being 1.0.
 */
 func (m *Matf64) Div(float64OrMatf64 interface{}) *Matf64 {
+	m.materialize()
 	switch v := float64OrMatf64.(type) {
 	case float64:
 		for i := range m.vals {
@@ -1152,6 +1943,46 @@ func (m *Matf64) Div(float64OrMatf64 interface{}) *Matf64 {
 	return m
 }
 
+/*
+DivSafe is like Div, but every element divided by zero is set to fill,
+instead of becoming +Inf, -Inf or NaN. For example:
+
+	m := matrix.Matf64FromData([]float64{1, 2, 3})
+	n := matrix.Matf64FromData([]float64{2, 0, 3})
+	m.DivSafe(n, 0)
+
+This will result in m being [0.5, 0, 1]. Unlike Div, the passed float64 or
+the elements of the passed Matf64 may be 0.0.
+*/
+func (m *Matf64) DivSafe(float64OrMatf64 interface{}, fill float64) *Matf64 {
+	m.materialize()
+	switch v := float64OrMatf64.(type) {
+	case float64:
+		for i := range m.vals {
+			if v == 0 {
+				m.vals[i] = fill
+			} else {
+				m.vals[i] /= v
+			}
+		}
+	case *Matf64:
+		checkSameShape("DivSafe()", m, v)
+		for i, d := range v.vals {
+			if d == 0 {
+				m.vals[i] = fill
+			} else {
+				m.vals[i] /= d
+			}
+		}
+	default:
+		s := "\nIn %s, the passed value must be a float64 or *Matf64.\n"
+		s += "However, value of type  \"%v\" was received.\n"
+		s = fmt.Sprintf(s, "DivSafe()", reflect.TypeOf(v))
+		printErr(s)
+	}
+	return m
+}
+
 /*
 Sum takes the sum of the elements of a Matf64. It can be called in one of two ways:
 
@@ -1164,8 +1995,8 @@ row or column. For example:
 	m.Sum(0, 2) // Returns the sum of the 3rd row
 	m.Sum(1, 0) // Returns the sum of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Sum(0, -1) uses the last row.
 */
 func (m *Matf64) Sum(args ...int) float64 {
 	sum := 0.0
@@ -1178,20 +2009,12 @@ func (m *Matf64) Sum(args ...int) float64 {
 		axis, slice := args[0], args[1]
 		switch axis {
 		case 0:
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Sum()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Sum()", "row", slice, m.r)
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
 			}
 		case 1:
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Sum()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Sum()", "column", slice, m.c)
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
 			}
@@ -1221,8 +2044,8 @@ specifying the row or column. For example:
 	m.Avg(0, 2) // Returns the average of the 3rd row
 	m.Avg(1, 0) // Returns the average of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Avg(0, -1) uses the last row.
 */
 func (m *Matf64) Avg(args ...int) float64 {
 	sum := 0.0
@@ -1235,21 +2058,13 @@ func (m *Matf64) Avg(args ...int) float64 {
 	case 2:
 		axis, slice := args[0], args[1]
 		if axis == 0 {
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Avg()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Avg()", "row", slice, m.r)
 			for i := 0; i < m.c; i++ {
 				sum += m.vals[slice*m.c+i]
 			}
 			sum /= float64(m.c)
 		} else if axis == 1 {
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Avg()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Avg()", "column", slice, m.c)
 			for i := 0; i < m.r; i++ {
 				sum += m.vals[i*m.c+slice]
 			}
@@ -1281,8 +2096,8 @@ specifying the row or column. For example:
 	m.Prd(0, 2) // Returns the product of the 3rd row
 	m.Prd(1, 0) // Returns the product of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Prd(0, -1) uses the last row.
 */
 func (m *Matf64) Prd(args ...int) float64 {
 	prd := 1.0
@@ -1294,20 +2109,12 @@ func (m *Matf64) Prd(args ...int) float64 {
 	case 2:
 		axis, slice := args[0], args[1]
 		if axis == 0 {
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Prd()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Prd()", "row", slice, m.r)
 			for i := 0; i < m.c; i++ {
 				prd *= m.vals[slice*m.c+i]
 			}
 		} else if axis == 1 {
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Prd()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Prd()", "column", slice, m.c)
 			for i := 0; i < m.r; i++ {
 				prd *= m.vals[i*m.c+slice]
 			}
@@ -1338,8 +2145,8 @@ specifying the row or column. For example:
 	m.Std(0, 2) // Returns the standard deviation of the 3rd row
 	m.Std(1, 0) // Returns the standard deviation of the first column.
 
-Note that second passed integer cannot be less than 0, or greater that the
-length of the matrix in that dimension.
+The second passed integer may be negative, in which case it counts from the
+end, so m.Std(0, -1) uses the last row.
 */
 func (m *Matf64) Std(args ...int) float64 {
 	std := 0.0
@@ -1354,29 +2161,21 @@ func (m *Matf64) Std(args ...int) float64 {
 	case 2:
 		axis, slice := args[0], args[1]
 		if axis == 0 {
-			if (slice >= m.r) || (slice < 0) {
-				s := "\nIn %s the row %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Std()", slice, m.r)
-				printErr(s)
-			}
+			slice = normIndex("Std()", "row", slice, m.r)
 			avg := m.Avg(axis, slice)
 			sum := 0.0
 			for i := 0; i < m.c; i++ {
 				sum += ((avg - m.vals[slice*m.c+i]) * (avg - m.vals[slice*m.c+i]))
 			}
-			std = math.Sqrt(sum / float64(len(m.vals)))
+			std = math.Sqrt(sum / float64(m.c))
 		} else if axis == 1 {
-			if (slice >= m.c) || (slice < 0) {
-				s := "\nIn %s the column %d is outside of bounds [0, %d)\n"
-				s = fmt.Sprintf(s, "Std()", slice, m.c)
-				printErr(s)
-			}
+			slice = normIndex("Std()", "column", slice, m.c)
 			avg := m.Avg(axis, slice)
 			sum := 0.0
 			for i := 0; i < m.r; i++ {
 				sum += ((avg - m.vals[i*m.c+slice]) * (avg - m.vals[i*m.c+slice]))
 			}
-			std = math.Sqrt(sum / float64(len(m.vals)))
+			std = math.Sqrt(sum / float64(m.r))
 		} else {
 			s := "\nIn %s, the first argument must be 0 or 1, however %d "
 			s += "was received.\n"
@@ -1405,6 +2204,9 @@ then
 is a 5 by 10 mat whose element at row i and column j is given by:
 
 	Sum(m.Row(i).Mul(n.col(j))
+
+Both mats are left intact, so m and n may be the same mat. To multiply by a
+transpose without transposing either mat, see TDot and DotT.
 */
 func (m *Matf64) Dot(n *Matf64) *Matf64 {
 	if m.c != n.r {
@@ -1415,19 +2217,147 @@ func (m *Matf64) Dot(n *Matf64) *Matf64 {
 		printErr(s)
 	}
 	o := Newf64(m.r, n.c)
-	n.T()
-	defer n.T()
+	report := progressFunc()
+	for i := 0; i < m.r; i++ {
+		orow := o.vals[i*n.c : (i+1)*n.c]
+		for k, a := range m.vals[i*m.c : (i+1)*m.c] {
+			for j, b := range n.vals[k*n.c : (k+1)*n.c] {
+				orow[j] += a * b
+			}
+		}
+		if report != nil {
+			report("Dot()", i+1, m.r)
+		}
+	}
+	return o
+}
+
+/*
+TDot returns the product of the transpose of m with n, that is m.T().Dot(n),
+without transposing m. The number of rows of m must equal the number of rows
+of n. Both mats are left intact, so m and n may be the same mat, and
+m.TDot(m) is the Gram matrix of the columns of m.
+*/
+func (m *Matf64) TDot(n *Matf64) *Matf64 {
+	if m.r != n.r {
+		s := "\nIn %s the number of rows of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "TDot()", m.r, n.r)
+		printErr(s)
+	}
+	o := Newf64(m.c, n.c)
+	report := progressFunc()
+	for k := 0; k < m.r; k++ {
+		nrow := n.vals[k*n.c : (k+1)*n.c]
+		for i, a := range m.vals[k*m.c : (k+1)*m.c] {
+			orow := o.vals[i*n.c : (i+1)*n.c]
+			for j, b := range nrow {
+				orow[j] += a * b
+			}
+		}
+		if report != nil {
+			report("TDot()", k+1, m.r)
+		}
+	}
+	return o
+}
+
+/*
+DotT returns the product of m with the transpose of n, that is
+m.Dot(n.T()), without transposing n. The number of columns of m must equal
+the number of columns of n. Both mats are left intact, so m and n may be the
+same mat, and m.DotT(m) is the Gram matrix of the rows of m.
+*/
+func (m *Matf64) DotT(n *Matf64) *Matf64 {
+	if m.c != n.c {
+		s := "\nIn %s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of columns of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "DotT()", m.c, n.c)
+		printErr(s)
+	}
+	o := Newf64(m.r, n.r)
+	report := progressFunc()
 	for i := 0; i < m.r; i++ {
-		imc := i * m.c
-		mrow := m.vals[imc : imc+m.c]
+		mrow := m.vals[i*m.c : (i+1)*m.c]
 		for j := 0; j < n.r; j++ {
-			jnc := j * n.c
-			o.vals[i*n.r+j] = dotf64Helper(mrow, n.vals[jnc:jnc+n.c])
+			o.vals[i*n.r+j] = dotf64Helper(mrow, n.vals[j*n.c:(j+1)*n.c])
+		}
+		if report != nil {
+			report("DotT()", i+1, m.r)
 		}
 	}
 	return o
 }
 
+/*
+Gemmf64 is the general matrix multiplication. It computes
+
+	c = alpha * a.Dot(b) + beta * c
+
+and stores the result in c, which is also returned. Unlike Dot, no new Matf64
+is allocated, which makes it possible to accumulate products into an existing
+mat, or to build blocked algorithms on top of it. For example:
+
+	matrix.Gemmf64(1.0, a, b, 1.0, c) // c += a.Dot(b)
+
+The number of columns of a must equal the number of rows of b, and c must
+have as many rows as a and as many columns as b. c cannot be the same mat as
+a or b. When beta is 0.0, the original values of c are ignored entirely.
+*/
+func Gemmf64(alpha float64, a, b *Matf64, beta float64, c *Matf64) *Matf64 {
+	if a.c != b.r {
+		s := "\nIn %s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Gemmf64()", a.c, b.r)
+		printErr(s)
+	}
+	if c.r != a.r || c.c != b.c {
+		s := "\nIn %s the destination mat is %dx%d, but the product of the\n"
+		s += "passed mats is %dx%d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Gemmf64()", c.r, c.c, a.r, b.c)
+		printErr(s)
+	}
+	if c == a || c == b {
+		s := "\nIn %s the destination mat cannot be one of the factors.\n"
+		s = fmt.Sprintf(s, "Gemmf64()")
+		printErr(s)
+	}
+	c.materialize()
+	if beta == 0.0 {
+		for i := range c.vals {
+			c.vals[i] = 0.0
+		}
+	} else if beta != 1.0 {
+		for i := range c.vals {
+			c.vals[i] *= beta
+		}
+	}
+	if alpha == 0.0 {
+		return c
+	}
+	report := progressFunc()
+	for i := 0; i < a.r; i++ {
+		crow := c.vals[i*c.c : (i+1)*c.c]
+		for k := 0; k < a.c; k++ {
+			aik := alpha * a.vals[i*a.c+k]
+			if aik == 0.0 {
+				continue
+			}
+			brow := b.vals[k*b.c : (k+1)*b.c]
+			for j, v := range brow {
+				crow[j] += aik * v
+			}
+		}
+		if report != nil {
+			report("Gemmf64()", i+1, a.r)
+		}
+	}
+	return c
+}
+
 func dotf64Helper(a, b []float64) float64 {
 	a = a[:len(a)]
 	b = b[:len(a)]
@@ -1439,30 +2369,12 @@ func dotf64Helper(a, b []float64) float64 {
 }
 
 /*
-String returns the string representation of a mat. This is done by putting
-every row into a line, and separating the entries of that row by a space. note
-that the last line does not contain a newline.
+String returns the string representation of a mat, with one row per line and
+the shortest representation of each value, as printed by the %v verb. See
+Format for more control over the way values are printed.
 */
 func (m *Matf64) String() string {
-	var str string
-	str += "["
-	for i := 0; i < m.r; i++ {
-		for j := 0; j < m.c; j++ {
-			if j == 0 {
-				str += "["
-			}
-			str += strconv.FormatFloat(m.vals[i*m.c+j], 'f', 14, 64)
-			if j+1 != m.c {
-				str += ",\t"
-			}
-		}
-		if i+1 <= m.r {
-			str += "]\n "
-		}
-	}
-	str = str[:len(str)-2] // take out the last newline.
-	str += "]\n"
-	return str
+	return fmt.Sprintf("%v", m)
 }
 
 /*
@@ -1475,26 +2387,43 @@ func (m *Matf64) AppendCol(v []float64) *Matf64 {
 		s = fmt.Sprintf(s, "AppendCol()", m.r, len(v))
 		printErr(s)
 	}
-	// TODO: redo this by hand, instead of taking this shortcut... or check if
-	// this is a huge bottleneck
-	q := m.ToSlice2D()
-	for i := range q {
-		q[i] = append(q[i], v[i])
-	}
-	m.c++
-	m.vals = append(m.vals, v...)
+	rows, cols := m.RowNames(), m.ColNames()
+	m.widen(1)
+	m.editNames(rows, insertName(cols, len(cols), ""))
 	for i := 0; i < m.r; i++ {
-		for j := 0; j < m.c; j++ {
-			m.vals[i*m.c+j] = q[i][j]
-		}
+		m.vals[i*m.c+m.c-1] = v[i]
 	}
 	return m
 }
 
+// widen adds k columns to the right side of m. The existing values are
+// shifted in place, starting from the last row so that no row is overwritten
+// before it is moved, and the new columns are left with stale values which
+// the caller is expected to fill.
+func (m *Matf64) widen(k int) {
+	m.materialize()
+	n := m.r * (m.c + k)
+	if cap(m.vals) < n {
+		newVals := make([]float64, n, 2*n)
+		for i := 0; i < m.r; i++ {
+			copy(newVals[i*(m.c+k):], m.vals[i*m.c:(i+1)*m.c])
+		}
+		m.vals = newVals
+		m.c += k
+		return
+	}
+	m.vals = m.vals[:n]
+	for i := m.r - 1; i > 0; i-- {
+		copy(m.vals[i*(m.c+k):i*(m.c+k)+m.c], m.vals[i*m.c:(i+1)*m.c])
+	}
+	m.c += k
+}
+
 /*
 AppendRow appends a row to the bottom of a Matf64.
 */
 func (m *Matf64) AppendRow(v []float64) *Matf64 {
+	m.materialize()
 	if m.c != len(v) {
 		s := "\nIn %s the number of cols of the receiver is %d, while\n"
 		s += "the number of rows of the vector is %d. They must be equal.\n"
@@ -1514,10 +2443,291 @@ func (m *Matf64) AppendRow(v []float64) *Matf64 {
 	} else {
 		m.vals = append(m.vals, v...)
 	}
+	rows, cols := m.RowNames(), m.ColNames()
+	m.r++
+	m.editNames(insertName(rows, len(rows), ""), cols)
+	return m
+}
+
+/*
+AppendRows appends several rows to the bottom of a Matf64 at once. The rows
+can either be given as a [][]float64, each slice holding one row, or as a
+*Matf64 with as many columns as the receiver:
+
+	m.AppendRows([][]float64{{1, 2}, {3, 4}})
+	m.AppendRows(n)
+
+Room for all the rows is made at once, so appending k rows copies the
+existing values at most once, where k calls to AppendRow may copy them k/2
+times.
+*/
+func (m *Matf64) AppendRows(rowsOrMat interface{}) *Matf64 {
+	var k int
+	var src []float64
+	var names []string
+	switch v := rowsOrMat.(type) {
+	case [][]float64:
+		for i := range v {
+			if len(v[i]) != m.c {
+				s := "\nIn %s the number of cols of the receiver is %d, while\n"
+				s += "row %d has %d values. They must be equal.\n"
+				s = fmt.Sprintf(s, "AppendRows()", m.c, i, len(v[i]))
+				printErr(s)
+			}
+		}
+		k = len(v)
+	case *Matf64:
+		if v.c != m.c {
+			s := "\nIn %s the number of cols of the receiver is %d, while\n"
+			s += "the number of cols of the passed Matf64 is %d. They must be equal.\n"
+			s = fmt.Sprintf(s, "AppendRows()", m.c, v.c)
+			printErr(s)
+		}
+		k = v.r
+		src = v.vals[:v.r*v.c]
+		names = v.RowNames()
+	default:
+		s := "\nIn %s, the passed value must be a [][]float64 or *Matf64.\n"
+		s += "However, value of type  %v was received.\n"
+		s = fmt.Sprintf(s, "AppendRows()", reflect.TypeOf(v))
+		printErr(s)
+	}
+	m.materialize()
+	rows, cols := m.RowNames(), m.ColNames()
+	old := len(m.vals)
+	n := old + k*m.c
+	if cap(m.vals) < n {
+		newVals := make([]float64, n, 2*n)
+		copy(newVals, m.vals)
+		m.vals = newVals
+	} else {
+		m.vals = m.vals[:n]
+	}
+	if v, ok := rowsOrMat.([][]float64); ok {
+		for i := range v {
+			copy(m.vals[old+i*m.c:], v[i])
+		}
+	} else {
+		copy(m.vals[old:], src)
+	}
+	m.editNames(concatNames(rows, names, m.r, k), cols)
+	m.r += k
+	return m
+}
+
+/*
+AppendCols appends several columns to the right side of a Matf64 at once.
+The columns can either be given as a [][]float64, each slice holding one
+column, or as a *Matf64 with as many rows as the receiver, in which case
+AppendCols is the same as Concat. The values of each row are moved once,
+where k calls to AppendCol move them k times.
+*/
+func (m *Matf64) AppendCols(colsOrMat interface{}) *Matf64 {
+	switch v := colsOrMat.(type) {
+	case [][]float64:
+		for j := range v {
+			if len(v[j]) != m.r {
+				s := "\nIn %s the number of rows of the receiver is %d, while\n"
+				s += "column %d has %d values. They must be equal.\n"
+				s = fmt.Sprintf(s, "AppendCols()", m.r, j, len(v[j]))
+				printErr(s)
+			}
+		}
+		rows, cols := m.RowNames(), m.ColNames()
+		old := m.c
+		m.widen(len(v))
+		m.editNames(rows, concatNames(cols, nil, old, len(v)))
+		for j := range v {
+			for i, x := range v[j] {
+				m.vals[i*m.c+old+j] = x
+			}
+		}
+	case *Matf64:
+		m.Concat(v)
+	default:
+		s := "\nIn %s, the passed value must be a [][]float64 or *Matf64.\n"
+		s += "However, value of type  %v was received.\n"
+		s = fmt.Sprintf(s, "AppendCols()", reflect.TypeOf(v))
+		printErr(s)
+	}
+	return m
+}
+
+/*
+DeleteRow removes a row from the mat, in place, shifting the rows below it up
+by one. Just as with Row, negative indices count from the bottom, so
+m.DeleteRow(-1) removes the last row. The capacity of the underlying slice
+is kept, so that rows can be appended again without reallocating.
+*/
+func (m *Matf64) DeleteRow(x int) *Matf64 {
+	x = normIndex("DeleteRow()", "row", x, m.r)
+	m.materialize()
+	rows, cols := m.RowNames(), m.ColNames()
+	defer m.editNames(deleteName(rows, x), cols)
+	copy(m.vals[x*m.c:], m.vals[(x+1)*m.c:])
+	m.vals = m.vals[:len(m.vals)-m.c]
+	m.r--
+	return m
+}
+
+/*
+DeleteCol removes a column from the mat, in place, shifting the columns to
+its right left by one. Negative indices count from the right, so
+m.DeleteCol(-1) removes the last column. The values are moved in a single
+pass over the mat.
+*/
+func (m *Matf64) DeleteCol(x int) *Matf64 {
+	x = normIndex("DeleteCol()", "column", x, m.c)
+	m.materialize()
+	rows, cols := m.RowNames(), m.ColNames()
+	defer m.editNames(rows, deleteName(cols, x))
+	// Each element moves left by one slot per deleted column before it, so
+	// copying forward never overwrites a value that has not moved yet.
+	dst := x
+	for i := 0; i < m.r; i++ {
+		start := i*m.c + x + 1
+		end := (i + 1) * m.c
+		if i < m.r-1 {
+			end += x
+		}
+		dst += copy(m.vals[dst:], m.vals[start:end])
+	}
+	m.vals = m.vals[:m.r*(m.c-1)]
+	m.c--
+	return m
+}
+
+/*
+InsertRow inserts v as a new row of the mat, in place, so that it becomes row
+x and the rows from x onwards are shifted down by one. x may range from 0,
+to insert at the top, to the number of rows, to insert at the bottom just
+like AppendRow. As in Go slices and Python lists, negative indices count
+from the bottom, so m.InsertRow(-1, v) inserts v before the last row.
+*/
+func (m *Matf64) InsertRow(x int, v []float64) *Matf64 {
+	if (x > m.r) || (x < -m.r) {
+		s := "\nIn %s, row %d is outside of the bounds [-%d, %d]\n"
+		s = fmt.Sprintf(s, "InsertRow()", x, m.r, m.r)
+		printErr(s)
+	}
+	if m.c != len(v) {
+		s := "\nIn %s the number of cols of the receiver is %d, while\n"
+		s += "the length of the vector is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "InsertRow()", m.c, len(v))
+		printErr(s)
+	}
+	if x < 0 {
+		x += m.r
+	}
+	m.materialize()
+	rows, cols := m.RowNames(), m.ColNames()
+	defer m.editNames(insertName(rows, x, ""), cols)
+	n := len(m.vals) + m.c
+	if cap(m.vals) < n {
+		newVals := make([]float64, n, 2*n)
+		copy(newVals, m.vals[:x*m.c])
+		copy(newVals[(x+1)*m.c:], m.vals[x*m.c:])
+		m.vals = newVals
+	} else {
+		m.vals = m.vals[:n]
+		copy(m.vals[(x+1)*m.c:], m.vals[x*m.c:])
+	}
+	copy(m.vals[x*m.c:], v)
 	m.r++
 	return m
 }
 
+/*
+InsertCol inserts v as a new column of the mat, in place, so that it becomes
+column x and the columns from x onwards are shifted right by one. x may range
+from 0 to the number of columns, and negative indices count from the right,
+following the same rules as InsertRow.
+*/
+func (m *Matf64) InsertCol(x int, v []float64) *Matf64 {
+	if (x > m.c) || (x < -m.c) {
+		s := "\nIn %s, column %d is outside of the bounds [-%d, %d]\n"
+		s = fmt.Sprintf(s, "InsertCol()", x, m.c, m.c)
+		printErr(s)
+	}
+	if m.r != len(v) {
+		s := "\nIn %s the number of rows of the receiver is %d, while\n"
+		s += "the length of the vector is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "InsertCol()", m.r, len(v))
+		printErr(s)
+	}
+	if x < 0 {
+		x += m.c
+	}
+	rows, cols := m.RowNames(), m.ColNames()
+	defer m.editNames(rows, insertName(cols, x, ""))
+	m.widen(1)
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		copy(row[x+1:], row[x:])
+		row[x] = v[i]
+	}
+	return m
+}
+
+/*
+Roll circularly shifts the rows or the columns of the mat, in place. With an
+axis of 0, row i moves to row i+shift, and the rows pushed past the bottom
+wrap around to the top. With an axis of 1, the columns are shifted to the
+right in the same way. A negative shift moves rows up or columns left. For
+example:
+
+	m := matrix.Matf64FromData([]float64{1, 2, 3, 4})
+	m.Roll(1, 1) // [4, 1, 2, 3]
+*/
+func (m *Matf64) Roll(shift, axis int) *Matf64 {
+	var n int
+	switch axis {
+	case 0:
+		n = m.r
+	case 1:
+		n = m.c
+	default:
+		s := "\nIn %s, the axis must be 0 or 1, however %d was received.\n"
+		s = fmt.Sprintf(s, "Roll()", axis)
+		printErr(s)
+	}
+	if n == 0 {
+		return m
+	}
+	shift %= n
+	if shift < 0 {
+		shift += n
+	}
+	if shift == 0 {
+		return m
+	}
+	m.materialize()
+	rows, cols := m.RowNames(), m.ColNames()
+	if axis == 0 {
+		rollSlice(m.vals, shift*m.c)
+		m.editNames(rollNames(rows, shift), cols)
+		return m
+	}
+	m.editNames(rows, rollNames(cols, shift))
+	for i := 0; i < m.r; i++ {
+		rollSlice(m.vals[i*m.c:(i+1)*m.c], shift)
+	}
+	return m
+}
+
+// rollSlice circularly shifts v to the right by k, with 0 <= k < len(v),
+// using three reversals so that no extra memory is needed.
+func rollSlice(v []float64, k int) {
+	reverse := func(v []float64) {
+		for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+			v[i], v[j] = v[j], v[i]
+		}
+	}
+	reverse(v)
+	reverse(v[:k])
+	reverse(v[k:])
+}
+
 /*
 Concat merges a passed mat to the right side of the receiver. The passed mat
 must therefore have the same number of rows as the receiver.
@@ -1528,7 +2738,8 @@ For example:
 	m.Concat(n)
 	fmt.Println(m) // [[2.0, 2.0, 3.0, 3.0, 3.0]]
 
-Note that in the current implementation this is a somewhat expensive function.
+The receiver is widened in place, and a new underlying slice is only
+allocated when its capacity is exceeded.
 */
 func (m *Matf64) Concat(n *Matf64) *Matf64 {
 	if m.r != n.r {
@@ -1537,18 +2748,15 @@ func (m *Matf64) Concat(n *Matf64) *Matf64 {
 		s = fmt.Sprintf(s, "Concat()", m.r, n.r)
 		printErr(s)
 	}
-	q := m.ToSlice2D()
-	t := n.ToSlice1D()
-	r := n.ToSlice2D()
-	m.vals = append(m.vals, t...)
-	for i := range q {
-		q[i] = append(q[i], r[i]...)
+	if n == m {
+		n = m.Copy()
 	}
-	m.c += n.c
+	rows, cols := m.RowNames(), concatNames(m.ColNames(), n.ColNames(), m.c, n.c)
+	defer m.editNames(rows, cols)
+	oldC := m.c
+	m.widen(n.c)
 	for i := 0; i < m.r; i++ {
-		for j := 0; j < m.c; j++ {
-			m.vals[i*m.c+j] = q[i][j]
-		}
+		copy(m.vals[i*m.c+oldC:(i+1)*m.c], n.vals[i*n.c:(i+1)*n.c])
 	}
 	return m
 }
@@ -1566,6 +2774,7 @@ For example:
 Note that in the current implementation this is a somewhat expensive function.
 */
 func (m *Matf64) Append(n *Matf64) *Matf64 {
+	m.materialize()
 	if m.c != n.c {
 		s := "\nIn %s the number of cols of the receiver is %d, while\n"
 		s += "the number of cols of the passed Matf64 is %d. They must be equal.\n"
@@ -1,7 +1,10 @@
 package matrix
 
 import (
+	"io/ioutil"
 	"log"
+	"math"
+	"math/rand"
 	"os"
 	"testing"
 
@@ -34,6 +37,62 @@ func TestNewf64(t *testing.T) {
 	assert.Equal(t, 2*rows*cols, cap(m.vals), "should have twice the capacity")
 }
 
+func TestFullf64(t *testing.T) {
+	t.Helper()
+	m := Fullf64(3, 4, 2.5)
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 4, m.c, "should be equal")
+	assert.True(t, m.All(func(v *float64) bool { return *v == 2.5 }), "should be full")
+	assert.True(t, Onesf64(2, 5).All(func(v *float64) bool { return *v == 1 }), "should be ones")
+	assert.True(t, Zerosf64(2, 5).All(func(v *float64) bool { return *v == 0 }), "should be zeros")
+
+	n := OnesLikef64(m)
+	assert.Equal(t, 3, n.r, "should be equal")
+	assert.Equal(t, 4, n.c, "should be equal")
+	assert.True(t, n.All(func(v *float64) bool { return *v == 1 }), "should be ones")
+	n = ZerosLikef64(m)
+	assert.Equal(t, 3, n.r, "should be equal")
+	assert.Equal(t, 4, n.c, "should be equal")
+	assert.True(t, n.All(func(v *float64) bool { return *v == 0 }), "should be zeros")
+}
+
+func TestEyef64(t *testing.T) {
+	t.Helper()
+	want := Matf64FromData([][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+	assert.True(t, want.Equals(Eyef64(3)), "should be the identity")
+	assert.True(t, want.Equals(Identityf64(3)), "should be the identity")
+	want = Matf64FromData([][]float64{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}})
+	assert.True(t, want.Equals(Eyef64(3, 1)), "should be equal")
+	want = Matf64FromData([][]float64{{0, 0, 0}, {0, 0, 0}, {1, 0, 0}})
+	assert.True(t, want.Equals(Eyef64(3, -2)), "should be equal")
+	assert.Equal(t, 0.0, Eyef64(3, 3).Sum(), "should be all zeros")
+}
+
+func TestDiagf64(t *testing.T) {
+	t.Helper()
+	m := Diagf64([]float64{1, 2, 3})
+	want := Matf64FromData([][]float64{{1, 0, 0}, {0, 2, 0}, {0, 0, 3}})
+	assert.True(t, want.Equals(m), "should be equal")
+	m = Diagf64(nil)
+	assert.Equal(t, 0, m.r, "should be empty")
+	assert.Equal(t, 0, m.c, "should be empty")
+}
+
+func TestPermutationMatf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}, {5, 6}})
+	p := PermutationMatf64([]int{2, 0, 1})
+	want := Matf64FromData([][]float64{{5, 6}, {1, 2}, {3, 4}})
+	assert.True(t, want.Equals(p.Dot(m)), "should reorder the rows")
+
+	p = RandPermMatf64(6)
+	assert.Equal(t, 6.0, p.Sum(), "should hold n ones")
+	for i := 0; i < 6; i++ {
+		assert.Equal(t, 1.0, p.Sum(0, i), "each row should hold a single one")
+		assert.Equal(t, 1.0, p.Sum(1, i), "each column should hold a single one")
+	}
+}
+
 func TestMatf64FromData(t *testing.T) {
 	t.Helper()
 	rows := 50
@@ -183,6 +242,56 @@ func TestMatf64FromCSV(t *testing.T) {
 	}
 }
 
+func TestMatf64FromCSVColumns(t *testing.T) {
+	t.Helper()
+	filename := "cols_test.csv"
+	str := "id,name,height,weight\n1,ann,1.5,60\n2,bob,NA,72.5\n3,cid,1.8,\n"
+	err := ioutil.WriteFile(filename, []byte(str), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(filename)
+
+	m := Matf64FromCSV(filename, "weight", "height")
+	assert.Equal(t, 3, m.r, "should not include the header")
+	assert.Equal(t, 2, m.c, "should be equal")
+	assert.Equal(t, 60.0, m.Get(0, 0), "should be equal")
+	assert.Equal(t, 1.5, m.Get(0, 1), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(1, 1)), "should be NaN")
+	assert.True(t, math.IsNaN(m.Get(2, 0)), "should be NaN")
+
+	m = Matf64FromCSV(filename, 0, -1)
+	assert.Equal(t, 3, m.r, "should not include the header")
+	assert.Equal(t, 2, m.c, "should be equal")
+	assert.Equal(t, []string{"id", "weight"}, m.ColNames(), "should be equal")
+	assert.Equal(t, 3.0, m.Get(2, 0), "should be equal")
+	assert.Equal(t, 72.5, m.Get(1, 1), "should be equal")
+
+	str = "1,ann,1.5\n2,bob,NA\n"
+	err = ioutil.WriteFile(filename, []byte(str), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	m = Matf64FromCSV(filename)
+	assert.Equal(t, 2, m.r, "should be equal")
+	assert.Equal(t, 3, m.c, "should load every column")
+	assert.Equal(t, 2.0, m.Get(1, 0), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 1)), "should be NaN")
+	assert.True(t, math.IsNaN(m.Get(1, 2)), "should be NaN")
+
+	// A missing value on the first line does not change the columns.
+	str = "a,b,c\n1,NA,3\n4,5,6\n"
+	err = ioutil.WriteFile(filename, []byte(str), 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	m = Matf64FromCSV(filename)
+	assert.Equal(t, 3, m.c, "should keep the column")
+	assert.Equal(t, []string{"a", "b", "c"}, m.ColNames(), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(0, 1)), "should be NaN")
+	assert.Equal(t, 5.0, m.Get(1, 1), "should be equal")
+}
+
 func TestRandf64(t *testing.T) {
 	t.Helper()
 	rows := 31
@@ -208,6 +317,116 @@ func TestRandf64(t *testing.T) {
 	}
 }
 
+func TestRandnf64(t *testing.T) {
+	t.Helper()
+	m := RandnMatf64(200, 100)
+	assert.InDelta(t, 0.0, m.Avg(), 0.04, "mean should be close to 0")
+	assert.InDelta(t, 1.0, m.Std(), 0.02, "std should be close to 1")
+	m = RandnMatf64(200, 100, 5.0, 0.5)
+	assert.InDelta(t, 5.0, m.Avg(), 0.02, "mean should be close to 5")
+	assert.InDelta(t, 0.5, m.Std(), 0.01, "std should be close to 0.5")
+}
+
+func TestRandExpf64(t *testing.T) {
+	t.Helper()
+	m := RandExpMatf64(200, 100, 4.0)
+	assert.True(t, m.All(func(v *float64) bool { return *v >= 0 }), "should be non-negative")
+	assert.InDelta(t, 0.25, m.Avg(), 0.01, "mean should be close to 1/rate")
+}
+
+func TestRandPoissonf64(t *testing.T) {
+	t.Helper()
+	for _, lambda := range []float64{0, 2.5, 40, 1e6} {
+		m := RandPoissonMatf64(200, 100, lambda)
+		assert.True(t, m.All(func(v *float64) bool {
+			return *v >= 0 && *v == math.Floor(*v)
+		}), "should be counts")
+		tol := 0.05*math.Sqrt(lambda) + 1e-12
+		assert.InDelta(t, lambda, m.Avg(), tol, "mean should be close to lambda")
+		assert.InDelta(t, math.Sqrt(lambda), m.Std(), tol, "std should be close to sqrt(lambda)")
+	}
+}
+
+func TestRandIntf64(t *testing.T) {
+	t.Helper()
+	m := RandIntMatf64(30, 40, -3, 4)
+	seen := make(map[float64]bool)
+	for _, v := range m.vals {
+		assert.True(t, v >= -3 && v < 4 && v == math.Floor(v), "should be an integer in range")
+		seen[v] = true
+	}
+	assert.Equal(t, 7, len(seen), "should draw every value")
+	m = RandIntMatf64(2, 2, 5, 6)
+	assert.True(t, m.All(func(v *float64) bool { return *v == 5 }), "should be equal")
+
+	m = RandIntMatf64With(rand.New(rand.NewSource(1)), 10, 10, -maxInt-1, maxInt)
+	assert.True(t, m.Any(func(v *float64) bool { return *v < 0 }), "should draw negative values")
+	assert.True(t, m.Any(func(v *float64) bool { return *v > 0 }), "should draw positive values")
+}
+
+func TestRandSparsef64(t *testing.T) {
+	t.Helper()
+	m := RandSparsef64(20, 30, 0.1, rand.New(rand.NewSource(1)))
+	nonZero := 0
+	for _, v := range m.vals {
+		assert.True(t, v >= 0 && v < 1, "should be in range")
+		if v != 0 {
+			nonZero++
+		}
+	}
+	assert.Equal(t, 60, nonZero, "should have the requested density")
+	assert.Equal(t, 0.0, RandSparsef64(3, 3, 0, nil).Sum(), "should be all zeros")
+	assert.True(t, RandSparsef64(3, 3, 1, nil).All(func(v *float64) bool {
+		return *v != 0
+	}), "should be full")
+}
+
+func TestLinspacef64(t *testing.T) {
+	t.Helper()
+	m := Linspacef64(0, 1, 5)
+	assert.Equal(t, []float64{0, 0.25, 0.5, 0.75, 1}, m.vals, "should be equal")
+	assert.Equal(t, 1, m.r, "should be a row vector")
+	m = Linspacef64(3, -3, 4)
+	assert.Equal(t, []float64{3, 1, -1, -3}, m.vals, "should be equal")
+	m = Linspacef64(0.1, 0.7, 7)
+	assert.Equal(t, 0.7, m.vals[6], "should include the endpoint")
+	assert.Equal(t, []float64{2}, Linspacef64(2, 5, 1).vals, "should be equal")
+	assert.Equal(t, 0, len(Linspacef64(2, 5, 0).vals), "should be empty")
+}
+
+func TestArangef64(t *testing.T) {
+	t.Helper()
+	m := Arangef64(0, 1, 0.25)
+	assert.Equal(t, []float64{0, 0.25, 0.5, 0.75}, m.vals, "should exclude stop")
+	assert.Equal(t, 1, m.r, "should be a row vector")
+	m = Arangef64(5, 0, -2)
+	assert.Equal(t, []float64{5, 3, 1}, m.vals, "should count down")
+	m = Arangef64(0, 1, 0.3)
+	assert.Equal(t, 4, m.c, "should be equal")
+	assert.Equal(t, 0, len(Arangef64(1, 0, 1).vals), "should be empty")
+	assert.Equal(t, 0, len(Arangef64(1, 1, 1).vals), "should be empty")
+}
+
+func TestResizef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.Resize(3, 3, -1)
+	want := Matf64FromData([][]float64{{1, 2, -1}, {3, 4, -1}, {-1, -1, -1}})
+	assert.True(t, want.Equals(m), "should grow")
+	m.Resize(2, 1, 0)
+	assert.True(t, Matf64FromData([]float64{1, 3}, 2).Equals(m), "should crop")
+	m.Resize(0, 4, 0)
+	assert.Equal(t, 0, len(m.vals), "should be empty")
+	assert.Equal(t, 4, m.c, "should be equal")
+
+	m = Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.SetColNames([]string{"a", "b"})
+	m.Resize(2, 1, 0)
+	assert.Equal(t, []string{"a"}, m.ColNames(), "should drop the name")
+	m.Resize(2, 2, 0)
+	assert.Equal(t, []string{"a", ""}, m.ColNames(), "should not bring back the name")
+}
+
 func TestReshapef64(t *testing.T) {
 	t.Helper()
 	rows, cols := 10, 12
@@ -223,6 +442,41 @@ func TestReshapef64(t *testing.T) {
 	}
 }
 
+func TestRawDataf64(t *testing.T) {
+	t.Helper()
+	vals := []float64{1, 2, 3, 4, 5, 6}
+	m := Matf64FromRawData(vals, 2, 3)
+	assert.Equal(t, 4.0, m.Get(1, 0), "should be equal")
+	m.Set(0, 0, 9)
+	assert.Equal(t, 9.0, vals[0], "should alias vals")
+	raw := m.RawData()
+	raw[5] = 7
+	assert.Equal(t, 7.0, m.Get(1, 2), "should alias m")
+
+	n := m.CloneCOW()
+	n.RawData()[0] = 0
+	assert.Equal(t, 9.0, m.Get(0, 0), "should leave the clone's source intact")
+}
+
+func TestDimsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	r, c := m.Dims()
+	assert.Equal(t, 2, r, "should be equal")
+	assert.Equal(t, 3, c, "should be equal")
+	assert.Equal(t, m.Get(1, 2), m.At(1, 2), "should be equal")
+}
+
+func TestRawRowf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}, {5, 6}})
+	row := m.RawRow(-2)
+	assert.Equal(t, []float64{3, 4}, row, "should be equal")
+	assert.Equal(t, 2, cap(row), "should not reach the next row")
+	row[1] = 0
+	assert.Equal(t, 0.0, m.Get(1, 1), "should alias m")
+}
+
 func TestShapef64(t *testing.T) {
 	t.Helper()
 	m := Newf64(11, 10)
@@ -267,6 +521,16 @@ func TestToSlicef64(t *testing.T) {
 	assert.NotEqual(t, m.vals[0], s[0][0], "changing mat should not effect data")
 }
 
+func TestFlattenf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Equal(t, []float64{1, 2, 3, 4, 5, 6}, m.Flatten(RowMajor), "should be equal")
+	assert.Equal(t, []float64{1, 4, 2, 5, 3, 6}, m.Flatten(ColMajor), "should be equal")
+	s := m.Flatten(RowMajor)
+	s[0] = 10
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be a copy")
+}
+
 func TestToCSVf64(t *testing.T) {
 	t.Helper()
 	m := Newf64(23, 17)
@@ -279,6 +543,13 @@ func TestToCSVf64(t *testing.T) {
 	if !n.Equals(m) {
 		t.Errorf("m and n are not equal")
 	}
+
+	m = Matf64FromData([][]float64{{1, 2.5}, {-3, 4}})
+	m.SetColNames([]string{"height", "weight"})
+	m.ToCSV(filename)
+	n = Matf64FromCSV(filename)
+	assert.True(t, n.Equals(m), "should skip the header")
+	assert.Equal(t, []string{"height", "weight"}, n.ColNames(), "should be equal")
 	os.Remove(filename)
 }
 
@@ -458,6 +729,73 @@ func BenchmarkRowf64(b *testing.B) {
 	}
 }
 
+func TestShuffleRowsf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(10, 2)
+	for i := 0; i < 10; i++ {
+		m.SetRow(i, float64(i))
+	}
+	n := m.Copy()
+	m.ShuffleRows(rand.New(rand.NewSource(3)))
+	seen := make(map[float64]bool)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, m.Get(i, 0), m.Get(i, 1), "rows should move as a whole")
+		seen[m.Get(i, 0)] = true
+	}
+	assert.Equal(t, 10, len(seen), "should keep every row")
+
+	n.ShuffleRows(rand.New(rand.NewSource(3)))
+	assert.True(t, m.Equals(n), "should be reproducible")
+}
+
+func TestTrilTriuf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{9, 10, 11, 12},
+	})
+	want := Matf64FromData([][]float64{
+		{1, 0, 0, 0},
+		{5, 6, 0, 0},
+		{9, 10, 11, 0},
+	})
+	assert.True(t, want.Equals(m.Tril(0)), "should be equal")
+	want = Matf64FromData([][]float64{
+		{0, 0, 0, 0},
+		{5, 0, 0, 0},
+		{9, 10, 0, 0},
+	})
+	assert.True(t, want.Equals(m.Tril(-1)), "should be equal")
+	want = Matf64FromData([][]float64{
+		{1, 2, 0, 0},
+		{5, 6, 7, 0},
+		{9, 10, 11, 12},
+	})
+	assert.True(t, want.Equals(m.Tril(1)), "should be equal")
+	want = Matf64FromData([][]float64{
+		{1, 2, 3, 4},
+		{0, 6, 7, 8},
+		{0, 0, 11, 12},
+	})
+	assert.True(t, want.Equals(m.Triu(0)), "should be equal")
+	want = Matf64FromData([][]float64{
+		{0, 2, 3, 4},
+		{0, 0, 7, 8},
+		{0, 0, 0, 12},
+	})
+	assert.True(t, want.Equals(m.Triu(1)), "should be equal")
+	want = Matf64FromData([][]float64{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{0, 10, 11, 12},
+	})
+	assert.True(t, want.Equals(m.Triu(-1)), "should be equal")
+	assert.True(t, m.Equals(m.Tril(5)), "should keep everything")
+	assert.Equal(t, 0.0, m.Triu(5).Sum(), "should zero everything")
+	assert.Equal(t, 1.0, m.Get(0, 0), "should leave the original intact")
+}
+
 func TestMinf64(t *testing.T) {
 	t.Helper()
 	m := Newf64(3, 4)
@@ -507,6 +845,39 @@ func TestCopyf64(t *testing.T) {
 	}
 }
 
+func TestCloneCOWf64(t *testing.T) {
+	t.Helper()
+	rows, cols := 7, 5
+	m := Newf64(rows, cols)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	n := m.CloneCOW()
+	o := m.CloneCOW()
+	assert.True(t, &m.vals[0] == &n.vals[0], "values should be shared")
+	assert.True(t, m.Equals(n), "should be equal")
+
+	n.Set(0, 0, 100.0)
+	assert.False(t, &m.vals[0] == &n.vals[0], "values should not be shared")
+	assert.Equal(t, 0.0, m.Get(0, 0), "original should be intact")
+	assert.Equal(t, 0.0, o.Get(0, 0), "other clones should be intact")
+	assert.Equal(t, 100.0, n.Get(0, 0), "should be equal")
+
+	m.Add(1.0)
+	assert.Equal(t, 0.0, o.Get(0, 0), "other clones should be intact")
+	assert.Equal(t, 1.0, m.Get(0, 0), "should be equal")
+
+	p := o.vals
+	o.Mul(2.0)
+	assert.True(t, &p[0] == &o.vals[0], "last owner should not copy")
+
+	q := o.CloneCOW()
+	q.AppendRow(make([]float64, cols))
+	assert.Equal(t, rows, o.r, "should be intact")
+	assert.Equal(t, rows*cols, len(o.vals), "should be intact")
+	assert.Equal(t, rows+1, q.r, "should have one more row")
+}
+
 func TestTf64(t *testing.T) {
 	t.Helper()
 	m := Newf64(12, 3)
@@ -726,6 +1097,15 @@ func BenchmarkDivf64(b *testing.B) {
 	}
 }
 
+func TestDivSafef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 0})
+	n := Matf64FromData([]float64{2, 0, 0})
+	assert.Equal(t, []float64{0.5, -1, -1}, m.Copy().DivSafe(n, -1).vals, "should be equal")
+	assert.Equal(t, []float64{7, 7, 7}, m.Copy().DivSafe(0.0, 7).vals, "should be equal")
+	assert.Equal(t, []float64{0.5, 1, 0}, m.DivSafe(2.0, 7).vals, "should be equal")
+}
+
 func TestSumf64(t *testing.T) {
 	t.Helper()
 	row := 12
@@ -776,6 +1156,10 @@ func TestStdf64(t *testing.T) {
 	for i := 0; i < col; i++ {
 		assert.Equal(t, 0.0, m.Std(1, i), "should be equal")
 	}
+	m = Matf64FromData([][]float64{{2, 4, 4, 4}, {5, 5, 7, 9}})
+	assert.Equal(t, 2.0, m.Std(), "should be equal")
+	assert.Equal(t, math.Sqrt(0.75), m.Std(0, 0), "should divide by the row length")
+	assert.Equal(t, 1.5, m.Std(1, 0), "should divide by the column length")
 }
 
 func TestDotf64(t *testing.T) {
@@ -822,6 +1206,22 @@ func TestDotf64(t *testing.T) {
 	assert.True(t, x1.Equals(x), "A times I should equal A")
 }
 
+func TestTDotDotTf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := Matf64FromData([][]float64{{1, -1}, {0, 2}})
+	c := Matf64FromData([][]float64{{2, 0, 1}, {1, 1, 1}, {0, 3, -1}, {1, 0, 0}})
+	assert.True(t, a.Copy().T().Dot(b).Equals(a.TDot(b)), "should be equal")
+	assert.True(t, a.Dot(c.Copy().T()).Equals(a.DotT(c)), "should be equal")
+	assert.True(t, a.Copy().T().Dot(a).Equals(a.TDot(a)), "should be equal")
+	assert.True(t, a.Dot(a.Copy().T()).Equals(a.DotT(a)), "should be equal")
+	assert.Equal(t, [][]float64{{1, 2, 3}, {4, 5, 6}}, a.ToSlice2D(), "should be left intact")
+
+	// Dot no longer transposes its argument, so a square mat can be squared.
+	s := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	assert.Equal(t, [][]float64{{7, 10}, {15, 22}}, s.Dot(s).ToSlice2D(), "should be equal")
+}
+
 func BenchmarkDotf64(b *testing.B) {
 	m := Newf64(10)
 	n := Newf64(10)
@@ -860,6 +1260,73 @@ func BenchmarkDotf64Vanilla(b *testing.B) {
 	}
 }
 
+func TestGemmf64(t *testing.T) {
+	t.Helper()
+	a := Newf64(4, 3)
+	for i := range a.vals {
+		a.vals[i] = float64(i)
+	}
+	b := Newf64(3, 5)
+	for i := range b.vals {
+		b.vals[i] = float64(i) - 7.0
+	}
+	c := Newf64(4, 5).SetAll(2.0)
+	want := a.Dot(b).Mul(3.0).Add(c.Copy().Mul(0.5))
+	Gemmf64(3.0, a, b, 0.5, c)
+	assert.True(t, want.Equals(c), "should be equal")
+
+	c.SetAll(1.0)
+	Gemmf64(1.0, a, b, 0.0, c)
+	assert.True(t, a.Dot(b).Equals(c), "beta of zero should ignore c")
+
+	c.SetAll(1.0)
+	Gemmf64(1.0, a, b, 1.0, c)
+	Gemmf64(1.0, a, b, 1.0, c)
+	want = a.Dot(b).Mul(2.0).Add(1.0)
+	assert.True(t, want.Equals(c), "should accumulate")
+}
+
+func BenchmarkGemmf64(b *testing.B) {
+	m := Newf64(10)
+	n := Newf64(10)
+	o := Newf64(10)
+	for i := range m.vals {
+		m.vals[i] = float64(i + i)
+	}
+	for i := range n.vals {
+		n.vals[i] = float64(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Gemmf64(1.0, m, n, 1.0, o)
+	}
+}
+
+func TestAppendRowsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}})
+	m.AppendRows([][]float64{{3, 4}, {5, 6}})
+	m.AppendRows(Matf64FromData([][]float64{{7, 8}}))
+	want := Matf64FromData([][]float64{{1, 2}, {3, 4}, {5, 6}, {7, 8}})
+	assert.True(t, want.Equals(m), "should be equal")
+	m.AppendRows(m)
+	assert.Equal(t, 8, m.r, "should be equal")
+	assert.Equal(t, want.vals, m.vals[8:], "should be equal")
+
+	m = Matf64FromData([][]float64{{1, 2}}).SetRowNames([]string{"a"})
+	m.AppendRows([][]float64{{3, 4}})
+	assert.Equal(t, []string{"a", ""}, m.RowNames(), "should be equal")
+}
+
+func TestAppendColsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1}, {2}})
+	m.AppendCols([][]float64{{3, 4}, {5, 6}})
+	m.AppendCols(Matf64FromData([][]float64{{7}, {8}}))
+	want := Matf64FromData([][]float64{{1, 3, 5, 7}, {2, 4, 6, 8}})
+	assert.True(t, want.Equals(m), "should be equal")
+}
+
 func TestAppendColf64(t *testing.T) {
 	t.Helper()
 	var (
@@ -877,6 +1344,14 @@ func TestAppendColf64(t *testing.T) {
 	assert.Equal(t, col+2, m.c, "should have two more columns")
 	m.AppendCol(v)
 	assert.Equal(t, col+3, m.c, "should have three more columns")
+	for i := 0; i < row; i++ {
+		for j := 0; j < col; j++ {
+			assert.Equal(t, float64(i*col+j), m.Get(i, j), "should be equal")
+		}
+		for j := col; j < col+3; j++ {
+			assert.Equal(t, float64(0.0), m.Get(i, j), "should be zero")
+		}
+	}
 }
 
 func TestAppendRowf64(t *testing.T) {
@@ -901,6 +1376,79 @@ func TestAppendRowf64(t *testing.T) {
 	assert.Equal(t, row+3, m.r, "should have three more rows")
 }
 
+func TestDeleteRowf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}, {5, 6}, {7, 8}})
+	m.DeleteRow(1)
+	assert.True(t, Matf64FromData([][]float64{{1, 2}, {5, 6}, {7, 8}}).Equals(m), "should be equal")
+	m.DeleteRow(-1)
+	assert.True(t, Matf64FromData([][]float64{{1, 2}, {5, 6}}).Equals(m), "should be equal")
+	m.DeleteRow(0).DeleteRow(0)
+	assert.Equal(t, 0, m.r, "should be empty")
+	assert.Equal(t, 0, len(m.vals), "should be empty")
+	m.AppendRow([]float64{9, 9})
+	assert.Equal(t, []float64{9, 9}, m.vals, "should be able to append again")
+}
+
+func TestDeleteColf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	n := m.Copy().DeleteCol(1)
+	assert.True(t, Matf64FromData([][]float64{{1, 3}, {4, 6}, {7, 9}}).Equals(n), "should be equal")
+	n = m.Copy().DeleteCol(0)
+	assert.True(t, Matf64FromData([][]float64{{2, 3}, {5, 6}, {8, 9}}).Equals(n), "should be equal")
+	n = m.Copy().DeleteCol(-1)
+	assert.True(t, Matf64FromData([][]float64{{1, 2}, {4, 5}, {7, 8}}).Equals(n), "should be equal")
+	n = Matf64FromData([]float64{1, 2, 3}, 3).DeleteCol(0)
+	assert.Equal(t, 3, n.r, "should keep the rows")
+	assert.Equal(t, 0, n.c, "should be empty")
+	c := m.CloneCOW().DeleteCol(2)
+	assert.Equal(t, 9.0, m.Get(2, 2), "should not modify the original")
+	assert.Equal(t, 8.0, c.Get(2, 1), "should be equal")
+}
+
+func TestInsertRowf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.InsertRow(1, []float64{9, 9})
+	assert.True(t, Matf64FromData([][]float64{{1, 2}, {9, 9}, {3, 4}}).Equals(m), "should be equal")
+	m.InsertRow(0, []float64{0, 0})
+	assert.True(t, Matf64FromData([][]float64{{0, 0}, {1, 2}, {9, 9}, {3, 4}}).Equals(m), "should be equal")
+	m.InsertRow(4, []float64{5, 5})
+	assert.Equal(t, []float64{5, 5}, m.Row(-1).vals, "should append at the bottom")
+	m.InsertRow(-1, []float64{7, 7})
+	assert.Equal(t, []float64{7, 7}, m.Row(-2).vals, "should insert before the last row")
+	assert.Equal(t, 6, m.r, "should be equal")
+}
+
+func TestInsertColf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	m.InsertCol(1, []float64{8, 9})
+	assert.True(t, Matf64FromData([][]float64{{1, 8, 2}, {3, 9, 4}}).Equals(m), "should be equal")
+	m.InsertCol(0, []float64{0, 0})
+	assert.True(t, Matf64FromData([][]float64{{0, 1, 8, 2}, {0, 3, 9, 4}}).Equals(m), "should be equal")
+	m.InsertCol(4, []float64{5, 6})
+	assert.Equal(t, []float64{5, 6}, m.Col(-1).vals, "should append on the right")
+	m.InsertCol(-1, []float64{7, 7})
+	assert.Equal(t, []float64{7, 7}, m.Col(-2).vals, "should insert before the last column")
+}
+
+func TestRollf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([]float64{1, 2, 3, 4})
+	assert.Equal(t, []float64{4, 1, 2, 3}, m.Copy().Roll(1, 1).vals, "should be equal")
+	assert.Equal(t, []float64{3, 4, 1, 2}, m.Copy().Roll(-2, 1).vals, "should be equal")
+	assert.Equal(t, []float64{2, 3, 4, 1}, m.Copy().Roll(7, 1).vals, "should be equal")
+	assert.Equal(t, []float64{1, 2, 3, 4}, m.Roll(1, 0).vals, "should be equal")
+
+	m = Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	want := Matf64FromData([][]float64{{7, 8, 9}, {1, 2, 3}, {4, 5, 6}})
+	assert.True(t, want.Equals(m.Copy().Roll(1, 0)), "should be equal")
+	want = Matf64FromData([][]float64{{2, 3, 1}, {5, 6, 4}, {8, 9, 7}})
+	assert.True(t, want.Equals(m.Roll(-1, 1)), "should be equal")
+}
+
 func TestConcatf64(t *testing.T) {
 	t.Helper()
 	var (
@@ -932,4 +1480,10 @@ func TestConcatf64(t *testing.T) {
 			idx2++
 		}
 	}
+	m = Newf64(2, 3).SetAll(1.0)
+	m.Concat(m)
+	assert.Equal(t, 6, m.c, "should have twice the columns")
+	for i := range m.vals {
+		assert.Equal(t, float64(1.0), m.vals[i], "should be equal")
+	}
 }
@@ -0,0 +1,307 @@
+package matrix
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Data types and array classes of the Level 5 MAT-file format, as described
+// in MathWorks' "MAT-File Format" document.
+const (
+	miINT8       = 1
+	miUINT8      = 2
+	miINT16      = 3
+	miUINT16     = 4
+	miINT32      = 5
+	miUINT32     = 6
+	miSINGLE     = 7
+	miDOUBLE     = 9
+	miINT64      = 12
+	miUINT64     = 13
+	miMATRIX     = 14
+	miCOMPRESSED = 15
+
+	mxDOUBLE_CLASS = 6
+	mxUINT64_CLASS = 15
+
+	mxCOMPLEX_FLAG = 0x08
+
+	matHeaderSize = 128
+)
+
+/*
+SaveMat saves several named mats into a Level 5 MAT-file, which can be
+loaded by MATLAB and Octave with the load command. Each mat becomes a double
+precision variable with the given name. For example:
+
+	matrix.SaveMat("results.mat", map[string]*matrix.Matf64{"A": a, "x": x})
+
+Note that the names must be valid MATLAB variable names.
+*/
+func SaveMat(path string, mats map[string]*Matf64) {
+	var b bytes.Buffer
+	text := "MATLAB 5.0 MAT-file, created by github.com/gocrunch/matrix"
+	b.WriteString(text + strings.Repeat(" ", 116-len(text)))
+	b.Write(make([]byte, 8))
+	binary.Write(&b, binary.LittleEndian, uint16(0x0100))
+	b.WriteString("IM")
+	names := make([]string, 0, len(mats))
+	for name := range mats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m := mats[name]
+		var e bytes.Buffer
+		writeMatElement(&e, miUINT32, []uint32{mxDOUBLE_CLASS, 0})
+		writeMatElement(&e, miINT32, []int32{int32(m.r), int32(m.c)})
+		writeMatElement(&e, miINT8, []byte(name))
+		// MAT-files store the values in column-major order.
+		vals := make([]float64, 0, len(m.vals))
+		for j := 0; j < m.c; j++ {
+			for i := 0; i < m.r; i++ {
+				vals = append(vals, m.vals[i*m.c+j])
+			}
+		}
+		writeMatElement(&e, miDOUBLE, vals)
+		writeMatTag(&b, miMATRIX, e.Len())
+		b.Write(e.Bytes())
+	}
+	if err := ioutil.WriteFile(path, b.Bytes(), 0644); err != nil {
+		s := "\nIn matrix.%s, cannot save %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "SaveMat()", path, err)
+		printErr(s)
+	}
+}
+
+func writeMatTag(b *bytes.Buffer, typ, n int) {
+	binary.Write(b, binary.LittleEndian, []uint32{uint32(typ), uint32(n)})
+}
+
+// writeMatElement writes a data element made of a tag and of data, padded
+// to a multiple of 8 bytes.
+func writeMatElement(b *bytes.Buffer, typ int, data interface{}) {
+	n := binary.Size(data)
+	writeMatTag(b, typ, n)
+	binary.Write(b, binary.LittleEndian, data)
+	if n%8 != 0 {
+		b.Write(make([]byte, 8-n%8))
+	}
+}
+
+/*
+LoadMat loads the numerical variables stored in a Level 5 MAT-file, such as
+the ones saved by MATLAB (with the default -v7 option, or with -v6), Octave
+(with the -v7 or -v6 options) or SaveMat. The returned map is keyed by the
+names of the variables, and all numerical types are converted to float64.
+
+Only real, dense 2D arrays can be loaded. Other variables, such as strings,
+cells, structs, complex or sparse arrays, are skipped.
+*/
+func LoadMat(path string) map[string]*Matf64 {
+	data, err := ioutil.ReadFile(path)
+	if err == nil && len(data) < matHeaderSize {
+		err = io.ErrUnexpectedEOF
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if err == nil {
+		switch string(data[126:128]) {
+		case "IM":
+		case "MI":
+			order = binary.BigEndian
+		default:
+			err = fmt.Errorf("not a Level 5 MAT-file")
+		}
+	}
+	mats := make(map[string]*Matf64)
+	if err == nil {
+		err = readMatElements(data[matHeaderSize:], order, mats)
+	}
+	if err != nil {
+		s := "\nIn matrix.%s, cannot load %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "LoadMat()", path, err)
+		printErr(s)
+	}
+	return mats
+}
+
+// readMatTag reads the tag of the data element starting at b, and returns
+// its type, its data, and the total size of the element.
+func readMatTag(b []byte, order binary.ByteOrder) (int, []byte, int, error) {
+	if len(b) < 8 {
+		return 0, nil, 0, io.ErrUnexpectedEOF
+	}
+	typ := order.Uint32(b)
+	if typ>>16 != 0 {
+		// Small data element format, where the data fits in the tag.
+		n := int(typ >> 16)
+		if n > 4 {
+			return 0, nil, 0, fmt.Errorf("invalid small data element")
+		}
+		return int(typ & 0xffff), b[4 : 4+n], 8, nil
+	}
+	n := int(order.Uint32(b[4:]))
+	if n < 0 || len(b)-8 < n {
+		return 0, nil, 0, io.ErrUnexpectedEOF
+	}
+	size := 8 + n
+	if typ != miCOMPRESSED && size%8 != 0 {
+		size += 8 - size%8
+	}
+	if size > len(b) {
+		size = len(b)
+	}
+	return int(typ), b[8 : 8+n], size, nil
+}
+
+func readMatElements(b []byte, order binary.ByteOrder, mats map[string]*Matf64) error {
+	for len(b) > 0 {
+		typ, data, size, err := readMatTag(b, order)
+		if err != nil {
+			return err
+		}
+		b = b[size:]
+		switch typ {
+		case miCOMPRESSED:
+			inner, err := readMatCompressed(data, order)
+			if err != nil {
+				return err
+			}
+			if err = readMatElements(inner, order, mats); err != nil {
+				return err
+			}
+		case miMATRIX:
+			name, m, err := readMatMatrix(data, order)
+			if err != nil {
+				return err
+			}
+			if m != nil {
+				mats[name] = m
+			}
+		}
+	}
+	return nil
+}
+
+// readMatCompressed decompresses the data of a miCOMPRESSED element, which
+// holds a single data element. Only as many bytes as the tag of that element
+// declares are read, so that a small compressed stream cannot expand without
+// bound.
+func readMatCompressed(data []byte, order binary.ByteOrder) ([]byte, error) {
+	z, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	tag := make([]byte, 8)
+	if _, err := io.ReadFull(z, tag); err != nil {
+		return nil, err
+	}
+	if order.Uint32(tag)>>16 != 0 {
+		return tag, nil
+	}
+	n := int64(order.Uint32(tag[4:]))
+	body, err := ioutil.ReadAll(io.LimitReader(z, n))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) != n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return append(tag, body...), nil
+}
+
+// readMatMatrix reads the sub-elements of a miMATRIX element. A nil mat is
+// returned for arrays that cannot be represented by a Matf64.
+func readMatMatrix(b []byte, order binary.ByteOrder) (string, *Matf64, error) {
+	var parts [4][]byte
+	var types [4]int
+	for i := range parts {
+		typ, data, size, err := readMatTag(b, order)
+		if err != nil {
+			if i < 3 {
+				return "", nil, err
+			}
+			break
+		}
+		parts[i], types[i] = data, typ
+		b = b[size:]
+	}
+	if len(parts[0]) < 8 {
+		return "", nil, fmt.Errorf("invalid array flags")
+	}
+	flags := order.Uint32(parts[0])
+	class := flags & 0xff
+	if class < mxDOUBLE_CLASS || class > mxUINT64_CLASS || flags&(mxCOMPLEX_FLAG<<8) != 0 {
+		return "", nil, nil
+	}
+	dims, err := matNumbers(parts[1], types[1], order)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(dims) != 2 {
+		return "", nil, nil
+	}
+	name := string(parts[2])
+	vals, err := matNumbers(parts[3], types[3], order)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, d := range dims {
+		if d < 0 || d > math.MaxInt32 || d != math.Trunc(d) {
+			return "", nil, fmt.Errorf("invalid dimension %v", d)
+		}
+	}
+	r, c := int(dims[0]), int(dims[1])
+	if (c == 0 && len(vals) != 0) || (c != 0 && (len(vals)%c != 0 || len(vals)/c != r)) {
+		return "", nil, fmt.Errorf(wrongLength, "LoadMat()", r, c, len(vals))
+	}
+	m := Newf64(r, c)
+	for j := 0; j < c; j++ {
+		for i := 0; i < r; i++ {
+			m.vals[i*c+j] = vals[j*r+i]
+		}
+	}
+	return name, m, nil
+}
+
+// matNumbers decodes numerical data of the given type as float64s.
+func matNumbers(b []byte, typ int, order binary.ByteOrder) ([]float64, error) {
+	var size int
+	var conv func([]byte) float64
+	switch typ {
+	case miINT8:
+		size, conv = 1, func(b []byte) float64 { return float64(int8(b[0])) }
+	case miUINT8:
+		size, conv = 1, func(b []byte) float64 { return float64(b[0]) }
+	case miINT16:
+		size, conv = 2, func(b []byte) float64 { return float64(int16(order.Uint16(b))) }
+	case miUINT16:
+		size, conv = 2, func(b []byte) float64 { return float64(order.Uint16(b)) }
+	case miINT32:
+		size, conv = 4, func(b []byte) float64 { return float64(int32(order.Uint32(b))) }
+	case miUINT32:
+		size, conv = 4, func(b []byte) float64 { return float64(order.Uint32(b)) }
+	case miSINGLE:
+		size, conv = 4, func(b []byte) float64 { return float64(math.Float32frombits(order.Uint32(b))) }
+	case miDOUBLE:
+		size, conv = 8, func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }
+	case miINT64:
+		size, conv = 8, func(b []byte) float64 { return float64(int64(order.Uint64(b))) }
+	case miUINT64:
+		size, conv = 8, func(b []byte) float64 { return float64(order.Uint64(b)) }
+	default:
+		return nil, fmt.Errorf("unsupported data type %d", typ)
+	}
+	v := make([]float64, len(b)/size)
+	for i := range v {
+		v[i] = conv(b[i*size:])
+	}
+	return v, nil
+}
@@ -0,0 +1,91 @@
+package matrix
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatFile(t *testing.T) {
+	t.Helper()
+	filename := "matfile_test.mat"
+	a := RandMatf64(5, 3)
+	x := RandMatf64(1, 4)
+	SaveMat(filename, map[string]*Matf64{"A": a, "x": x})
+	defer os.Remove(filename)
+	mats := LoadMat(filename)
+	assert.Equal(t, 2, len(mats), "should be equal")
+	assert.True(t, a.Equals(mats["A"]), "should be equal")
+	assert.True(t, x.Equals(mats["x"]), "should be equal")
+}
+
+func TestLoadMatCompressed(t *testing.T) {
+	t.Helper()
+	// A 2x2 double variable named "B" whose values are stored as uint8,
+	// inside a compressed element, as MATLAB does by default.
+	var e bytes.Buffer
+	writeMatElement(&e, miUINT32, []uint32{mxDOUBLE_CLASS, 0})
+	writeMatElement(&e, miINT32, []int32{2, 2})
+	writeMatElement(&e, miINT8, []byte("B"))
+	writeMatElement(&e, miUINT8, []uint8{1, 3, 2, 4})
+	var inner bytes.Buffer
+	writeMatTag(&inner, miMATRIX, e.Len())
+	inner.Write(e.Bytes())
+	var z bytes.Buffer
+	w := zlib.NewWriter(&z)
+	w.Write(inner.Bytes())
+	w.Close()
+
+	var f bytes.Buffer
+	f.Write(bytes.Repeat([]byte(" "), 124))
+	f.Write([]byte{0x00, 0x01, 'I', 'M'})
+	writeMatTag(&f, miCOMPRESSED, z.Len())
+	f.Write(z.Bytes())
+	// A char array, which should be skipped.
+	e.Reset()
+	writeMatElement(&e, miUINT32, []uint32{4, 0})
+	writeMatElement(&e, miINT32, []int32{1, 2})
+	writeMatElement(&e, miINT8, []byte("s"))
+	writeMatElement(&e, miUINT16, []uint16{'h', 'i'})
+	writeMatTag(&f, miMATRIX, e.Len())
+	f.Write(e.Bytes())
+
+	filename := "matfile_compressed_test.mat"
+	if err := ioutil.WriteFile(filename, f.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(filename)
+	mats := LoadMat(filename)
+	assert.Equal(t, 1, len(mats), "should skip the char array")
+	want := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	assert.True(t, want.Equals(mats["B"]), "should be equal")
+}
+
+func TestLoadMatInvalid(t *testing.T) {
+	t.Helper()
+	var e bytes.Buffer
+	writeMatElement(&e, miUINT32, []uint32{mxDOUBLE_CLASS, 0})
+	writeMatElement(&e, miINT32, []int32{-2, -2})
+	writeMatElement(&e, miINT8, []byte("B"))
+	writeMatElement(&e, miUINT8, []uint8{1, 3, 2, 4})
+	_, _, err := readMatMatrix(e.Bytes(), binary.LittleEndian)
+	assert.NotNil(t, err, "should fail on negative dimensions")
+
+	// A compressed element whose inner tag claims far more data than the
+	// stream holds.
+	var inner bytes.Buffer
+	writeMatTag(&inner, miMATRIX, 1<<31)
+	inner.Write(e.Bytes())
+	var z bytes.Buffer
+	w := zlib.NewWriter(&z)
+	w.Write(inner.Bytes())
+	w.Close()
+	_, err = readMatCompressed(z.Bytes(), binary.LittleEndian)
+	assert.Equal(t, io.ErrUnexpectedEOF, err, "should stop at the end of the stream")
+}
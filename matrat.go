@@ -0,0 +1,455 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+/*
+MatRat is a mat of exact rational numbers, backed by a []*big.Rat in row
+major order. Arithmetic on it never rounds, so determinants, inverses and
+solutions of linear systems are exact, which suits teaching tools and
+combinatorics. The numerators and denominators can grow quickly though, so
+it is only practical for small mats.
+
+As with Matf64, the fields are not accessible, and most methods modify the
+receiver in place and return it, so that calls can be chained.
+*/
+type MatRat struct {
+	r, c int
+	vals []*big.Rat
+}
+
+/*
+NewRat returns a rXc MatRat of zeros.
+*/
+func NewRat(r, c int) *MatRat {
+	if r < 0 || c < 0 {
+		s := "\nIn %s, the shape %dX%d is negative.\n"
+		s = fmt.Sprintf(s, "NewRat()", r, c)
+		printErr(s)
+	}
+	m := &MatRat{r: r, c: c, vals: make([]*big.Rat, r*c)}
+	for i := range m.vals {
+		m.vals[i] = new(big.Rat)
+	}
+	return m
+}
+
+/*
+IdentityRat returns the nXn identity mat.
+*/
+func IdentityRat(n int) *MatRat {
+	m := NewRat(n, n)
+	for i := 0; i < n; i++ {
+		m.vals[i*n+i].SetInt64(1)
+	}
+	return m
+}
+
+/*
+MatRatFromMatf64 returns a MatRat holding the exact values of the float64s in
+m, so that for instance 0.1 becomes 3602879701896397/36028797018963968.
+NaNs and infinities cannot be represented, and are rejected.
+*/
+func MatRatFromMatf64(m *Matf64) *MatRat {
+	n := NewRat(m.r, m.c)
+	for i, x := range m.vals {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			s := "\nIn %s, the element at index %d is %v, which cannot be\n"
+			s += "represented.\n"
+			s = fmt.Sprintf(s, "MatRatFromMatf64()", i, x)
+			printErr(s)
+		}
+		n.vals[i].SetFloat64(x)
+	}
+	return n
+}
+
+/*
+MatRatFromStrings returns a MatRat holding the numbers in rows, which must
+all have the same length. Each number is parsed with big.Rat.SetString, so
+fractions such as "2/3" and decimals such as "0.1" are represented exactly.
+*/
+func MatRatFromStrings(rows [][]string) (*MatRat, error) {
+	c := 0
+	if len(rows) > 0 {
+		c = len(rows[0])
+	}
+	m := NewRat(len(rows), c)
+	for i, row := range rows {
+		if len(row) != c {
+			return nil, fmt.Errorf("In %s: row %d has %d elements, but row 0 has %d", "MatRatFromStrings()", i, len(row), c)
+		}
+		for j, x := range row {
+			if _, ok := m.vals[i*c+j].SetString(x); !ok {
+				return nil, fmt.Errorf("In %s: cannot parse %q at row %d and column %d", "MatRatFromStrings()", x, i, j)
+			}
+		}
+	}
+	return m, nil
+}
+
+/*
+Shape returns the number of rows and columns of the mat.
+*/
+func (m *MatRat) Shape() (int, int) {
+	return m.r, m.c
+}
+
+/*
+Get returns a copy of the element at row r and column c. Negative indices
+count from the end.
+*/
+func (m *MatRat) Get(r, c int) *big.Rat {
+	r = normIndex("Get()", "row", r, m.r)
+	c = normIndex("Get()", "column", c, m.c)
+	return new(big.Rat).Set(m.vals[r*m.c+c])
+}
+
+/*
+Set sets the element at row r and column c to x. Negative indices count from
+the end.
+*/
+func (m *MatRat) Set(r, c int, x *big.Rat) *MatRat {
+	r = normIndex("Set()", "row", r, m.r)
+	c = normIndex("Set()", "column", c, m.c)
+	m.vals[r*m.c+c].Set(x)
+	return m
+}
+
+/*
+ToMatf64 returns the values of the mat rounded to the nearest float64s.
+*/
+func (m *MatRat) ToMatf64() *Matf64 {
+	n := Newf64(m.r, m.c)
+	for i, x := range m.vals {
+		n.vals[i], _ = x.Float64()
+	}
+	return n
+}
+
+/*
+Copy returns a deep copy of the mat.
+*/
+func (m *MatRat) Copy() *MatRat {
+	n := &MatRat{r: m.r, c: m.c, vals: make([]*big.Rat, len(m.vals))}
+	for i, x := range m.vals {
+		n.vals[i] = new(big.Rat).Set(x)
+	}
+	return n
+}
+
+/*
+Equals reports whether two mats have the same shape and equal elements.
+*/
+func (m *MatRat) Equals(n *MatRat) bool {
+	if m.r != n.r || m.c != n.c {
+		return false
+	}
+	for i, x := range m.vals {
+		if x.Cmp(n.vals[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+T transposes the mat in place, and returns it.
+*/
+func (m *MatRat) T() *MatRat {
+	vals := make([]*big.Rat, len(m.vals))
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			vals[j*m.r+i] = m.vals[i*m.c+j]
+		}
+	}
+	m.r, m.c, m.vals = m.c, m.r, vals
+	return m
+}
+
+/*
+Add adds to each element of the receiver either a scalar, given as an int or
+a *big.Rat, or the corresponding element of a *MatRat of the same shape. The
+receiver is modified in place.
+*/
+func (m *MatRat) Add(scalarOrMatRat interface{}) *MatRat {
+	m.elementwise("Add()", scalarOrMatRat, (*big.Rat).Add)
+	return m
+}
+
+/*
+Sub subtracts from each element of the receiver either a scalar or the
+corresponding element of a *MatRat of the same shape, as in Add.
+*/
+func (m *MatRat) Sub(scalarOrMatRat interface{}) *MatRat {
+	m.elementwise("Sub()", scalarOrMatRat, (*big.Rat).Sub)
+	return m
+}
+
+/*
+Mul multiplies each element of the receiver by either a scalar or the
+corresponding element of a *MatRat of the same shape, as in Add. For the
+matrix product, see Dot.
+*/
+func (m *MatRat) Mul(scalarOrMatRat interface{}) *MatRat {
+	m.elementwise("Mul()", scalarOrMatRat, (*big.Rat).Mul)
+	return m
+}
+
+/*
+Div divides each element of the receiver by either a scalar or the
+corresponding element of a *MatRat of the same shape, as in Add. None of
+the divisors may be zero.
+*/
+func (m *MatRat) Div(scalarOrMatRat interface{}) *MatRat {
+	if v, ok := scalarOrMatRat.(*MatRat); ok {
+		for i, x := range v.vals {
+			if x.Sign() == 0 && i < len(m.vals) {
+				s := "\nIn %s, the element at index %d of the divisor is zero.\n"
+				s = fmt.Sprintf(s, "Div()", i)
+				printErr(s)
+			}
+		}
+	} else if y := ratScalar("Div()", scalarOrMatRat); y != nil && y.Sign() == 0 {
+		s := "\nIn %s, the divisor is zero.\n"
+		s = fmt.Sprintf(s, "Div()")
+		printErr(s)
+	}
+	m.elementwise("Div()", scalarOrMatRat, (*big.Rat).Quo)
+	return m
+}
+
+// ratScalar returns v as a *big.Rat if it is a scalar accepted by the
+// elementwise methods, and nil if it is a *MatRat.
+func ratScalar(fn string, v interface{}) *big.Rat {
+	switch v := v.(type) {
+	case int:
+		return big.NewRat(int64(v), 1)
+	case *big.Rat:
+		return v
+	case *MatRat:
+		return nil
+	}
+	s := "\nIn %s, the passed value must be an int, *big.Rat or *MatRat.\n"
+	s += "However, value of type \"%v\" was received.\n"
+	s = fmt.Sprintf(s, fn, reflect.TypeOf(v))
+	printHelperErr(s)
+	return nil
+}
+
+// elementwise sets each element x of m to op(x, y), where y is the scalar or
+// the corresponding element of the *MatRat v.
+func (m *MatRat) elementwise(fn string, v interface{}, op func(z, x, y *big.Rat) *big.Rat) {
+	if y := ratScalar(fn, v); y != nil {
+		for _, x := range m.vals {
+			op(x, x, y)
+		}
+		return
+	}
+	n := v.(*MatRat)
+	if n.r != m.r || n.c != m.c {
+		s := "\nIn %s, the receiver is %dX%d but the passed mat is %dX%d.\n"
+		s += "They must have the same shape.\n"
+		s = fmt.Sprintf(s, fn, m.r, m.c, n.r, n.c)
+		printHelperErr(s)
+	}
+	for i, x := range m.vals {
+		op(x, x, n.vals[i])
+	}
+}
+
+/*
+Dot returns the matrix product of m and n, as a new mat. The number of
+columns of m must equal the number of rows of n. Both mats are left intact.
+*/
+func (m *MatRat) Dot(n *MatRat) *MatRat {
+	if m.c != n.r {
+		s := "\nIn %s the number of columns of the first mat is %d\n"
+		s += "which is not equal to the number of rows of the second mat,\n"
+		s += "which is %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Dot()", m.c, n.r)
+		printErr(s)
+	}
+	o := NewRat(m.r, n.c)
+	p := new(big.Rat)
+	for i := 0; i < m.r; i++ {
+		for k := 0; k < m.c; k++ {
+			a := m.vals[i*m.c+k]
+			if a.Sign() == 0 {
+				continue
+			}
+			for j := 0; j < n.c; j++ {
+				p.Mul(a, n.vals[k*n.c+j])
+				o.vals[i*n.c+j].Add(o.vals[i*n.c+j], p)
+			}
+		}
+	}
+	return o
+}
+
+/*
+RREF returns the reduced row echelon form of the mat, obtained by
+Gauss-Jordan elimination, along with the rank of the mat. In the result,
+the first nonzero element of every nonzero row is 1 and is the only nonzero
+element of its column, and the zero rows are at the bottom. The mat is left
+intact.
+*/
+func (m *MatRat) RREF() (*MatRat, int) {
+	a := m.Copy()
+	return a, a.eliminate(a.c)
+}
+
+// eliminate reduces the mat in place to its reduced row echelon form, with
+// pivots only searched for in the first cols columns, and returns the number
+// of pivots. The other columns are transformed along, which solves systems
+// whose right hand sides are stored there.
+func (m *MatRat) eliminate(cols int) int {
+	t := new(big.Rat)
+	row := 0
+	for j := 0; j < cols && row < m.r; j++ {
+		p := row
+		for p < m.r && m.vals[p*m.c+j].Sign() == 0 {
+			p++
+		}
+		if p == m.r {
+			continue
+		}
+		if p != row {
+			for l := 0; l < m.c; l++ {
+				m.vals[row*m.c+l], m.vals[p*m.c+l] = m.vals[p*m.c+l], m.vals[row*m.c+l]
+			}
+		}
+		inv := new(big.Rat).Inv(m.vals[row*m.c+j])
+		for l := j; l < m.c; l++ {
+			m.vals[row*m.c+l].Mul(m.vals[row*m.c+l], inv)
+		}
+		for i := 0; i < m.r; i++ {
+			f := m.vals[i*m.c+j]
+			if i == row || f.Sign() == 0 {
+				continue
+			}
+			f = new(big.Rat).Set(f)
+			for l := j; l < m.c; l++ {
+				m.vals[i*m.c+l].Sub(m.vals[i*m.c+l], t.Mul(f, m.vals[row*m.c+l]))
+			}
+		}
+		row++
+	}
+	return row
+}
+
+/*
+Det returns the determinant of the square mat m, computed exactly by
+Gaussian elimination. The mat is left intact.
+*/
+func (m *MatRat) Det() *big.Rat {
+	if m.r != m.c {
+		s := "\nIn %s, the mat must be square, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, "Det()", m.r, m.c)
+		printErr(s)
+	}
+	n := m.r
+	a := m.Copy()
+	det := big.NewRat(1, 1)
+	f, t := new(big.Rat), new(big.Rat)
+	for j := 0; j < n; j++ {
+		p := j
+		for p < n && a.vals[p*n+j].Sign() == 0 {
+			p++
+		}
+		if p == n {
+			return new(big.Rat)
+		}
+		if p != j {
+			for l := 0; l < n; l++ {
+				a.vals[j*n+l], a.vals[p*n+l] = a.vals[p*n+l], a.vals[j*n+l]
+			}
+			det.Neg(det)
+		}
+		det.Mul(det, a.vals[j*n+j])
+		for i := j + 1; i < n; i++ {
+			if a.vals[i*n+j].Sign() == 0 {
+				continue
+			}
+			f.Quo(a.vals[i*n+j], a.vals[j*n+j])
+			for l := j; l < n; l++ {
+				a.vals[i*n+l].Sub(a.vals[i*n+l], t.Mul(f, a.vals[j*n+l]))
+			}
+		}
+	}
+	return det
+}
+
+/*
+Solve returns the exact solution x of m * x = b, where m is square. b may
+have several columns, each of which is solved for. An error is returned if
+m is singular. Both mats are left intact.
+*/
+func (m *MatRat) Solve(b *MatRat) (*MatRat, error) {
+	if m.r != m.c || b.r != m.r {
+		s := "\nIn %s, the mat must be square and have as many rows as the\n"
+		s += "right hand side, but they are %dX%d and %dX%d.\n"
+		s = fmt.Sprintf(s, "Solve()", m.r, m.c, b.r, b.c)
+		printErr(s)
+	}
+	return m.solve("Solve()", b)
+}
+
+/*
+Inverse returns the exact inverse of the square mat m, or an error if m is
+singular. The mat is left intact.
+*/
+func (m *MatRat) Inverse() (*MatRat, error) {
+	if m.r != m.c {
+		s := "\nIn %s, the mat must be square, but it is %dX%d.\n"
+		s = fmt.Sprintf(s, "Inverse()", m.r, m.c)
+		printErr(s)
+	}
+	return m.solve("Inverse()", IdentityRat(m.r))
+}
+
+// solve reduces [m b] to [I x] and returns x, or an error naming fn if m is
+// singular.
+func (m *MatRat) solve(fn string, b *MatRat) (*MatRat, error) {
+	n, k := m.r, b.c
+	a := NewRat(n, n+k)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.vals[i*a.c+j].Set(m.vals[i*n+j])
+		}
+		for j := 0; j < k; j++ {
+			a.vals[i*a.c+n+j].Set(b.vals[i*k+j])
+		}
+	}
+	if a.eliminate(n) < n {
+		return nil, fmt.Errorf("In %s: the mat is singular", fn)
+	}
+	x := &MatRat{r: n, c: k, vals: make([]*big.Rat, 0, n*k)}
+	for i := 0; i < n; i++ {
+		x.vals = append(x.vals, a.vals[i*a.c+n:(i+1)*a.c]...)
+	}
+	return x, nil
+}
+
+/*
+String returns the elements of the mat as fractions, or as integers when
+their denominator is 1, one row per line, separated by spaces.
+*/
+func (m *MatRat) String() string {
+	var b bytes.Buffer
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(m.vals[i*m.c+j].RatString())
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package matrix
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatRat(t *testing.T) {
+	t.Helper()
+	m, err := MatRatFromStrings([][]string{{"1/2", "2"}, {"0.1", "4"}})
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, "1/2 2\n1/10 4\n", m.String(), "should be equal")
+	assert.True(t, m.Copy().Dot(IdentityRat(2)).Equals(m), "should be equal")
+	o := m.Copy().Add(1).Sub(IdentityRat(2)).Mul(big.NewRat(2, 3)).Div(2)
+	assert.Equal(t, "1/6 1\n11/30 4/3\n", o.String(), "should be equal")
+	assert.Equal(t, "1/2 1/10\n2 4\n", m.Copy().T().String(), "should be equal")
+	assert.Equal(t, big.NewRat(1, 10), m.Get(-1, 0), "should be equal")
+	assert.Equal(t, "3602879701896397/36028797018963968", MatRatFromMatf64(Matf64FromData([]float64{0.1}, 1, 1)).String()[:34], "should be exact")
+	assert.Equal(t, 0.1, m.ToMatf64().Get(1, 0), "should be equal")
+
+	_, err = MatRatFromStrings([][]string{{"1", "x"}})
+	assert.EqualError(t, err, `In MatRatFromStrings(): cannot parse "x" at row 0 and column 1`)
+}
+
+func TestDetRat(t *testing.T) {
+	t.Helper()
+	// The determinant of the nXn Hilbert mat is the inverse of an integer.
+	n := 6
+	h := NewRat(n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			h.vals[i*n+j].SetFrac64(1, int64(i+j+1))
+		}
+	}
+	want, _ := new(big.Rat).SetString("1/186313420339200000")
+	assert.Equal(t, 0, want.Cmp(h.Det()), "should be equal")
+
+	inv, err := h.Inverse()
+	assert.Nil(t, err, "should not fail")
+	assert.True(t, h.Dot(inv).Equals(IdentityRat(n)), "should be exact")
+	assert.Equal(t, "36", inv.Get(0, 0).RatString(), "should be equal")
+
+	m, _ := MatRatFromStrings([][]string{{"0", "1"}, {"1", "0"}})
+	assert.Equal(t, "-1", m.Det().RatString(), "should be equal")
+}
+
+func TestSolveRat(t *testing.T) {
+	t.Helper()
+	m, _ := MatRatFromStrings([][]string{{"2", "1", "-1"}, {"-3", "-1", "2"}, {"-2", "1", "2"}})
+	b, _ := MatRatFromStrings([][]string{{"8"}, {"-11"}, {"-3"}})
+	x, err := m.Solve(b)
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, "2\n3\n-1\n", x.String(), "should be equal")
+
+	s, _ := MatRatFromStrings([][]string{{"1", "2", "3"}, {"2", "4", "6"}, {"1", "0", "1"}})
+	_, err = s.Solve(b)
+	assert.EqualError(t, err, "In Solve(): the mat is singular")
+	_, err = s.Inverse()
+	assert.EqualError(t, err, "In Inverse(): the mat is singular")
+	assert.Equal(t, "0", s.Det().RatString(), "should be equal")
+	r, rank := s.RREF()
+	assert.Equal(t, 2, rank, "should be equal")
+	assert.Equal(t, "1 0 1\n0 1 1\n0 0 0\n", r.String(), "should be equal")
+}
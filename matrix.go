@@ -15,3 +15,6 @@ which the error was encountered is printed to the screen, in addition
 to the full stack trace, in order to help fix the issue rapidly.
 */
 package matrix
+
+// maxInt is the largest value held by an int on the current platform.
+const maxInt = int(^uint(0) >> 1)
@@ -0,0 +1,173 @@
+/*
+Package matrixtest provides helpers for testing code built on the matrix
+package: comparisons of mats within a tolerance, shape assertions, golden
+file comparisons, and random mats for property based tests. For example:
+
+	func TestSolve(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < 100; i++ {
+			a := matrixtest.RandSquare(rng, 20)
+			n, _ := a.Shape()
+			x := matrixtest.RandMat(rng, n, 3)
+			matrixtest.AssertEqual(t, x, solve(a, a.Dot(x)), 1e-9)
+		}
+	}
+*/
+package matrixtest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gocrunch/matrix"
+)
+
+// maxListed is the number of differing values listed by AssertEqual.
+const maxListed = 10
+
+/*
+AssertEqual reports an error through t unless want and got have the same
+shape, and every value of got is within tol of the corresponding value of
+want. NaNs are considered equal to each other, and infinite values must be
+equal. The error lists the first differing values along with their row and
+column. It returns whether the mats are equal, so that a test can stop when
+they are not.
+*/
+func AssertEqual(t testing.TB, want, got *matrix.Matf64, tol float64) bool {
+	t.Helper()
+	r, c := want.Shape()
+	if !AssertShape(t, got, r, c) {
+		return false
+	}
+	w, g := want.ToSlice1D(), got.ToSlice1D()
+	var b bytes.Buffer
+	n := 0
+	for i := range w {
+		if within(w[i], g[i], tol) {
+			continue
+		}
+		if n < maxListed {
+			fmt.Fprintf(&b, "\n\tat (%d, %d): want %v, got %v", i/c, i%c, w[i], g[i])
+		}
+		n++
+	}
+	if n == 0 {
+		return true
+	}
+	if n > maxListed {
+		fmt.Fprintf(&b, "\n\tand %d more", n-maxListed)
+	}
+	t.Errorf("%d values differ by more than %v:%s", n, tol, b.String())
+	return false
+}
+
+func within(a, b, tol float64) bool {
+	switch {
+	case math.IsNaN(a) || math.IsNaN(b):
+		return math.IsNaN(a) && math.IsNaN(b)
+	case math.IsInf(a, 0) || math.IsInf(b, 0):
+		return a == b
+	}
+	return math.Abs(a-b) <= tol
+}
+
+/*
+AssertShape reports an error through t unless m has r rows and c columns,
+and returns whether it does.
+*/
+func AssertShape(t testing.TB, m *matrix.Matf64, r, c int) bool {
+	t.Helper()
+	mr, mc := m.Shape()
+	if mr != r || mc != c {
+		t.Errorf("the mat is %dX%d, but %dX%d was expected", mr, mc, r, c)
+		return false
+	}
+	return true
+}
+
+/*
+AssertGolden compares got, within tol, to the mat stored in the CSV file at
+path, typically under the testdata directory of a package. When the
+UPDATE_GOLDEN environment variable is set, the file is written with the
+values of got instead, so that golden files can be created or refreshed
+with:
+
+	UPDATE_GOLDEN=1 go test ./...
+
+If the columns of got are named, the file has a header, and the columns are
+read back by name.
+*/
+func AssertGolden(t testing.TB, got *matrix.Matf64, path string, tol float64) bool {
+	t.Helper()
+	if os.Getenv("UPDATE_GOLDEN") != "" {
+		got.ToCSV(path)
+		return true
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Errorf("cannot open the golden file: %v, set UPDATE_GOLDEN to create it", err)
+		return false
+	}
+	defer f.Close()
+	var cols []interface{}
+	for _, name := range got.ColNames() {
+		cols = append(cols, name)
+	}
+	want := matrix.Matf64FromCSVReader(f, cols...)
+	return AssertEqual(t, want, got, tol)
+}
+
+/*
+RandMat returns an r by c mat whose values are drawn from the standard
+normal distribution using rng.
+*/
+func RandMat(rng *rand.Rand, r, c int) *matrix.Matf64 {
+	return matrix.RandnMatf64With(rng, r, c)
+}
+
+/*
+RandSquare returns a square mat with between 1 and maxN rows, whose values
+are drawn from the standard normal distribution using rng.
+*/
+func RandSquare(rng *rand.Rand, maxN int) *matrix.Matf64 {
+	n := 1 + rng.Intn(maxN)
+	return RandMat(rng, n, n)
+}
+
+/*
+RandShape returns a mat with between 1 and maxR rows and between 1 and maxC
+columns, whose values are drawn from the standard normal distribution using
+rng.
+*/
+func RandShape(rng *rand.Rand, maxR, maxC int) *matrix.Matf64 {
+	return RandMat(rng, 1+rng.Intn(maxR), 1+rng.Intn(maxC))
+}
+
+/*
+Mat wraps a Matf64 so that random mats can be generated by testing/quick:
+
+	f := func(a matrixtest.Mat) bool {
+		m := a.Matf64
+		return m.Copy().T().T().Equals(m)
+	}
+	err := quick.Check(f, nil)
+
+The mats have between 1 and size rows and columns, where size is the size
+hint given by testing/quick, and standard normal values.
+*/
+type Mat struct {
+	*matrix.Matf64
+}
+
+// Generate implements the quick.Generator interface.
+func (Mat) Generate(rng *rand.Rand, size int) reflect.Value {
+	if size < 1 {
+		size = 1
+	}
+	return reflect.ValueOf(Mat{RandShape(rng, size, size)})
+}
@@ -0,0 +1,83 @@
+package matrixtest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/gocrunch/matrix"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records the errors reported by the assertions.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertEqual(t *testing.T) {
+	t.Helper()
+	want := matrix.Matf64FromData([][]float64{{1, math.NaN()}, {math.Inf(1), 4}})
+	got := matrix.Matf64FromData([][]float64{{1.05, math.NaN()}, {math.Inf(1), 4}})
+	r := &recorder{TB: t}
+	assert.True(t, AssertEqual(r, want, got, 0.1), "should be equal")
+	assert.False(t, AssertEqual(r, want, got, 0.01), "should differ")
+	assert.Equal(t, 1, len(r.errors), "should be equal")
+	assert.True(t, strings.Contains(r.errors[0], "at (0, 0): want 1, got 1.05"), r.errors[0])
+
+	r.errors = nil
+	assert.False(t, AssertEqual(r, want, matrix.Newf64(2, 3), 1), "should differ")
+	assert.Equal(t, []string{"the mat is 2X3, but 2X2 was expected"}, r.errors, "should be equal")
+
+	r.errors = nil
+	assert.False(t, AssertEqual(r, matrix.Newf64(4, 4), matrix.Onesf64(4, 4), 0), "should differ")
+	assert.True(t, strings.HasSuffix(r.errors[0], "and 6 more"), r.errors[0])
+}
+
+func TestAssertGolden(t *testing.T) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "golden")
+	assert.Nil(t, err, "should not fail")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "m.csv")
+	m := matrix.Matf64FromData([][]float64{{1, 2}, {3, 4}}).SetColNames([]string{"a", "b"})
+
+	r := &recorder{TB: t}
+	assert.False(t, AssertGolden(r, m, path, 0), "should fail without a file")
+	os.Setenv("UPDATE_GOLDEN", "1")
+	assert.True(t, AssertGolden(r, m, path, 0), "should write the file")
+	os.Unsetenv("UPDATE_GOLDEN")
+	assert.True(t, AssertGolden(r, m, path, 0), "should be equal")
+	assert.False(t, AssertGolden(r, m.Copy().Set(0, 0, 5), path, 0), "should differ")
+}
+
+func TestRandMat(t *testing.T) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		m := RandSquare(rng, 5)
+		r, c := m.Shape()
+		assert.Equal(t, r, c, "should be square")
+		assert.True(t, r >= 1 && r <= 5, "should be within bounds")
+		m = RandShape(rng, 3, 7)
+		r, c = m.Shape()
+		assert.True(t, r >= 1 && r <= 3 && c >= 1 && c <= 7, "should be within bounds")
+	}
+	f := func(a Mat) bool {
+		m := a.Matf64
+		return m.Copy().T().T().Equals(m)
+	}
+	assert.Nil(t, quick.Check(f, nil), "should hold")
+}
@@ -0,0 +1,57 @@
+package matrix
+
+import (
+	"sort"
+)
+
+/*
+SetMeta attaches a piece of metadata to the mat, such as its source file, its
+units or the time it was created, so that pipelines can tell where a mat
+came from:
+
+	m := matrix.Matf64FromCSV("survey.csv", "height")
+	m.SetMeta("source", "survey.csv").SetMeta("units", "cm")
+
+The metadata is kept by Copy and CloneCOW, is encoded by MarshalJSON and
+ToProto, and is read back by UnmarshalJSON and Matf64FromProto. Setting a
+key to the empty string removes it.
+*/
+func (m *Matf64) SetMeta(key, value string) *Matf64 {
+	// The map is replaced rather than modified, since it is shared with the
+	// copies of m.
+	meta := make(map[string]string, len(m.meta)+1)
+	for k, v := range m.meta {
+		meta[k] = v
+	}
+	if value == "" {
+		delete(meta, key)
+	} else {
+		meta[key] = value
+	}
+	if len(meta) == 0 {
+		meta = nil
+	}
+	m.meta = meta
+	return m
+}
+
+/*
+Meta returns the metadata attached to the mat under the given key, or the
+empty string if there is none.
+*/
+func (m *Matf64) Meta(key string) string {
+	return m.meta[key]
+}
+
+/*
+MetaKeys returns the keys of the metadata attached to the mat, in sorted
+order.
+*/
+func (m *Matf64) MetaKeys() []string {
+	keys := make([]string, 0, len(m.meta))
+	for k := range m.meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
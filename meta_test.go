@@ -0,0 +1,44 @@
+package matrix
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(2, 2)
+	assert.Equal(t, "", m.Meta("source"), "should be empty")
+	m.SetMeta("source", "survey.csv").SetMeta("units", "cm")
+	assert.Equal(t, "survey.csv", m.Meta("source"), "should be equal")
+	assert.Equal(t, []string{"source", "units"}, m.MetaKeys(), "should be sorted")
+
+	n := m.Copy()
+	c := m.CloneCOW()
+	n.SetMeta("units", "m")
+	assert.Equal(t, "cm", m.Meta("units"), "should not be shared")
+	assert.Equal(t, "cm", c.Meta("units"), "should be kept by CloneCOW")
+	assert.Equal(t, "m", n.Meta("units"), "should be equal")
+
+	m.SetMeta("units", "")
+	assert.Equal(t, []string{"source"}, m.MetaKeys(), "should be removed")
+}
+
+func TestMetaSerializationf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}}).SetMeta("source", "a.csv").SetMeta("units", "kg")
+
+	b, err := json.Marshal(m)
+	assert.Nil(t, err, "should not fail")
+	n := Newf64()
+	assert.Nil(t, json.Unmarshal(b, n), "should not fail")
+	assert.Equal(t, "a.csv", n.Meta("source"), "should be equal")
+	assert.Equal(t, "kg", n.Meta("units"), "should be equal")
+
+	n = Matf64FromProto(m.ToProto())
+	assert.True(t, m.Equals(n), "should be equal")
+	assert.Equal(t, []string{"source", "units"}, n.MetaKeys(), "should be equal")
+	assert.Equal(t, "kg", n.Meta("units"), "should be equal")
+}
@@ -0,0 +1,201 @@
+package matrix
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+/*
+WritePGM writes the mat to w as a grayscale Netpbm image (PGM), where each
+element is the intensity of a pixel, following the same rules as ToGray:
+0.0 is black, 1.0 is white, values outside of this range are clipped, and
+NaNs are black. The image is written with 16 bits per pixel, so any mat read
+with Matf64FromNetpbm is written back without loss. If plain is true, the
+values are written as text (format P2), otherwise they are written in binary
+(format P5), which is much more compact.
+*/
+func (m *Matf64) WritePGM(w io.Writer, plain bool) {
+	writeNetpbm(w, "WritePGM()", plain, m.r, m.c, m)
+}
+
+/*
+WritePPM writes a color Netpbm image (PPM) to w, whose red, green and blue
+channels are given by three mats of the same shape. The channels follow the
+same rules as in WritePGM. If plain is true, the values are written as text
+(format P3), otherwise they are written in binary (format P6).
+*/
+func WritePPM(w io.Writer, red, green, blue *Matf64, plain bool) {
+	if red.r != green.r || red.r != blue.r || red.c != green.c || red.c != blue.c {
+		s := "\nIn matrix.%s, the three channels must have the same shape, but\n"
+		s += "they are %dx%d, %dx%d and %dx%d.\n"
+		s = fmt.Sprintf(s, "WritePPM()", red.r, red.c, green.r, green.c, blue.r, blue.c)
+		printErr(s)
+	}
+	writeNetpbm(w, "WritePPM()", plain, red.r, red.c, red, green, blue)
+}
+
+func writeNetpbm(w io.Writer, fn string, plain bool, r, c int, channels ...*Matf64) {
+	magic := map[bool]map[int]string{
+		true:  {1: "P2", 3: "P3"},
+		false: {1: "P5", 3: "P6"},
+	}[plain][len(channels)]
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "%s\n%d %d\n%d\n", magic, c, r, math.MaxUint16)
+	for i := 0; i < r*c; i++ {
+		for k, ch := range channels {
+			v := uint16(math.Floor(intensity(ch.vals[i])*math.MaxUint16 + 0.5))
+			if !plain {
+				b.Write([]byte{byte(v >> 8), byte(v)})
+				continue
+			}
+			if k != 0 || i%c != 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strconv.Itoa(int(v)))
+			if k == len(channels)-1 && i%c == c-1 {
+				b.WriteByte('\n')
+			}
+		}
+	}
+	if err := b.Flush(); err != nil {
+		s := "\nIn %s, cannot write the image due to error: %v.\n"
+		s = fmt.Sprintf(s, fn, err)
+		printHelperErr(s)
+	}
+}
+
+/*
+Matf64FromNetpbm reads a grayscale (PGM) or color (PPM) Netpbm image from r,
+in either the plain (P2, P3) or binary (P5, P6) format, and returns the
+intensity of its pixels, scaled between 0.0 and 1.0 by the maximum value of
+the image. Colored images are converted to grayscale in the same way as
+color.Gray16Model. The mat has one row per line of pixels, and one column
+per pixel of each line.
+*/
+func Matf64FromNetpbm(r io.Reader) *Matf64 {
+	m, err := readNetpbm(bufio.NewReader(r))
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read the image due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromNetpbm()", err)
+		printErr(s)
+	}
+	return m
+}
+
+func readNetpbm(b *bufio.Reader) (*Matf64, error) {
+	magic, err := netpbmToken(b)
+	if err != nil {
+		return nil, err
+	}
+	var plain bool
+	var channels int
+	switch magic {
+	case "P2":
+		plain, channels = true, 1
+	case "P3":
+		plain, channels = true, 3
+	case "P5":
+		plain, channels = false, 1
+	case "P6":
+		plain, channels = false, 3
+	default:
+		return nil, fmt.Errorf("unsupported Netpbm format %q", magic)
+	}
+	var header [3]int
+	for i := range header {
+		tok, err := netpbmToken(b)
+		if err != nil {
+			return nil, err
+		}
+		header[i], err = strconv.Atoi(tok)
+		if err != nil || header[i] < 0 {
+			return nil, fmt.Errorf("invalid header value %q", tok)
+		}
+	}
+	c, r, maxVal := header[0], header[1], header[2]
+	if maxVal == 0 || maxVal > math.MaxUint16 {
+		return nil, fmt.Errorf("invalid maximum value %d", maxVal)
+	}
+	if c != 0 && r > maxInt/c {
+		return nil, fmt.Errorf("invalid size %dX%d", c, r)
+	}
+	// The values are grown as pixels are read, so that a forged header does
+	// not allocate more memory than the data it is followed by.
+	n := r * c
+	vals := make([]float64, 0, binaryChunk)
+	if n < binaryChunk {
+		vals = make([]float64, 0, n)
+	}
+	var sample [3]float64
+	for i := 0; i < n; i++ {
+		for k := 0; k < channels; k++ {
+			var v int
+			switch {
+			case plain:
+				tok, err := netpbmToken(b)
+				if err != nil {
+					return nil, err
+				}
+				if v, err = strconv.Atoi(tok); err != nil {
+					return nil, fmt.Errorf("invalid value %q", tok)
+				}
+			case maxVal < 256:
+				x, err := b.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				v = int(x)
+			default:
+				var x [2]byte
+				if _, err := io.ReadFull(b, x[:]); err != nil {
+					return nil, err
+				}
+				v = int(x[0])<<8 | int(x[1])
+			}
+			if v > maxVal {
+				return nil, fmt.Errorf("value %d is larger than the maximum value %d", v, maxVal)
+			}
+			sample[k] = float64(v) / float64(maxVal)
+		}
+		if channels == 1 {
+			vals = append(vals, sample[0])
+		} else {
+			// Same weights as color.Gray16Model.
+			vals = append(vals, (19595*sample[0]+38470*sample[1]+7471*sample[2])/65536)
+		}
+	}
+	m := Newf64()
+	m.r, m.c, m.vals = r, c, vals
+	return m, nil
+}
+
+// netpbmToken returns the next whitespace separated token of a Netpbm header
+// or plain raster, skipping comments. The whitespace that ends the token is
+// consumed, which is the single byte separating a header from a binary raster.
+func netpbmToken(b *bufio.Reader) (string, error) {
+	var tok []byte
+	for {
+		x, err := b.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(tok) != 0 {
+				return string(tok), nil
+			}
+			return "", err
+		}
+		switch {
+		case x == '#' && len(tok) == 0:
+			if _, err = b.ReadString('\n'); err != nil {
+				return "", err
+			}
+		case x == ' ' || x == '\t' || x == '\n' || x == '\r' || x == '\v' || x == '\f':
+			if len(tok) != 0 {
+				return string(tok), nil
+			}
+		default:
+			tok = append(tok, x)
+		}
+	}
+}
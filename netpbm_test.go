@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetpbmf64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(9, 4)
+	m.Map(func(v *float64) {
+		*v = math.Floor(*v*math.MaxUint16+0.5) / math.MaxUint16
+	})
+	for _, plain := range []bool{true, false} {
+		var b bytes.Buffer
+		m.WritePGM(&b, plain)
+		assert.True(t, m.Equals(Matf64FromNetpbm(&b)), "should be lossless")
+	}
+	var b bytes.Buffer
+	one := Newf64(9, 4).SetAll(1.0)
+	WritePPM(&b, one, one, one, true)
+	assert.True(t, strings.HasPrefix(b.String(), "P3\n4 9\n65535\n"), "should be equal")
+	assert.True(t, one.Equals(Matf64FromNetpbm(&b)), "white should stay white")
+}
+
+func TestMatf64FromNetpbm(t *testing.T) {
+	t.Helper()
+	pgm := "P2\n# a comment\n3 2\n# another\n4\n0 1 2\n3 4 0\n"
+	m := Matf64FromNetpbm(strings.NewReader(pgm))
+	want := Matf64FromData([][]float64{{0, 0.25, 0.5}, {0.75, 1, 0}})
+	assert.True(t, want.Equals(m), "should be equal")
+
+	ppm := "P6 2 1 255\n" + string([]byte{255, 0, 0, 0, 0, 0})
+	m = Matf64FromNetpbm(strings.NewReader(ppm))
+	assert.InDelta(t, 19595.0/65536, m.Get(0, 0), 1e-12, "should be the luminance of red")
+	assert.Equal(t, 0.0, m.Get(0, 1), "should be black")
+}
+
+func TestNetpbmForgedSize(t *testing.T) {
+	t.Helper()
+	_, err := readNetpbm(bufio.NewReader(strings.NewReader("P5 1000000000 1000000000 255\n\x01\x02")))
+	assert.Equal(t, io.EOF, err, "should stop at the end of the data")
+	_, err = readNetpbm(bufio.NewReader(strings.NewReader("P5 9223372036854775807 3 255\n")))
+	assert.NotNil(t, err, "should fail on an overflowing size")
+}
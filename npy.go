@@ -0,0 +1,322 @@
+package matrix
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// npyMagic is the prefix of every file in numpy's .npy format.
+const npyMagic = "\x93NUMPY"
+
+// npyMaxHeader is the largest .npy header accepted by readNpy. Real headers
+// take less than a hundred bytes.
+const npyMaxHeader = 1 << 16
+
+// writeNpy writes m to w in version 1.0 of the .npy format, as a 2D array
+// of little endian float64s in C (row-major) order.
+func writeNpy(w io.Writer, m *Matf64) error {
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': (%d, %d), }", m.r, m.c)
+	// The header is padded with spaces and terminated by a newline so that
+	// the data starts at a multiple of 64 bytes.
+	pad := 64 - (len(npyMagic)+4+len(header)+1)%64
+	if pad == 64 {
+		pad = 0
+	}
+	header += strings.Repeat(" ", pad) + "\n"
+	var b bytes.Buffer
+	b.WriteString(npyMagic)
+	b.Write([]byte{1, 0})
+	binary.Write(&b, binary.LittleEndian, uint16(len(header)))
+	b.WriteString(header)
+	if _, err := w.Write(b.Bytes()); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.LittleEndian, m.vals)
+}
+
+// readNpy reads a 0, 1 or 2 dimensional array in the .npy format from r.
+// 1D arrays become row vectors. Floats and integers of any size and
+// endianness are supported, and converted to float64.
+func readNpy(r io.Reader) (*Matf64, error) {
+	pre := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, pre); err != nil {
+		return nil, err
+	}
+	if string(pre[:len(npyMagic)]) != npyMagic {
+		return nil, fmt.Errorf("not a .npy file")
+	}
+	var hlen int
+	switch pre[len(npyMagic)] {
+	case 1:
+		var n uint16
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		hlen = int(n)
+	case 2, 3:
+		var n uint32
+		if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+			return nil, err
+		}
+		hlen = int(n)
+	default:
+		return nil, fmt.Errorf("unsupported .npy version %d", pre[len(npyMagic)])
+	}
+	if hlen > npyMaxHeader {
+		return nil, fmt.Errorf("the .npy header length %d exceeds %d bytes", hlen, npyMaxHeader)
+	}
+	header := make([]byte, hlen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	descr, fortran, shape, err := parseNpyHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if descr[0] == '>' {
+		order = binary.BigEndian
+	}
+	size, err := strconv.Atoi(descr[2:])
+	if err != nil {
+		return nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+	var conv func([]byte) float64
+	switch descr[1:] {
+	case "f8":
+		conv = func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }
+	case "f4":
+		conv = func(b []byte) float64 { return float64(math.Float32frombits(order.Uint32(b))) }
+	case "i8":
+		conv = func(b []byte) float64 { return float64(int64(order.Uint64(b))) }
+	case "i4":
+		conv = func(b []byte) float64 { return float64(int32(order.Uint32(b))) }
+	case "i2":
+		conv = func(b []byte) float64 { return float64(int16(order.Uint16(b))) }
+	case "i1":
+		conv = func(b []byte) float64 { return float64(int8(b[0])) }
+	case "u8":
+		conv = func(b []byte) float64 { return float64(order.Uint64(b)) }
+	case "u4":
+		conv = func(b []byte) float64 { return float64(order.Uint32(b)) }
+	case "u2":
+		conv = func(b []byte) float64 { return float64(order.Uint16(b)) }
+	case "u1", "b1":
+		conv = func(b []byte) float64 { return float64(b[0]) }
+	default:
+		return nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+	m := Newf64()
+	switch len(shape) {
+	case 0:
+		m.r, m.c = 1, 1
+	case 1:
+		m.r, m.c = 1, shape[0]
+	case 2:
+		m.r, m.c = shape[0], shape[1]
+	default:
+		return nil, fmt.Errorf("cannot load a %d dimensional array", len(shape))
+	}
+	if m.c != 0 && m.r > maxInt/size/m.c {
+		return nil, fmt.Errorf("invalid shape %v", shape)
+	}
+	// The values are read a chunk at a time and appended, so that a forged
+	// shape cannot make the reader allocate more than the data holds.
+	n := m.r * m.c
+	capacity := n
+	if capacity > binaryChunk {
+		capacity = binaryChunk
+	}
+	m.vals = make([]float64, 0, capacity)
+	data := make([]byte, binaryChunk*size)
+	for i := 0; i < n; i += binaryChunk {
+		k := n - i
+		if k > binaryChunk {
+			k = binaryChunk
+		}
+		if _, err := io.ReadFull(r, data[:k*size]); err != nil {
+			return nil, err
+		}
+		for j := 0; j < k; j++ {
+			m.vals = append(m.vals, conv(data[j*size:]))
+		}
+	}
+	if fortran {
+		// The data is in column-major order, which is the row-major order of
+		// the transpose.
+		m.r, m.c = m.c, m.r
+		m.T()
+	}
+	return m, nil
+}
+
+// parseNpyHeader extracts the fields of a .npy header, which is the repr of
+// a python dict such as {'descr': '<f8', 'fortran_order': False, 'shape': (3, 4), }
+func parseNpyHeader(h string) (descr string, fortran bool, shape []int, err error) {
+	field := func(key string) (string, bool) {
+		i := strings.Index(h, "'"+key+"'")
+		if i < 0 {
+			return "", false
+		}
+		rest := strings.TrimSpace(h[i+len(key)+2:])
+		if !strings.HasPrefix(rest, ":") {
+			return "", false
+		}
+		return strings.TrimSpace(rest[1:]), true
+	}
+	v, ok := field("descr")
+	if !ok || len(v) < 2 || v[0] != '\'' {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q", h)
+	}
+	end := strings.Index(v[1:], "'")
+	if end < 0 {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q", h)
+	}
+	descr = v[1 : 1+end]
+	if len(descr) < 3 || !strings.ContainsAny(descr[:1], "<>|=") {
+		return "", false, nil, fmt.Errorf("unsupported dtype %q", descr)
+	}
+	if descr[0] == '|' || descr[0] == '=' {
+		descr = "<" + descr[1:]
+	}
+	v, ok = field("fortran_order")
+	if !ok {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q", h)
+	}
+	fortran = strings.HasPrefix(v, "True")
+	v, ok = field("shape")
+	end = strings.Index(v, ")")
+	if !ok || !strings.HasPrefix(v, "(") || end < 0 {
+		return "", false, nil, fmt.Errorf("invalid .npy header %q", h)
+	}
+	for _, d := range strings.Split(v[1:end], ",") {
+		d = strings.TrimSpace(d)
+		if d == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(d, "L"))
+		if err != nil || n < 0 || n > math.MaxInt32 {
+			return "", false, nil, fmt.Errorf("invalid shape in .npy header %q", h)
+		}
+		shape = append(shape, n)
+	}
+	return descr, fortran, shape, nil
+}
+
+/*
+WriteNpy writes the mat to w in numpy's .npy format, as a 2D array of little
+endian float64s. The result can be loaded in python with numpy.load. The mat
+can be read back with ReadNpy:
+
+	m.WriteNpy(f)
+	n := matrix.Newf64().ReadNpy(f)
+*/
+func (m *Matf64) WriteNpy(w io.Writer) {
+	if err := writeNpy(w, m); err != nil {
+		s := "\nIn %s, cannot write the mat due to error: %v.\n"
+		s = fmt.Sprintf(s, "WriteNpy()", err)
+		printErr(s)
+	}
+}
+
+/*
+ReadNpy reads a 0, 1 or 2 dimensional array in numpy's .npy format from r,
+and stores it in the receiver, replacing its shape and values. 1D arrays are
+loaded as row vectors, and all supported numerical types are converted to
+float64.
+*/
+func (m *Matf64) ReadNpy(r io.Reader) *Matf64 {
+	n, err := readNpy(r)
+	if err != nil {
+		s := "\nIn %s, cannot read the mat due to error: %v.\n"
+		s = fmt.Sprintf(s, "ReadNpy()", err)
+		printErr(s)
+	}
+	*m = *n
+	return m
+}
+
+/*
+SaveNpz saves several named mats into a single compressed archive, in the
+.npz format used by numpy.savez_compressed. For example:
+
+	matrix.SaveNpz("model.npz", map[string]*matrix.Matf64{
+		"weights": w,
+		"biases":  b,
+	})
+
+can be loaded in python with:
+
+	data = numpy.load("model.npz")
+	w, b = data["weights"], data["biases"]
+*/
+func SaveNpz(path string, mats map[string]*Matf64) {
+	var b bytes.Buffer
+	z := zip.NewWriter(&b)
+	names := make([]string, 0, len(mats))
+	for name := range mats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var err error
+	for _, name := range names {
+		var f io.Writer
+		f, err = z.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Deflate})
+		if err == nil {
+			err = writeNpy(f, mats[name])
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = z.Close()
+	}
+	if err == nil {
+		err = ioutil.WriteFile(path, b.Bytes(), 0644)
+	}
+	if err != nil {
+		s := "\nIn matrix.%s, cannot save %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "SaveNpz()", path, err)
+		printErr(s)
+	}
+}
+
+/*
+LoadNpz loads all the arrays stored in a .npz archive, such as the ones
+created by SaveNpz, numpy.savez or numpy.savez_compressed. The returned map
+is keyed by the names of the arrays. 1D arrays are loaded as row vectors, and
+all supported numerical types are converted to float64.
+*/
+func LoadNpz(path string) map[string]*Matf64 {
+	z, err := zip.OpenReader(path)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot open %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "LoadNpz()", path, err)
+		printErr(s)
+	}
+	defer z.Close()
+	mats := make(map[string]*Matf64)
+	for _, f := range z.File {
+		var rc io.ReadCloser
+		rc, err = f.Open()
+		if err == nil {
+			mats[strings.TrimSuffix(f.Name, ".npy")], err = readNpy(rc)
+			rc.Close()
+		}
+		if err != nil {
+			s := "\nIn matrix.%s, cannot read %s from %s due to error: %v.\n"
+			s = fmt.Sprintf(s, "LoadNpz()", f.Name, path, err)
+			printErr(s)
+		}
+	}
+	return mats
+}
@@ -0,0 +1,86 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNpz(t *testing.T) {
+	t.Helper()
+	filename := "npz_test.npz"
+	w := RandMatf64(13, 7)
+	b := RandMatf64(1, 7)
+	SaveNpz(filename, map[string]*Matf64{"weights": w, "biases": b})
+	defer os.Remove(filename)
+	mats := LoadNpz(filename)
+	assert.Equal(t, 2, len(mats), "should be equal")
+	assert.True(t, w.Equals(mats["weights"]), "should be equal")
+	assert.True(t, b.Equals(mats["biases"]), "should be equal")
+}
+
+func TestReadNpy(t *testing.T) {
+	t.Helper()
+	// numpy.save(f, numpy.array([[1, 2, 3], [4, 5, 6]], dtype='<i4', order='F'))
+	header := "{'descr': '<i4', 'fortran_order': True, 'shape': (2, 3), }"
+	for len(header)%16 != 5 {
+		header += " "
+	}
+	header += "\n"
+	var b bytes.Buffer
+	b.WriteString("\x93NUMPY\x01\x00")
+	b.Write([]byte{byte(len(header)), 0})
+	b.WriteString(header)
+	for _, v := range []byte{1, 4, 2, 5, 3, 6} {
+		b.Write([]byte{v, 0, 0, 0})
+	}
+	m, err := readNpy(&b)
+	assert.Nil(t, err, "should not fail")
+	want := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.True(t, want.Equals(m), "should be equal")
+
+	b.Reset()
+	writeNpy(&b, want)
+	assert.Equal(t, 0, (b.Len()-6*8)%64, "data should be aligned")
+	m, err = readNpy(&b)
+	assert.Nil(t, err, "should not fail")
+	assert.True(t, want.Equals(m), "should be equal")
+
+	descr, fortran, shape, err := parseNpyHeader("{'descr': '<f8', 'fortran_order': False, 'shape': (5,), }")
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, "<f8", descr, "should be equal")
+	assert.False(t, fortran, "should be false")
+	assert.Equal(t, []int{5}, shape, "should be equal")
+
+	for _, h := range []string{
+		"{'shape': (2, 2), 'fortran_order': False, 'descr': '<f8}",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2}",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (-2, 2), }",
+		"{'descr': '<f8', 'fortran_order': False, 'shape': (4294967296, 2), }",
+	} {
+		_, _, _, err = parseNpyHeader(h)
+		assert.NotNil(t, err, "should fail on %s", h)
+	}
+
+	// A forged shape fails on the missing data instead of allocating for it.
+	b.Reset()
+	h := "{'descr': '<f8', 'fortran_order': False, 'shape': (1000000000, 1000000000), }"
+	b.WriteString(npyMagic + "\x01\x00")
+	binary.Write(&b, binary.LittleEndian, uint16(len(h)))
+	b.WriteString(h)
+	_, err = readNpy(&b)
+	assert.Equal(t, io.EOF, err, "should be equal")
+}
+
+func TestNpyf64(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(4, 3)
+	var b bytes.Buffer
+	m.WriteNpy(&b)
+	n := Newf64().ReadNpy(&b)
+	assert.True(t, m.Equals(n), "should be equal")
+}
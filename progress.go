@@ -0,0 +1,59 @@
+package matrix
+
+import (
+	"sync/atomic"
+)
+
+/*
+ProgressFunc is called by long running operations to report their progress.
+op is the name of the operation, such as "Dot()", done is the number of rows
+processed so far, or of iterations for iterative methods, and total is the
+number of rows or iterations to process, or -1 when it is not known in
+advance, as when loading a CSV file or iterating until convergence. The
+percentage of the work done is thus 100*done/total when total is positive.
+*/
+type ProgressFunc func(op string, done, total int)
+
+// progress holds the ProgressFunc set with SetProgress, wrapped in a struct
+// since an atomic.Value cannot hold nil.
+var progress atomic.Value
+
+type progressHolder struct {
+	f ProgressFunc
+}
+
+func init() {
+	progress.Store(progressHolder{})
+}
+
+/*
+SetProgress registers a function that is called as bulk operations make
+progress, so that command line tools built on this package can show a
+progress bar for long jobs:
+
+	matrix.SetProgress(func(op string, done, total int) {
+		if total > 0 {
+			fmt.Fprintf(os.Stderr, "\r%s %3d%%", op, 100*done/total)
+		}
+	})
+
+It is currently called by Dot, TDot, DotT and Gemmf64, which report every
+row of the result, and by Matf64FromCSV and Matf64FromCSVReader, which
+report every 4096 rows read, as well as once all rows are read.
+TruncatedSVD and RandomizedSVD report each iteration, Lanczos and Arnoldi
+each restart, and Logm each square root, in addition to the rows of the
+products they compute.
+
+The function is called from the goroutine running the operation, and must
+be safe for concurrent use if operations run concurrently. It should return
+quickly, since it slows the operation down otherwise. Passing nil removes
+the function.
+*/
+func SetProgress(f ProgressFunc) {
+	progress.Store(progressHolder{f})
+}
+
+// progressFunc returns the function set with SetProgress, or nil.
+func progressFunc() ProgressFunc {
+	return progress.Load().(progressHolder).f
+}
@@ -0,0 +1,49 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProgress(t *testing.T) {
+	t.Helper()
+	var calls []string
+	SetProgress(func(op string, done, total int) {
+		calls = append(calls, fmt.Sprintf("%s %d/%d", op, done, total))
+	})
+	defer SetProgress(nil)
+
+	a, b := RandMatf64(3, 2), RandMatf64(2, 4)
+	a.Dot(b)
+	assert.Equal(t, []string{"Dot() 1/3", "Dot() 2/3", "Dot() 3/3"}, calls, "should be equal")
+
+	calls = nil
+	Gemmf64(1, a, b, 0, Newf64(3, 4))
+	assert.Equal(t, "Gemmf64() 3/3", calls[len(calls)-1], "should be equal")
+
+	calls = nil
+	var csv bytes.Buffer
+	for i := 0; i < 5000; i++ {
+		csv.WriteString("1,2\n")
+	}
+	Matf64FromCSVReader(&csv)
+	want := []string{"Matf64FromCSVReader() 4096/-1", "Matf64FromCSVReader() 5000/-1"}
+	assert.Equal(t, want, calls, "should be equal")
+
+	calls = nil
+	RandMatf64(20, 10).RandomizedSVD(2, 3, nil)
+	assert.Contains(t, calls, "RandomizedSVD() 3/3", "should report the iterations")
+
+	calls = nil
+	m := Matf64FromData([][]float64{{2, 1}, {1, 3}})
+	m.Lanczos(1, EigenLargest)
+	assert.Contains(t, calls, "Lanczos() 1/-1", "should report the restarts")
+
+	SetProgress(nil)
+	calls = nil
+	a.Dot(b)
+	assert.Nil(t, calls, "should not be called")
+}
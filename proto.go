@@ -0,0 +1,199 @@
+package matrix
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Field numbers and wire types of the Matrix message defined in
+// proto/matrix.proto.
+const (
+	protoRows = 1
+	protoCols = 2
+	protoData = 3
+	protoMeta = 5
+
+	// Fields of the entries of the meta map.
+	protoKey   = 1
+	protoValue = 2
+
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+/*
+ToProto encodes the mat as a Matrix message, as defined in
+proto/matrix.proto, in the Protocol Buffers binary format. The result can
+be decoded with Matf64FromProto, or by the code generated by protoc from
+proto/matrix.proto in any language. In a Go service, it can for instance be
+carried in a bytes field, or unmarshaled into the generated type:
+
+	var pb matrixpb.Matrix
+	err := proto.Unmarshal(m.ToProto(), &pb)
+*/
+func (m *Matf64) ToProto() []byte {
+	b := make([]byte, 0, 2*binary.MaxVarintLen64+binary.MaxVarintLen32+8*len(m.vals)+3)
+	if m.r != 0 {
+		b = appendProtoVarint(b, protoRows<<3|protoVarint)
+		b = appendProtoVarint(b, uint64(m.r))
+	}
+	if m.c != 0 {
+		b = appendProtoVarint(b, protoCols<<3|protoVarint)
+		b = appendProtoVarint(b, uint64(m.c))
+	}
+	if len(m.vals) != 0 {
+		// Repeated scalars are packed, as is the default in proto3.
+		b = appendProtoVarint(b, protoData<<3|protoBytes)
+		b = appendProtoVarint(b, uint64(8*len(m.vals)))
+		for _, v := range m.vals {
+			var u [8]byte
+			binary.LittleEndian.PutUint64(u[:], math.Float64bits(v))
+			b = append(b, u[:]...)
+		}
+	}
+	for _, k := range m.MetaKeys() {
+		// Map entries are messages with the key and the value as fields.
+		var e []byte
+		e = appendProtoString(e, protoKey, k)
+		e = appendProtoString(e, protoValue, m.meta[k])
+		b = appendProtoVarint(b, protoMeta<<3|protoBytes)
+		b = appendProtoVarint(b, uint64(len(e)))
+		b = append(b, e...)
+	}
+	return b
+}
+
+func appendProtoString(b []byte, field uint64, s string) []byte {
+	b = appendProtoVarint(b, field<<3|protoBytes)
+	b = appendProtoVarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func appendProtoVarint(b []byte, v uint64) []byte {
+	var u [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(u[:], v)
+	return append(b, u[:n]...)
+}
+
+/*
+Matf64FromProto decodes a Matrix message, as defined in proto/matrix.proto,
+from the Protocol Buffers binary format. Both the packed and the unpacked
+encodings of the data field are accepted, and unknown fields are skipped.
+*/
+func Matf64FromProto(b []byte) *Matf64 {
+	r, c, vals, meta, err := decodeProtoMatrix(b)
+	if err == nil && r*c != len(vals) {
+		err = fmt.Errorf(wrongLength, "Matf64FromProto()", r, c, len(vals))
+	}
+	if err != nil {
+		s := "\nIn matrix.%s, cannot decode the message due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromProto()", err)
+		printErr(s)
+	}
+	m := Newf64(r, c)
+	copy(m.vals, vals)
+	for k, v := range meta {
+		m.SetMeta(k, v)
+	}
+	return m
+}
+
+func decodeProtoMatrix(b []byte) (r, c int, vals []float64, meta map[string]string, err error) {
+	errTruncated := fmt.Errorf("truncated message")
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return 0, 0, nil, nil, errTruncated
+		}
+		b = b[n:]
+		field, wire := key>>3, key&7
+		switch wire {
+		case protoVarint:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return 0, 0, nil, nil, errTruncated
+			}
+			b = b[n:]
+			// Unknown varint fields, such as those of newer versions of the
+			// message, may hold any value and are skipped.
+			if field != protoRows && field != protoCols {
+				continue
+			}
+			if v > math.MaxInt32 {
+				return 0, 0, nil, nil, fmt.Errorf("invalid dimension %d", v)
+			}
+			if field == protoRows {
+				r = int(v)
+			} else {
+				c = int(v)
+			}
+		case protoFixed64:
+			if len(b) < 8 {
+				return 0, 0, nil, nil, errTruncated
+			}
+			if field == protoData {
+				vals = append(vals, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+			}
+			b = b[8:]
+		case protoBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return 0, 0, nil, nil, errTruncated
+			}
+			data := b[n : n+int(l)]
+			b = b[n+int(l):]
+			if field == protoData {
+				if len(data)%8 != 0 {
+					return 0, 0, nil, nil, fmt.Errorf("invalid packed data of %d bytes", len(data))
+				}
+				for i := 0; i < len(data); i += 8 {
+					vals = append(vals, math.Float64frombits(binary.LittleEndian.Uint64(data[i:])))
+				}
+			}
+			if field == protoMeta {
+				k, v, err := decodeProtoEntry(data)
+				if err != nil {
+					return 0, 0, nil, nil, err
+				}
+				if meta == nil {
+					meta = make(map[string]string)
+				}
+				meta[k] = v
+			}
+		case protoFixed32:
+			if len(b) < 4 {
+				return 0, 0, nil, nil, errTruncated
+			}
+			b = b[4:]
+		default:
+			return 0, 0, nil, nil, fmt.Errorf("unsupported wire type %d", wire)
+		}
+	}
+	return r, c, vals, meta, nil
+}
+
+// decodeProtoEntry decodes an entry of a map<string, string> field.
+func decodeProtoEntry(b []byte) (k, v string, err error) {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 || key&7 != protoBytes {
+			return "", "", fmt.Errorf("invalid map entry")
+		}
+		b = b[n:]
+		l, n := binary.Uvarint(b)
+		if n <= 0 || l > uint64(len(b)-n) {
+			return "", "", fmt.Errorf("truncated message")
+		}
+		switch key >> 3 {
+		case protoKey:
+			k = string(b[n : n+int(l)])
+		case protoValue:
+			v = string(b[n : n+int(l)])
+		}
+		b = b[n+int(l):]
+	}
+	return k, v, nil
+}
@@ -0,0 +1,33 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProto(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := m.ToProto()
+	assert.Equal(t, []byte{0x08, 2, 0x10, 3, 0x1a, 48}, b[:6], "should be equal")
+	assert.Equal(t, 6+48, len(b), "should be equal")
+	assert.True(t, m.Equals(Matf64FromProto(b)), "should be equal")
+
+	// Unpacked data, preceded by an unknown string field.
+	b = []byte{0x22, 2, 'h', 'i', 0x08, 1, 0x10, 2}
+	b = append(b, 0x19, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f)
+	b = append(b, 0x19, 0, 0, 0, 0, 0, 0, 0, 0x40)
+	assert.True(t, Matf64FromData([]float64{1, 2}).Equals(Matf64FromProto(b)), "should be equal")
+
+	// An unknown varint field may hold any value, but the dimensions may not.
+	b = []byte{0x30, 0x80, 0x80, 0x80, 0x80, 0x80, 0x20, 0x08, 1, 0x10, 1}
+	b = append(b, 0x19, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f)
+	assert.True(t, Matf64FromData([]float64{1}).Equals(Matf64FromProto(b)), "should be equal")
+	_, _, _, _, err := decodeProtoMatrix([]byte{0x08, 0x80, 0x80, 0x80, 0x80, 0x80, 0x20})
+	assert.NotNil(t, err, "should fail on a huge dimension")
+
+	m = Newf64()
+	assert.Equal(t, 0, len(m.ToProto()), "should be empty")
+	assert.True(t, m.Equals(Matf64FromProto(nil)), "should be equal")
+}
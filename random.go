@@ -0,0 +1,75 @@
+package matrix
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// randSource holds the *rand.Rand used by the random constructors when they
+// are not given one explicitly.
+var randSource atomic.Value
+
+func init() {
+	randSource.Store(rand.New(globalSource{}))
+}
+
+/*
+SetRandSource sets the source of randomness used by all the random
+constructors of this package, such as RandMatf64, when they are not given a
+*rand.Rand of their own. For example:
+
+	matrix.SetRandSource(rand.NewSource(42))
+
+makes every subsequent random mat reproducible. The source is guarded by a
+mutex, so the constructors remain safe to call from concurrent goroutines.
+Passing nil restores the default, which is the global state of math/rand.
+
+Each random constructor also has a variant ending in With, such as
+RandMatf64With, which takes a *rand.Rand as its first argument. Giving each
+goroutine its own *rand.Rand avoids contention on a shared source, and
+passing nil to these variants uses the package source.
+*/
+func SetRandSource(src rand.Source) {
+	if src == nil {
+		randSource.Store(rand.New(globalSource{}))
+		return
+	}
+	randSource.Store(rand.New(&lockedSource{src: src}))
+}
+
+// randOrDefault returns rng, or the package source if rng is nil.
+func randOrDefault(rng *rand.Rand) *rand.Rand {
+	if rng != nil {
+		return rng
+	}
+	return randSource.Load().(*rand.Rand)
+}
+
+// globalSource draws from the global state of math/rand, which is already
+// safe for concurrent use.
+type globalSource struct{}
+
+func (globalSource) Int63() int64 { return rand.Int63() }
+
+func (globalSource) Uint64() uint64 { return rand.Uint64() }
+
+func (globalSource) Seed(seed int64) { rand.Seed(seed) }
+
+// lockedSource makes a rand.Source safe for concurrent use.
+type lockedSource struct {
+	mu  sync.Mutex
+	src rand.Source
+}
+
+func (s *lockedSource) Int63() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.src.Int63()
+}
+
+func (s *lockedSource) Seed(seed int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.src.Seed(seed)
+}
@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRandSource(t *testing.T) {
+	t.Helper()
+	defer SetRandSource(nil)
+	SetRandSource(rand.NewSource(42))
+	m := RandMatf64(4, 5)
+	n := RandnMatf64(4, 5)
+	SetRandSource(rand.NewSource(42))
+	assert.True(t, m.Equals(RandMatf64(4, 5)), "should be reproducible")
+	assert.True(t, n.Equals(RandnMatf64(4, 5)), "should be reproducible")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			RandMatf64(10, 10)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRandWith(t *testing.T) {
+	t.Helper()
+	m := RandSPDf64With(rand.New(rand.NewSource(7)), 5, 10)
+	n := RandSPDf64With(rand.New(rand.NewSource(7)), 5, 10)
+	assert.True(t, m.Equals(n), "should be reproducible")
+	a := RandMatf32With(rand.New(rand.NewSource(7)), 3, 3)
+	b := RandMatf32With(rand.New(rand.NewSource(7)), 3, 3)
+	assert.True(t, a.Equals(b), "should be reproducible")
+	assert.Equal(t, 3, RandPoissonMatf64With(nil, 3, 2, 1).r, "should use the package source")
+}
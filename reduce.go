@@ -0,0 +1,58 @@
+package matrix
+
+/*
+Reduce folds all the elements of the mat into a single value, in row-major
+order, starting from init. For example, the largest absolute value of m is:
+
+	maxAbs := m.Reduce(0, func(acc, v float64) float64 {
+		return math.Max(acc, math.Abs(v))
+	})
+*/
+func (m *Matf64) Reduce(init float64, f func(acc, v float64) float64) float64 {
+	acc := init
+	for _, v := range m.vals {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+/*
+ReduceRows folds each row of the mat into a single value, starting from init
+for every row, and returns the results as a column vector with one element
+per row. For example, the harmonic mean of each row of m is:
+
+	_, c := m.Shape()
+	n := m.ReduceRows(0, func(acc, v float64) float64 {
+		return acc + 1/v
+	})
+	n.Reciprocal().Mul(float64(c))
+*/
+func (m *Matf64) ReduceRows(init float64, f func(acc, v float64) float64) *Matf64 {
+	n := Newf64(m.r, 1)
+	for i := 0; i < m.r; i++ {
+		acc := init
+		for _, v := range m.vals[i*m.c : (i+1)*m.c] {
+			acc = f(acc, v)
+		}
+		n.vals[i] = acc
+	}
+	return n
+}
+
+/*
+ReduceCols folds each column of the mat into a single value, starting from
+init for every column, and returns the results as a row vector with one
+element per column (see ReduceRows).
+*/
+func (m *Matf64) ReduceCols(init float64, f func(acc, v float64) float64) *Matf64 {
+	n := Newf64(1, m.c)
+	for j := range n.vals {
+		n.vals[j] = init
+	}
+	for i := 0; i < m.r; i++ {
+		for j, v := range m.vals[i*m.c : (i+1)*m.c] {
+			n.vals[j] = f(n.vals[j], v)
+		}
+	}
+	return n
+}
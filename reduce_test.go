@@ -0,0 +1,26 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReducef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, -7, 3}, {4, 5, -2}})
+	maxAbs := func(acc, v float64) float64 {
+		return math.Max(acc, math.Abs(v))
+	}
+	assert.Equal(t, 7.0, m.Reduce(0, maxAbs), "should be equal")
+
+	n := m.ReduceRows(0, maxAbs)
+	assert.Equal(t, 2, n.r, "should be a column vector")
+	assert.Equal(t, 1, n.c, "should be a column vector")
+	assert.Equal(t, []float64{7, 5}, n.vals, "should be equal")
+
+	n = m.ReduceCols(1, func(acc, v float64) float64 { return acc * v })
+	assert.Equal(t, 1, n.r, "should be a row vector")
+	assert.Equal(t, []float64{4, -35, -6}, n.vals, "should be equal")
+}
@@ -0,0 +1,111 @@
+package matrix
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+/*
+Matf64FromURL downloads a mat from the given http or https URL. This makes it
+possible to load datasets hosted on object storage, or any web server,
+without saving them to disk first:
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	m := matrix.Matf64FromURL(ctx, "https://example.com/data.csv.gz", 1<<30)
+
+The content is expected to be either in the format written by WriteBinary,
+or CSV data, which is loaded as with Matf64FromCSV. In both cases it may be
+compressed with gzip. The format is detected from the content itself, so the
+URL does not need to have a specific extension.
+
+The download is aborted when ctx is done, or when the (uncompressed) content
+is larger than maxBytes. For binary content, the shape in the header is
+checked against maxBytes before any memory is allocated for the values. A
+maxBytes of 0 or less means no limit.
+*/
+func Matf64FromURL(ctx context.Context, url string, maxBytes int64) *Matf64 {
+	req, err := http.NewRequest("GET", url, nil)
+	var resp *http.Response
+	if err == nil {
+		resp, err = http.DefaultClient.Do(req.WithContext(ctx))
+	}
+	if err == nil && resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		err = fmt.Errorf("server returned %s", resp.Status)
+	}
+	if err == nil && maxBytes > 0 && resp.ContentLength > maxBytes {
+		resp.Body.Close()
+		err = fmt.Errorf("content length %d exceeds the limit of %d bytes", resp.ContentLength, maxBytes)
+	}
+	if err != nil {
+		s := "\nIn matrix.%s, cannot download %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromURL()", url, err)
+		printErr(s)
+	}
+	defer resp.Body.Close()
+	r, err := decompress(resp.Body)
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read %s due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromURL()", url, err)
+		printErr(s)
+	}
+	if maxBytes > 0 {
+		r = &limitedReader{r, maxBytes}
+	}
+	b := bufio.NewReader(r)
+	if magic, err := b.Peek(len(binaryMagic)); err == nil && string(magic) == binaryMagic {
+		if maxBytes > 0 {
+			// Check the declared shape before anything is allocated for it.
+			hdr, err := b.Peek(binaryHeaderSize)
+			if err == nil {
+				err = checkBinarySize(bytes.NewReader(hdr), maxBytes)
+			}
+			if err != nil {
+				s := "\nIn matrix.%s, cannot read %s due to error: %v.\n"
+				s = fmt.Sprintf(s, "Matf64FromURL()", url, err)
+				printErr(s)
+			}
+		}
+		return Newf64().ReadBinary(b)
+	}
+	return matf64FromCSVReader(b, url, "Matf64FromURL()", nil)
+}
+
+// checkBinarySize reads a binary header from r, and returns an error if the
+// values it declares take more than maxBytes.
+func checkBinarySize(r io.Reader, maxBytes int64) error {
+	h, err := readBinaryHeader(r)
+	if err != nil {
+		return err
+	}
+	size := int64(8)
+	if h.dtype == binaryFloat32 {
+		size = 4
+	}
+	if n := size * int64(h.r) * int64(h.c); n > maxBytes-binaryHeaderSize {
+		return fmt.Errorf("a %dx%d mat takes %d bytes, which exceeds the limit of %d bytes", h.r, h.c, n+binaryHeaderSize, maxBytes)
+	}
+	return nil
+}
+
+// limitedReader reads from r, and fails once more than n bytes were read.
+// Unlike io.LimitReader, which silently stops at the limit, it makes sure
+// that truncated data is never mistaken for complete data.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, fmt.Errorf("content exceeds the size limit")
+	}
+	return n, err
+}
@@ -0,0 +1,61 @@
+package matrix
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatf64FromURL(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(20, 3)
+	var bin, gz bytes.Buffer
+	m.WriteBinary(&bin)
+	z := gzip.NewWriter(&gz)
+	z.Write([]byte("1,2\n3,4\n"))
+	z.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/m.bin":
+			w.Write(bin.Bytes())
+		case "/m.csv.gz":
+			w.Write(gz.Bytes())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	n := Matf64FromURL(context.Background(), srv.URL+"/m.bin", 0)
+	assert.True(t, m.Equals(n), "should be equal")
+	n = Matf64FromURL(context.Background(), srv.URL+"/m.csv.gz", 1024)
+	assert.True(t, Matf64FromData([]float64{1, 2, 3, 4}, 2, 2).Equals(n), "should be equal")
+}
+
+func TestLimitedReader(t *testing.T) {
+	t.Helper()
+	buf := make([]byte, 10)
+	l := &limitedReader{bytes.NewReader([]byte("0123456789")), 10}
+	_, err := l.Read(buf)
+	assert.Nil(t, err, "should not fail at the limit")
+	l = &limitedReader{bytes.NewReader([]byte("0123456789")), 9}
+	_, err = l.Read(buf)
+	assert.NotNil(t, err, "should fail past the limit")
+}
+
+func TestCheckBinarySize(t *testing.T) {
+	t.Helper()
+	var b bytes.Buffer
+	Newf64(2, 3).WriteBinary(&b)
+	assert.Nil(t, checkBinarySize(bytes.NewReader(b.Bytes()), int64(b.Len())), "should fit")
+	err := checkBinarySize(bytes.NewReader(b.Bytes()), int64(b.Len()-1))
+	assert.EqualError(t, err, "a 2x3 mat takes 72 bytes, which exceeds the limit of 71 bytes")
+	var f bytes.Buffer
+	Newf32(2, 3).WriteBinary(&f)
+	assert.Nil(t, checkBinarySize(bytes.NewReader(f.Bytes()), 48), "should fit")
+}
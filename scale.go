@@ -0,0 +1,243 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+NormalizeRows scales each row of the mat, in place, so that its Lp norm is 1.
+p is usually 1 (the sum of absolute values) or 2 (the euclidean length), but
+any p of at least 1 is accepted, including math.Inf(1) for the largest
+absolute value. Rows whose norm is zero are left untouched, rather than
+being filled with NaNs. After
+
+	m.NormalizeRows(2)
+
+m.DotT(m) holds the cosine similarity between every pair of rows.
+*/
+func (m *Matf64) NormalizeRows(p float64) *Matf64 {
+	checkNormOrder("NormalizeRows()", p)
+	m.materialize()
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		if norm := pNorm(row, 1, p); norm != 0 {
+			for j := range row {
+				row[j] /= norm
+			}
+		}
+	}
+	return m
+}
+
+/*
+NormalizeCols scales each column of the mat, in place, so that its Lp norm is
+1. It follows the same rules as NormalizeRows.
+*/
+func (m *Matf64) NormalizeCols(p float64) *Matf64 {
+	checkNormOrder("NormalizeCols()", p)
+	m.materialize()
+	for j := 0; j < m.c; j++ {
+		col := m.vals[j:]
+		if norm := pNorm(col, m.c, p); norm != 0 {
+			for i := 0; i < m.r; i++ {
+				col[i*m.c] /= norm
+			}
+		}
+	}
+	return m
+}
+
+// pNorm returns the Lp norm of the elements v[0], v[stride], v[2*stride]...
+func pNorm(v []float64, stride int, p float64) float64 {
+	norm := 0.0
+	switch {
+	case p == 1:
+		for i := 0; i < len(v); i += stride {
+			norm += math.Abs(v[i])
+		}
+	case p == 2:
+		// Scaling by the largest value avoids overflow and underflow.
+		max := pNorm(v, stride, math.Inf(1))
+		if max == 0 || math.IsInf(max, 1) {
+			return max
+		}
+		for i := 0; i < len(v); i += stride {
+			x := v[i] / max
+			norm += x * x
+		}
+		norm = max * math.Sqrt(norm)
+	case math.IsInf(p, 1):
+		for i := 0; i < len(v); i += stride {
+			norm = math.Max(norm, math.Abs(v[i]))
+		}
+	default:
+		for i := 0; i < len(v); i += stride {
+			norm += math.Pow(math.Abs(v[i]), p)
+		}
+		norm = math.Pow(norm, 1/p)
+	}
+	return norm
+}
+
+func checkNormOrder(fn string, p float64) {
+	if !(p >= 1) {
+		s := "\nIn %s, the order of the norm must be at least 1, but %f was "
+		s += "received.\n"
+		s = fmt.Sprintf(s, fn, p)
+		printHelperErr(s)
+	}
+}
+
+/*
+MinMaxFit holds the parameters found by MinMaxScale, so that the same
+scaling can be applied to other data, such as a test set, or undone.
+*/
+type MinMaxFit struct {
+	// Lo and Hi are the bounds of the target range.
+	Lo, Hi float64
+	// Min and Max hold the smallest and largest value of each column of the
+	// fitted mat.
+	Min, Max []float64
+}
+
+/*
+MinMaxScale linearly rescales each column of the mat, in place, so that its
+smallest value becomes lo and its largest value becomes hi, and returns the
+fitted parameters. For example:
+
+	fit := train.MinMaxScale(0, 1)
+	fit.Transform(test)
+
+scales test exactly as train was scaled, so its values may fall outside of
+[0, 1]. Columns whose values are all equal become lo. NaNs are ignored when
+fitting, and stay NaN. lo must be less than or equal to hi.
+*/
+func (m *Matf64) MinMaxScale(lo, hi float64) *MinMaxFit {
+	if !(lo <= hi) {
+		s := "\nIn %s, lo (%f) must be less than or equal to hi (%f).\n"
+		s = fmt.Sprintf(s, "MinMaxScale()", lo, hi)
+		printErr(s)
+	}
+	f := &MinMaxFit{
+		Lo:  lo,
+		Hi:  hi,
+		Min: make([]float64, m.c),
+		Max: make([]float64, m.c),
+	}
+	for j := 0; j < m.c; j++ {
+		f.Min[j], f.Max[j] = math.Inf(1), math.Inf(-1)
+		for i := 0; i < m.r; i++ {
+			v := m.vals[i*m.c+j]
+			if v < f.Min[j] {
+				f.Min[j] = v
+			}
+			if v > f.Max[j] {
+				f.Max[j] = v
+			}
+		}
+	}
+	f.Transform(m)
+	return f
+}
+
+/*
+Transform applies the fitted scaling to the columns of m, in place, and
+returns m. m must have as many columns as the fitted mat.
+*/
+func (f *MinMaxFit) Transform(m *Matf64) *Matf64 {
+	f.checkCols("Transform()", m)
+	m.materialize()
+	for j := 0; j < m.c; j++ {
+		scale := 0.0
+		if f.Max[j] > f.Min[j] {
+			scale = (f.Hi - f.Lo) / (f.Max[j] - f.Min[j])
+		}
+		for i := 0; i < m.r; i++ {
+			m.vals[i*m.c+j] = (m.vals[i*m.c+j]-f.Min[j])*scale + f.Lo
+		}
+	}
+	return m
+}
+
+/*
+Inverse undoes the fitted scaling on the columns of m, in place, and returns
+m. Columns whose fitted values were all equal are restored to that value.
+*/
+func (f *MinMaxFit) Inverse(m *Matf64) *Matf64 {
+	f.checkCols("Inverse()", m)
+	m.materialize()
+	for j := 0; j < m.c; j++ {
+		scale := 0.0
+		if f.Hi > f.Lo {
+			scale = (f.Max[j] - f.Min[j]) / (f.Hi - f.Lo)
+		}
+		for i := 0; i < m.r; i++ {
+			m.vals[i*m.c+j] = (m.vals[i*m.c+j]-f.Lo)*scale + f.Min[j]
+		}
+	}
+	return m
+}
+
+func (f *MinMaxFit) checkCols(fn string, m *Matf64) {
+	if m.c != len(f.Min) {
+		s := "\nIn %s, the mat has %d columns, but the fit has %d.\n"
+		s = fmt.Sprintf(s, fn, m.c, len(f.Min))
+		printHelperErr(s)
+	}
+}
+
+/*
+CenterCols subtracts the mean of each column from that column, in place, so
+that every column of the result has a mean of zero. This is the first step
+of computing a covariance matrix or a PCA, with one sample per row:
+
+	r, _ := m.Shape()
+	m.CenterCols()
+	cov := m.Copy().T().Dot(m).Div(float64(r - 1))
+*/
+func (m *Matf64) CenterCols() *Matf64 {
+	m.materialize()
+	if m.r == 0 {
+		return m
+	}
+	mean := make([]float64, m.c)
+	for i := 0; i < m.r; i++ {
+		for j, v := range m.vals[i*m.c : (i+1)*m.c] {
+			mean[j] += v
+		}
+	}
+	for j := range mean {
+		mean[j] /= float64(m.r)
+	}
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		for j := range row {
+			row[j] -= mean[j]
+		}
+	}
+	return m
+}
+
+/*
+CenterRows subtracts the mean of each row from that row, in place, so that
+every row of the result has a mean of zero.
+*/
+func (m *Matf64) CenterRows() *Matf64 {
+	m.materialize()
+	if m.c == 0 {
+		return m
+	}
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		mean := 0.0
+		for _, v := range row {
+			mean += v
+		}
+		mean /= float64(m.c)
+		for j := range row {
+			row[j] -= mean
+		}
+	}
+	return m
+}
@@ -0,0 +1,75 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRowsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{3, -4}, {0, 0}, {1e200, 1e200}})
+	n := m.Copy().NormalizeRows(2)
+	assert.Equal(t, []float64{0.6, -0.8}, n.vals[:2], "should be equal")
+	assert.Equal(t, []float64{0, 0}, n.vals[2:4], "should leave zero rows")
+	assert.InDelta(t, math.Sqrt(0.5), n.vals[4], 1e-15, "should not overflow")
+	n = m.Copy().NormalizeRows(1)
+	assert.Equal(t, []float64{3.0 / 7, -4.0 / 7}, n.vals[:2], "should be equal")
+	n = m.Copy().NormalizeRows(math.Inf(1))
+	assert.Equal(t, []float64{0.75, -1}, n.vals[:2], "should be equal")
+	n = m.Copy().NormalizeRows(3)
+	assert.InDelta(t, 1.0, pNorm(n.vals[:2], 1, 3), 1e-15, "should be equal")
+}
+
+func TestNormalizeColsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{3, 0, 1}, {4, 0, -1}})
+	m.NormalizeCols(2)
+	want := Matf64FromData([][]float64{
+		{0.6, 0, 1 / math.Sqrt(2)},
+		{0.8, 0, -1 / math.Sqrt(2)},
+	})
+	for i := range want.vals {
+		assert.InDelta(t, want.vals[i], m.vals[i], 1e-15, "should be equal")
+	}
+}
+
+func TestMinMaxScalef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{1, 10, 5},
+		{3, math.NaN(), 5},
+		{2, 30, 5},
+	})
+	orig := m.Copy()
+	fit := m.MinMaxScale(-1, 1)
+	assert.Equal(t, []float64{1, 10, 5}, fit.Min, "should be equal")
+	assert.Equal(t, []float64{3, 30, 5}, fit.Max, "should be equal")
+	assert.Equal(t, []float64{-1, -1, -1}, m.Row(0).vals, "should be equal")
+	assert.Equal(t, 1.0, m.Get(1, 0), "should be equal")
+	assert.True(t, math.IsNaN(m.Get(1, 1)), "should stay NaN")
+	assert.Equal(t, []float64{0, 1, -1}, m.Row(2).vals, "should be equal")
+
+	test := Matf64FromData([]float64{4, 20, 6})
+	fit.Transform(test)
+	assert.Equal(t, []float64{2, 0, -1}, test.vals, "should use the fitted range")
+
+	fit.Inverse(m)
+	for i := range m.vals {
+		if !math.IsNaN(orig.vals[i]) {
+			assert.Equal(t, orig.vals[i], m.vals[i], "should undo the scaling")
+		}
+	}
+}
+
+func TestCenterf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 10}, {3, 20}, {5, 60}})
+	n := m.Copy().CenterCols()
+	want := Matf64FromData([][]float64{{-2, -20}, {0, -10}, {2, 30}})
+	assert.True(t, want.Equals(n), "should be equal")
+	n = m.CenterRows()
+	want = Matf64FromData([][]float64{{-4.5, 4.5}, {-8.5, 8.5}, {-27.5, 27.5}})
+	assert.True(t, want.Equals(n), "should be equal")
+}
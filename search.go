@@ -0,0 +1,89 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+Contains reports whether any element of the mat equals v. NaN is treated as
+equal to itself, so m.Contains(math.NaN()) reports whether the mat has
+missing values.
+*/
+func (m *Matf64) Contains(v float64) bool {
+	nan := math.IsNaN(v)
+	for _, x := range m.vals {
+		if x == v || (nan && math.IsNaN(x)) {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Count returns the number of elements of the mat for which f is true. f is
+called with a pointer to each element, as with All and Any, so for instance
+
+	m.Count(func(x *float64) bool { return *x > 0 })
+
+counts the positive elements of m.
+*/
+func (m *Matf64) Count(f func(*float64) bool) int {
+	n := 0
+	for i := range m.vals {
+		if f(&m.vals[i]) {
+			n++
+		}
+	}
+	return n
+}
+
+/*
+Find returns the row and column of every element of the mat for which f is
+true, in row major order, or nil if there are none. For example, the cells
+holding NaNs are listed with:
+
+	for _, rc := range m.Find(func(x *float64) bool { return math.IsNaN(*x) }) {
+		fmt.Printf("NaN at row %d, column %d\n", rc[0], rc[1])
+	}
+*/
+func (m *Matf64) Find(f func(*float64) bool) [][2]int {
+	var found [][2]int
+	for i := range m.vals {
+		if f(&m.vals[i]) {
+			found = append(found, [2]int{i / m.c, i % m.c})
+		}
+	}
+	return found
+}
+
+/*
+Replace sets every element of the mat within tol of old to repl, in place,
+and returns the mat. A tol of 0 replaces exact matches only. As in Contains,
+a NaN old matches the NaNs of the mat, so missing values can be filled, and
+sentinels can be turned into NaNs after an import:
+
+	m.Replace(-999, math.NaN(), 0)
+*/
+func (m *Matf64) Replace(old, repl, tol float64) *Matf64 {
+	nan := math.IsNaN(old)
+	return m.ReplaceWhere(func(x *float64) bool {
+		if nan {
+			return math.IsNaN(*x)
+		}
+		return math.Abs(*x-old) <= tol
+	}, repl)
+}
+
+/*
+ReplaceWhere sets every element of the mat for which f is true to repl, in
+place, and returns the mat. f is called as in Count.
+*/
+func (m *Matf64) ReplaceWhere(f func(*float64) bool, repl float64) *Matf64 {
+	m.materialize()
+	for i := range m.vals {
+		if f(&m.vals[i]) {
+			m.vals[i] = repl
+		}
+	}
+	return m
+}
@@ -0,0 +1,38 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, -2, 3}, {math.NaN(), 0, -6}})
+	assert.True(t, m.Contains(-6), "should be found")
+	assert.False(t, m.Contains(2), "should not be found")
+	assert.True(t, m.Contains(math.NaN()), "should find the NaN")
+	assert.False(t, Newf64(2, 2).Contains(math.NaN()), "should not find a NaN")
+
+	neg := func(x *float64) bool { return *x < 0 }
+	assert.Equal(t, 2, m.Count(func(x *float64) bool { return *x > 0 }), "should be equal")
+	assert.Equal(t, 2, m.Count(neg), "should be equal")
+	assert.Equal(t, [][2]int{{0, 1}, {1, 2}}, m.Find(neg), "should be equal")
+	assert.Nil(t, m.Find(func(x *float64) bool { return *x > 10 }), "should be nil")
+}
+
+func TestReplacef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, -999, 3}, {-999.0001, 0, -6}})
+	n := m.CloneCOW()
+	m.Replace(-999, math.NaN(), 0)
+	assert.Equal(t, 1, m.Count(func(x *float64) bool { return math.IsNaN(*x) }), "should be equal")
+	assert.Equal(t, -999.0, n.Get(0, 1), "should leave the clone intact")
+	m.Replace(-999, math.NaN(), 1e-3)
+	assert.Equal(t, [][2]int{{0, 1}, {1, 0}}, m.Find(func(x *float64) bool { return math.IsNaN(*x) }), "should be equal")
+	m.Replace(math.NaN(), 0, 0)
+	assert.False(t, m.Contains(math.NaN()), "should fill the NaNs")
+	m.ReplaceWhere(func(x *float64) bool { return *x < 0 }, 0)
+	assert.Equal(t, [][]float64{{1, 0, 3}, {0, 0, 0}}, m.ToSlice2D(), "should be equal")
+}
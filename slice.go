@@ -0,0 +1,115 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+SliceStep returns the rows from r0 up to r1, excluded, taking one every rStep
+rows, and within them the columns from c0 up to c1, excluded, taking one
+every cStep columns, as m[r0:r1:rStep, c0:c1:cStep] does in NumPy. For
+example, downsampling an image by 2 along its rows and by 5 along its
+columns is:
+
+	r, c := m.Shape()
+	small := m.SliceStep(0, r, 2, 0, c, 5)
+
+The bounds follow the rules of Python slices: negative bounds count from the
+end, and bounds past either end are clamped, so that a slice can be empty
+but never out of range. The steps cannot be 0, and negative steps walk
+backwards, from r0 down to r1 excluded. To walk backwards down to the first
+row, pass a bound before it, as in m.SliceStep(-1, -r-1, -1, 0, c, 1), which
+reverses the rows.
+
+The returned mat is a copy, with the names of the selected rows and columns,
+and the original mat is left intact. To write the slice back after changing
+it, see SetSliceStep.
+*/
+func (m *Matf64) SliceStep(r0, r1, rStep, c0, c1, cStep int) *Matf64 {
+	rows := sliceIndices("SliceStep()", "row", r0, r1, rStep, m.r)
+	cols := sliceIndices("SliceStep()", "column", c0, c1, cStep, m.c)
+	n := Newf64(len(rows), len(cols))
+	for i, r := range rows {
+		for j, c := range cols {
+			n.vals[i*n.c+j] = m.vals[r*m.c+c]
+		}
+	}
+	n.editNames(pickNames(m.RowNames(), rows), pickNames(m.ColNames(), cols))
+	return n
+}
+
+/*
+SetSliceStep copies n, in place, into the rows and columns of the mat that
+SliceStep selects with the same arguments, so that
+
+	r, c := m.Shape()
+	m.SetSliceStep(0, r, 2, 0, c, 1, m.SliceStep(0, r, 2, 0, c, 1).Mul(0.0))
+
+zeroes every other row of m. n must have the shape of the slice, and cannot
+be m itself.
+*/
+func (m *Matf64) SetSliceStep(r0, r1, rStep, c0, c1, cStep int, n *Matf64) *Matf64 {
+	rows := sliceIndices("SetSliceStep()", "row", r0, r1, rStep, m.r)
+	cols := sliceIndices("SetSliceStep()", "column", c0, c1, cStep, m.c)
+	if n.r != len(rows) || n.c != len(cols) {
+		s := "\nIn %s, the slice is %dX%d, but the passed mat is %dX%d.\n"
+		s = fmt.Sprintf(s, "SetSliceStep()", len(rows), len(cols), n.r, n.c)
+		printErr(s)
+	}
+	if n == m {
+		s := "\nIn %s, the passed mat cannot be the receiver.\n"
+		s = fmt.Sprintf(s, "SetSliceStep()")
+		printErr(s)
+	}
+	m.materialize()
+	for i, r := range rows {
+		for j, c := range cols {
+			m.vals[r*m.c+c] = n.vals[i*n.c+j]
+		}
+	}
+	return m
+}
+
+// sliceIndices returns the indices selected by the Python slice
+// [start:stop:step] of an axis of length n.
+func sliceIndices(fn, what string, start, stop, step, n int) []int {
+	if step == 0 {
+		s := "\nIn %s, the %s step cannot be 0.\n"
+		s = fmt.Sprintf(s, fn, what)
+		printHelperErr(s)
+	}
+	lo, hi := 0, n
+	if step < 0 {
+		lo, hi = -1, n-1
+	}
+	clamp := func(x int) int {
+		if x < 0 {
+			x += n
+		}
+		if x < lo {
+			return lo
+		}
+		if x > hi {
+			return hi
+		}
+		return x
+	}
+	start, stop = clamp(start), clamp(stop)
+	var indices []int
+	for i := start; (step > 0 && i < stop) || (step < 0 && i > stop); i += step {
+		indices = append(indices, i)
+	}
+	return indices
+}
+
+// pickNames returns the names at the given indices, or nil if names is nil.
+func pickNames(names []string, indices []int) []string {
+	if names == nil {
+		return nil
+	}
+	picked := make([]string, len(indices))
+	for i, x := range indices {
+		picked[i] = names[x]
+	}
+	return picked
+}
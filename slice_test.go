@@ -0,0 +1,36 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceStep(t *testing.T) {
+	t.Helper()
+	m := Newf64(4, 6)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	want := [][]float64{{0, 3}, {12, 15}}
+	assert.Equal(t, want, m.SliceStep(0, 4, 2, 0, 6, 3).ToSlice2D(), "should be equal")
+	assert.Equal(t, want, m.SliceStep(-4, 100, 2, -6, 6, 3).ToSlice2D(), "should clamp")
+	assert.Equal(t, [][]float64{{23, 21}, {11, 9}}, m.SliceStep(-1, 0, -2, -1, 2, -2).ToSlice2D(), "should be equal")
+	assert.Equal(t, [][]float64{{18}, {12}, {6}, {0}}, m.SliceStep(-1, -5, -1, 0, 1, 1).ToSlice2D(), "should reverse")
+	r, c := m.SliceStep(3, 1, 1, 0, 6, 1).Shape()
+	assert.Equal(t, []int{0, 6}, []int{r, c}, "should be empty")
+
+	m.SetColNames([]string{"a", "b", "c", "d", "e", "f"})
+	assert.Equal(t, []string{"b", "d", "f"}, m.SliceStep(0, 1, 1, 1, 6, 2).ColNames(), "should be equal")
+}
+
+func TestSetSliceStep(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	m.SetSliceStep(0, 3, 2, 1, 4, 2, Matf64FromData([][]float64{{1, 2}, {3, 4}}))
+	want := [][]float64{{0, 1, 0, 2}, {0, 0, 0, 0}, {0, 3, 0, 4}}
+	assert.Equal(t, want, m.ToSlice2D(), "should be equal")
+	n := m.SliceStep(0, 3, 1, 1, 4, 2)
+	m.SetSliceStep(0, 3, 1, 1, 4, 2, n.Mul(2.0))
+	assert.Equal(t, 8.0, m.Get(2, 3), "should be equal")
+}
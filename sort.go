@@ -0,0 +1,102 @@
+package matrix
+
+import (
+	"math"
+	"sort"
+)
+
+/*
+SortRowsBy reorders the rows of the mat, in place, by the values of one of
+its columns, in ascending order, or in descending order if descending is
+true. This is the equivalent of "ORDER BY" for a table loaded with
+Matf64FromCSV. Negative column indices count from the right, just as with
+Col. The sort is stable, so rows with equal keys keep their relative order,
+and sorting by several columns can be done by sorting by the least
+significant column first. Rows whose key is NaN are always placed last.
+*/
+func (m *Matf64) SortRowsBy(col int, descending bool) *Matf64 {
+	col = normIndex("SortRowsBy()", "column", col, m.c)
+	return m.SortRowsFunc(func(a, b []float64) bool {
+		x, y := a[col], b[col]
+		switch {
+		case math.IsNaN(x):
+			return false
+		case math.IsNaN(y):
+			return true
+		case descending:
+			return x > y
+		}
+		return x < y
+	})
+}
+
+/*
+SortRowsFunc reorders the rows of the mat, in place, so that less(a, b) is
+false for every row a that comes after a row b. less receives the rows
+themselves, which must not be modified. The sort is stable. For example, to
+sort by the first column, and then by the second one:
+
+	m.SortRowsFunc(func(a, b []float64) bool {
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] < b[1]
+	})
+*/
+func (m *Matf64) SortRowsFunc(less func(a, b []float64) bool) *Matf64 {
+	perm := make([]int, m.r)
+	for i := range perm {
+		perm[i] = i
+	}
+	sort.SliceStable(perm, func(i, j int) bool {
+		a, b := perm[i], perm[j]
+		return less(m.vals[a*m.c:(a+1)*m.c], m.vals[b*m.c:(b+1)*m.c])
+	})
+	m.materialize()
+	m.permuteRows(perm)
+	return m
+}
+
+// permuteRows moves row perm[i] of m to row i.
+func (m *Matf64) permuteRows(perm []int) {
+	vals := make([]float64, len(m.vals), cap(m.vals))
+	for i, p := range perm {
+		copy(vals[i*m.c:(i+1)*m.c], m.vals[p*m.c:(p+1)*m.c])
+	}
+	m.vals = vals
+	m.editNames(permuteNames(m.RowNames(), perm), m.ColNames())
+}
+
+/*
+PermuteRows reorders the rows of the mat, in place, so that row i becomes the
+row that was at index perm[i], which gives the same result as multiplying by
+PermutationMatf64(perm) on the left, without the cost of the product. perm
+must hold each of the integers from 0 to the number of rows minus one
+exactly once.
+*/
+func (m *Matf64) PermuteRows(perm []int) *Matf64 {
+	checkPerm("PermuteRows()", perm, m.r)
+	m.materialize()
+	m.permuteRows(perm)
+	return m
+}
+
+/*
+PermuteCols reorders the columns of the mat, in place, so that column j
+becomes the column that was at index perm[j]. perm must hold each of the
+integers from 0 to the number of columns minus one exactly once.
+*/
+func (m *Matf64) PermuteCols(perm []int) *Matf64 {
+	checkPerm("PermuteCols()", perm, m.c)
+	m.materialize()
+	row := make([]float64, m.c)
+	for i := 0; i < m.r; i++ {
+		vals := m.vals[i*m.c : (i+1)*m.c]
+		for j, p := range perm {
+			row[j] = vals[p]
+		}
+		copy(vals, row)
+	}
+	m.editNames(m.RowNames(), permuteNames(m.ColNames(), perm))
+	return m
+}
@@ -0,0 +1,50 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortRowsByf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{3, 1},
+		{1, 2},
+		{math.NaN(), 3},
+		{2, 4},
+		{1, 5},
+	})
+	m.SortRowsBy(0, false)
+	assert.Equal(t, []float64{2, 5, 4, 1, 3}, m.Col(1).vals, "should be sorted and stable")
+	m.SortRowsBy(-2, true)
+	assert.Equal(t, []float64{1, 4, 2, 5, 3}, m.Col(1).vals, "should be sorted and stable")
+	assert.True(t, math.IsNaN(m.Get(4, 0)), "NaN should be last")
+}
+
+func TestSortRowsFuncf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{5, 5}, {1, 2}, {0, 4}})
+	m.SortRowsFunc(func(a, b []float64) bool {
+		return a[0]+a[1] < b[0]+b[1]
+	})
+	want := Matf64FromData([][]float64{{1, 2}, {0, 4}, {5, 5}})
+	assert.True(t, want.Equals(m), "should be equal")
+}
+
+func TestPermuteRowsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}, {5, 6}})
+	perm := []int{2, 0, 1}
+	want := PermutationMatf64(perm).Dot(m)
+	assert.True(t, want.Equals(m.PermuteRows(perm)), "should match the permutation matrix")
+}
+
+func TestPermuteColsf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	m.PermuteCols([]int{1, 2, 0})
+	want := Matf64FromData([][]float64{{2, 3, 1}, {5, 6, 4}})
+	assert.True(t, want.Equals(m), "should be equal")
+}
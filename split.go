@@ -0,0 +1,133 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+VSplit splits the mat into k mats stacked vertically, each holding
+consecutive rows of m, so that appending them back in order gives m. When
+the number of rows is not a multiple of k, the first mats get one more row
+than the others. For example, splitting a 10X3 mat in 3 gives mats of 4, 3
+and 3 rows. k must be between 1 and the number of rows. The returned mats
+are copies, and the original mat is left intact.
+*/
+func (m *Matf64) VSplit(k int) []*Matf64 {
+	return m.SplitAt(splitPoints("VSplit()", m.r, k), 0)
+}
+
+/*
+HSplit splits the mat into k mats placed side by side, each holding
+consecutive columns of m, following the same rules as VSplit.
+*/
+func (m *Matf64) HSplit(k int) []*Matf64 {
+	return m.SplitAt(splitPoints("HSplit()", m.c, k), 1)
+}
+
+/*
+SplitAt splits the mat before each of the given indices, which must be
+increasing. With an axis of 0 the rows are split, and with an axis of 1 the
+columns are split. For example:
+
+	parts := m.SplitAt([]int{2, 5}, 0)
+
+returns three mats holding rows 0 to 1, rows 2 to 4, and the remaining rows
+of m. Indices may be equal, or equal to 0 or to the length of the axis, in
+which case some of the mats are empty. The returned mats are copies.
+*/
+func (m *Matf64) SplitAt(indices []int, axis int) []*Matf64 {
+	var n int
+	switch axis {
+	case 0:
+		n = m.r
+	case 1:
+		n = m.c
+	default:
+		s := "\nIn %s, the axis must be 0 or 1, however %d was received.\n"
+		s = fmt.Sprintf(s, "SplitAt()", axis)
+		printErr(s)
+	}
+	prev := 0
+	for _, x := range indices {
+		if x < prev || x > n {
+			s := "\nIn %s, the indices %v must be increasing, and within the\n"
+			s += "bounds [0, %d].\n"
+			s = fmt.Sprintf(s, "SplitAt()", indices, n)
+			printErr(s)
+		}
+		prev = x
+	}
+	parts := make([]*Matf64, 0, len(indices)+1)
+	ends := make([]int, 0, len(indices)+1)
+	ends = append(append(ends, indices...), n)
+	start := 0
+	for _, end := range ends {
+		var p *Matf64
+		if axis == 0 {
+			p = Newf64(end-start, m.c)
+			copy(p.vals, m.vals[start*m.c:end*m.c])
+		} else {
+			p = Newf64(m.r, end-start)
+			for i := 0; i < m.r; i++ {
+				copy(p.vals[i*p.c:(i+1)*p.c], m.vals[i*m.c+start:i*m.c+end])
+			}
+		}
+		parts = append(parts, p)
+		start = end
+	}
+	return parts
+}
+
+// splitPoints returns the indices at which n elements are split into k parts
+// of nearly equal sizes.
+func splitPoints(fn string, n, k int) []int {
+	if k < 1 || k > n {
+		s := "\nIn %s, cannot split %d elements in %d parts.\n"
+		s = fmt.Sprintf(s, fn, n, k)
+		printHelperErr(s)
+	}
+	points := make([]int, k-1)
+	x := 0
+	for i := range points {
+		x += n / k
+		if i < n%k {
+			x++
+		}
+		points[i] = x
+	}
+	return points
+}
+
+/*
+Batches splits the rows of the mat into consecutive batches of size rows,
+the last batch holding the remaining rows if the number of rows is not a
+multiple of size. It is meant for minibatch training and chunked exports:
+
+	for _, batch := range m.ShuffleRows(rng).Batches(32) {
+		train(batch)
+	}
+
+No values are copied: just as with CloneCOW, each batch shares its values
+with m until either of them is modified, at which point the modified mat
+gets its own copy. size must be at least 1.
+*/
+func (m *Matf64) Batches(size int) []*Matf64 {
+	if size < 1 {
+		s := "\nIn %s, the size of the batches must be at least 1, but %d "
+		s += "was received.\n"
+		s = fmt.Sprintf(s, "Batches()", size)
+		printErr(s)
+	}
+	batches := make([]*Matf64, 0, (m.r+size-1)/size)
+	for start := 0; start < m.r; start += size {
+		end := start + size
+		if end > m.r {
+			end = m.r
+		}
+		b := m.CloneCOW()
+		b.r = end - start
+		b.vals = m.vals[start*m.c : end*m.c : end*m.c]
+		batches = append(batches, b)
+	}
+	return batches
+}
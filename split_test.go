@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVSplitf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(10, 3)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	parts := m.VSplit(3)
+	assert.Equal(t, 3, len(parts), "should be equal")
+	assert.Equal(t, 4, parts[0].r, "should be equal")
+	assert.Equal(t, 3, parts[1].r, "should be equal")
+	assert.Equal(t, 3, parts[2].r, "should be equal")
+	n := parts[0].Copy().Append(parts[1]).Append(parts[2])
+	assert.Equal(t, m.vals, n.vals, "should give back the original values")
+	assert.Equal(t, 1, len(m.VSplit(1)), "should be equal")
+}
+
+func TestHSplitf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}})
+	parts := m.HSplit(2)
+	assert.True(t, Matf64FromData([][]float64{{1, 2}, {5, 6}}).Equals(parts[0]), "should be equal")
+	assert.True(t, Matf64FromData([][]float64{{3, 4}, {7, 8}}).Equals(parts[1]), "should be equal")
+	assert.True(t, m.Equals(parts[0].Concat(parts[1])), "should give back the original")
+}
+
+func TestSplitAtf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3, 4}, {5, 6, 7, 8}})
+	parts := m.SplitAt([]int{1, 1, 3}, 1)
+	assert.Equal(t, 4, len(parts), "should be equal")
+	assert.Equal(t, []float64{1, 5}, parts[0].vals, "should be equal")
+	assert.Equal(t, 0, parts[1].c, "should be empty")
+	assert.Equal(t, 2, parts[1].r, "should keep the rows")
+	assert.Equal(t, []float64{2, 3, 6, 7}, parts[2].vals, "should be equal")
+	assert.Equal(t, []float64{4, 8}, parts[3].vals, "should be equal")
+	parts = m.SplitAt([]int{0}, 0)
+	assert.Equal(t, 0, parts[0].r, "should be empty")
+	assert.True(t, m.Equals(parts[1]), "should be equal")
+}
+
+func TestBatchesf64(t *testing.T) {
+	t.Helper()
+	m := Newf64(7, 2)
+	for i := range m.vals {
+		m.vals[i] = float64(i)
+	}
+	batches := m.Batches(3)
+	assert.Equal(t, 3, len(batches), "should be equal")
+	assert.Equal(t, 3, batches[0].r, "should be equal")
+	assert.Equal(t, 1, batches[2].r, "should hold the remaining rows")
+	assert.Equal(t, []float64{6, 7, 8, 9, 10, 11}, batches[1].vals, "should be equal")
+	assert.Equal(t, []float64{12, 13}, batches[2].vals, "should be equal")
+
+	batches[1].Set(0, 0, -1)
+	assert.Equal(t, 6.0, m.Get(3, 0), "should not modify the original")
+	m.Set(6, 0, -2)
+	assert.Equal(t, 12.0, batches[2].Get(0, 0), "should not modify the batches")
+	assert.Equal(t, 4.0, batches[0].Get(2, 0), "should be equal")
+	assert.Equal(t, 0, len(Newf64(0, 2).Batches(4)), "should be empty")
+}
@@ -0,0 +1,108 @@
+package matrix
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+Matf64FromSQLRows reads the result of a database query into a mat, with one
+row per result row, and returns it along with the names of the loaded
+columns. For example:
+
+	rows, err := db.Query("SELECT id, label, x, y FROM samples")
+	...
+	m, names := matrix.Matf64FromSQLRows(rows) // names is [id x y]
+
+A column is loaded if its value in the first row is numerical, that is an
+integer, a float, a bool (as 0 or 1), a NULL, or text that can be converted
+to a float64. Other columns, such as labels or timestamps, are skipped. In the
+loaded columns, NULLs and values that cannot be converted are set to NaN.
+
+All the rows are read, and rows is closed before returning.
+*/
+func Matf64FromSQLRows(rows *sql.Rows) (*Matf64, []string) {
+	defer rows.Close()
+	names, err := rows.Columns()
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read the columns due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromSQLRows()", err)
+		printErr(s)
+	}
+	vals := make([]interface{}, len(names))
+	ptrs := make([]interface{}, len(names))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	m := Newf64()
+	var cols []int
+	var loaded []string
+	for rows.Next() {
+		if err = rows.Scan(ptrs...); err != nil {
+			break
+		}
+		if cols == nil {
+			cols = make([]int, 0, len(names))
+			for i := range vals {
+				if _, ok := sqlFloat64(vals[i]); ok {
+					cols = append(cols, i)
+					loaded = append(loaded, names[i])
+				}
+			}
+			m.c = len(cols)
+		}
+		for _, i := range cols {
+			v, _ := sqlFloat64(vals[i])
+			m.vals = append(m.vals, v)
+		}
+		m.r++
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	if err != nil {
+		s := "\nIn matrix.%s, cannot read the rows due to error: %v.\n"
+		s = fmt.Sprintf(s, "Matf64FromSQLRows()", err)
+		printErr(s)
+	}
+	if cols == nil {
+		// Without any row, there is no way to tell which columns are numerical.
+		loaded = []string{}
+	}
+	return m, loaded
+}
+
+// sqlFloat64 converts a value scanned from a database to a float64. It
+// returns NaN and true for NULLs, and NaN and false for values which are
+// not numerical.
+func sqlFloat64(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case nil:
+		return math.NaN(), true
+	case int64:
+		return float64(x), true
+	case float64:
+		return x, true
+	case bool:
+		if x {
+			return 1.0, true
+		}
+		return 0.0, true
+	case []byte:
+		return sqlParseFloat(string(x))
+	case string:
+		return sqlParseFloat(x)
+	}
+	return math.NaN(), false
+}
+
+func sqlParseFloat(s string) (float64, bool) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return math.NaN(), false
+	}
+	return f, true
+}
@@ -0,0 +1,70 @@
+package matrix
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDriver is a database/sql driver whose queries always return the same
+// fixed rows, regardless of the query.
+type testDriver struct{}
+
+type testConn struct{}
+
+type testStmt struct{}
+
+type testRows struct {
+	i int
+}
+
+var testRowsData = [][]driver.Value{
+	{int64(1), "ann", 1.5, time.Now(), []byte("60")},
+	{int64(2), "bob", nil, time.Now(), []byte("n/a")},
+	{int64(3), "cid", 1.75, time.Now(), []byte("72.5")},
+}
+
+func (testDriver) Open(string) (driver.Conn, error)         { return testConn{}, nil }
+func (testConn) Prepare(string) (driver.Stmt, error)        { return testStmt{}, nil }
+func (testConn) Close() error                               { return nil }
+func (testConn) Begin() (driver.Tx, error)                  { return nil, io.EOF }
+func (testStmt) Close() error                               { return nil }
+func (testStmt) NumInput() int                              { return 0 }
+func (testStmt) Exec([]driver.Value) (driver.Result, error) { return nil, io.EOF }
+func (testStmt) Query([]driver.Value) (driver.Rows, error)  { return &testRows{}, nil }
+func (*testRows) Columns() []string                         { return []string{"id", "name", "x", "at", "y"} }
+func (*testRows) Close() error                              { return nil }
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.i == len(testRowsData) {
+		return io.EOF
+	}
+	copy(dest, testRowsData[r.i])
+	r.i++
+	return nil
+}
+
+func init() {
+	sql.Register("matrix_test", testDriver{})
+}
+
+func TestMatf64FromSQLRows(t *testing.T) {
+	t.Helper()
+	db, err := sql.Open("matrix_test", "")
+	assert.Nil(t, err, "should not fail")
+	defer db.Close()
+	rows, err := db.Query("SELECT * FROM samples")
+	assert.Nil(t, err, "should not fail")
+	m, names := Matf64FromSQLRows(rows)
+	assert.Equal(t, []string{"id", "x", "y"}, names, "should skip text and times")
+	assert.Equal(t, 3, m.r, "should be equal")
+	assert.Equal(t, 3, m.c, "should be equal")
+	assert.Equal(t, []float64{1, 1.5, 60}, m.Row(0).vals, "should be equal")
+	assert.True(t, math.IsNaN(m.Get(1, 1)), "NULL should be NaN")
+	assert.True(t, math.IsNaN(m.Get(1, 2)), "should be NaN")
+	assert.Equal(t, 72.5, m.Get(2, 2), "should be equal")
+}
@@ -0,0 +1,318 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Median returns the median of the elements of a Matf64. It can be called in
+one of two ways:
+
+	m.Median()
+
+This will return the median of all elements in m. This method can also be
+called by passing 2 integers: 0 or 1 for row or column, and another int
+specifying the row or column. For example:
+
+	m.Median(0, 2) // Returns the median of the 3rd row
+	m.Median(1, 0) // Returns the median of the first column.
+
+When there is an even number of elements, the median is the mean of the two
+middle ones. If any of the elements is NaN, the median is NaN. The median is
+found by selection rather than by sorting, so it takes linear time on
+average, and the mat is left intact.
+*/
+func (m *Matf64) Median(args ...int) float64 {
+	v := m.axisCopy("Median()", args)
+	for _, x := range v {
+		if math.IsNaN(x) {
+			return math.NaN()
+		}
+	}
+	return median(v)
+}
+
+// median returns the median of v, reordering its elements in the process.
+func median(v []float64) float64 {
+	n := len(v)
+	if n == 0 {
+		return math.NaN()
+	}
+	hi := selectKth(v, n/2)
+	if n%2 == 1 {
+		return hi
+	}
+	// After selection, the elements before n/2 are all less than or equal
+	// to hi, so the other middle element is their maximum.
+	lo := v[0]
+	for _, x := range v[1 : n/2] {
+		if x > lo {
+			lo = x
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// selectKth reorders v so that v[k] is the element that would be at index k
+// if v was sorted, with no larger element before it and no smaller element
+// after it, and returns v[k]. v must not hold NaNs.
+func selectKth(v []float64, k int) float64 {
+	lo, hi := 0, len(v)-1
+	for lo < hi {
+		// The median of three makes sorted inputs as fast as random ones.
+		mid := lo + (hi-lo)/2
+		if v[mid] < v[lo] {
+			v[mid], v[lo] = v[lo], v[mid]
+		}
+		if v[hi] < v[lo] {
+			v[hi], v[lo] = v[lo], v[hi]
+		}
+		if v[hi] < v[mid] {
+			v[hi], v[mid] = v[mid], v[hi]
+		}
+		pivot := v[mid]
+		i, j := lo, hi
+		for i <= j {
+			for v[i] < pivot {
+				i++
+			}
+			for v[j] > pivot {
+				j--
+			}
+			if i <= j {
+				v[i], v[j] = v[j], v[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return v[k]
+		}
+	}
+	return v[k]
+}
+
+// axisCopy returns a copy of the elements selected by the axis arguments of
+// the statistics methods: all of them, a row, or a column.
+func (m *Matf64) axisCopy(fn string, args []int) []float64 {
+	switch len(args) {
+	case 0:
+		v := make([]float64, len(m.vals))
+		copy(v, m.vals)
+		return v
+	case 2:
+		axis, slice := args[0], args[1]
+		switch axis {
+		case 0:
+			slice = normIndex(fn, "row", slice, m.r)
+			v := make([]float64, m.c)
+			copy(v, m.vals[slice*m.c:(slice+1)*m.c])
+			return v
+		case 1:
+			slice = normIndex(fn, "column", slice, m.c)
+			v := make([]float64, m.r)
+			for i := range v {
+				v[i] = m.vals[i*m.c+slice]
+			}
+			return v
+		default:
+			s := "\nIn %s, the first argument must be 0 or 1, however %d "
+			s += "was received.\n"
+			s = fmt.Sprintf(s, fn, axis)
+			printHelperErr(s)
+		}
+	default:
+		s := "\nIn %s, 0 or 2 arguments expected, but %d was received.\n"
+		s = fmt.Sprintf(s, fn, len(args))
+		printHelperErr(s)
+	}
+	return nil
+}
+
+/*
+Var returns the variance of the elements of a Matf64, which is the sum of the
+squared differences to the mean divided by n-ddof, where n is the number of
+elements. ddof is the "delta degrees of freedom": 0 gives the population
+variance, and 1 gives the unbiased sample variance. The axis arguments
+follow the same rules as in Median:
+
+	m.Var(0)       // Returns the population variance of all elements
+	m.Var(1, 0, 2) // Returns the sample variance of the 3rd row
+	m.Var(1, 1, 0) // Returns the sample variance of the first column
+
+ddof cannot be negative. If it is not less than n, the variance is NaN.
+*/
+func (m *Matf64) Var(ddof int, args ...int) float64 {
+	if ddof < 0 {
+		s := "\nIn %s, ddof cannot be negative, but %d was received.\n"
+		s = fmt.Sprintf(s, "Var()", ddof)
+		printErr(s)
+	}
+	v := m.axisCopy("Var()", args)
+	n := len(v)
+	if ddof >= n {
+		return math.NaN()
+	}
+	avg := 0.0
+	for _, x := range v {
+		avg += x
+	}
+	avg /= float64(n)
+	sum := 0.0
+	for _, x := range v {
+		sum += (x - avg) * (x - avg)
+	}
+	return sum / float64(n-ddof)
+}
+
+/*
+Interpolation selects how Percentile computes a percentile that falls
+between two elements.
+*/
+type Interpolation int
+
+const (
+	// InterpLinear interpolates linearly between the two elements.
+	InterpLinear Interpolation = iota
+	// InterpNearest takes the nearest element, and the even one on ties.
+	InterpNearest
+	// InterpLower takes the smaller element.
+	InterpLower
+	// InterpUpper takes the larger element.
+	InterpUpper
+)
+
+/*
+Percentile returns the pth percentile of the elements of a Matf64, where p is
+between 0 and 100, and the axis arguments follow the same rules as in
+Median. For example:
+
+	m.Percentile(99, matrix.InterpLinear)         // 99th percentile of m
+	m.Percentile(25, matrix.InterpLower, 1, 3)    // first quartile of the 4th column
+
+The percentile is located at rank (n-1)*p/100 among the n sorted elements,
+and interp decides what to do when that rank is not an integer. This
+matches the definition used by NumPy. If any of the elements is NaN, the
+percentile is NaN. The mat is left intact.
+*/
+func (m *Matf64) Percentile(p float64, interp Interpolation, args ...int) float64 {
+	if !(p >= 0 && p <= 100) {
+		s := "\nIn %s, the percentile must be between 0 and 100, but %f was "
+		s += "received.\n"
+		s = fmt.Sprintf(s, "Percentile()", p)
+		printErr(s)
+	}
+	if interp < InterpLinear || interp > InterpUpper {
+		s := "\nIn %s, %d is not a valid interpolation.\n"
+		s = fmt.Sprintf(s, "Percentile()", interp)
+		printErr(s)
+	}
+	v := m.axisCopy("Percentile()", args)
+	if len(v) == 0 {
+		return math.NaN()
+	}
+	for _, x := range v {
+		if math.IsNaN(x) {
+			return math.NaN()
+		}
+	}
+	rank := float64(len(v)-1) * p / 100
+	lo := int(math.Floor(rank))
+	frac := rank - float64(lo)
+	if interp == InterpNearest {
+		if frac > 0.5 || (frac == 0.5 && lo%2 == 1) {
+			lo++
+		}
+		frac = 0
+	}
+	if interp == InterpLower {
+		frac = 0
+	}
+	if interp == InterpUpper && frac > 0 {
+		lo++
+		frac = 0
+	}
+	x := selectKth(v, lo)
+	if frac == 0 {
+		return x
+	}
+	// After selection, the elements after lo are all greater than or equal
+	// to x, so the next element in order is their minimum.
+	next := v[lo+1]
+	for _, y := range v[lo+2:] {
+		if y < next {
+			next = y
+		}
+	}
+	return x + (next-x)*frac
+}
+
+/*
+TieBreak selects which value Mode returns when several values are equally
+frequent.
+*/
+type TieBreak int
+
+const (
+	// TieSmallest returns the smallest of the most frequent values.
+	TieSmallest TieBreak = iota
+	// TieLargest returns the largest of the most frequent values.
+	TieLargest
+	// TieFirst returns the most frequent value that appears first, in
+	// row-major order.
+	TieFirst
+)
+
+/*
+Mode returns the most frequent value among the elements of a Matf64, and the
+number of times it appears. ties decides which value to return when several
+are equally frequent, and the axis arguments follow the same rules as in
+Median. For example:
+
+	label, n := m.Mode(matrix.TieSmallest, 0, 2) // most frequent value of the 3rd row
+
+NaNs are ignored. If there is no other element, the mode is NaN and its
+count is 0. Values are compared exactly, so Mode is meant for labels and
+quantized data rather than continuous measurements.
+*/
+func (m *Matf64) Mode(ties TieBreak, args ...int) (float64, int) {
+	if ties < TieSmallest || ties > TieFirst {
+		s := "\nIn %s, %d is not a valid tie break.\n"
+		s = fmt.Sprintf(s, "Mode()", ties)
+		printErr(s)
+	}
+	v := m.axisCopy("Mode()", args)
+	counts := make(map[float64]int)
+	mode, count := math.NaN(), 0
+	for _, x := range v {
+		if math.IsNaN(x) {
+			continue
+		}
+		counts[x]++
+		n := counts[x]
+		switch {
+		case n > count:
+			mode, count = x, n
+		case n == count && ties == TieSmallest && x < mode:
+			mode = x
+		case n == count && ties == TieLargest && x > mode:
+			mode = x
+		}
+	}
+	if ties == TieFirst {
+		// The running mode above favors the value that reaches the count
+		// first, so find the first occurrence among the tied values.
+		for _, x := range v {
+			if counts[x] == count {
+				return x, count
+			}
+		}
+	}
+	return mode, count
+}
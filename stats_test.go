@@ -0,0 +1,117 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedianf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{5, 1, 4, 2},
+		{3, 3, 3, 9},
+		{-1, 7, 0, 8},
+	})
+	assert.Equal(t, 3.0, m.Median(), "should be equal")
+	assert.Equal(t, 3.0, m.Median(0, 0), "should be equal")
+	assert.Equal(t, 3.0, m.Median(0, 1), "should be equal")
+	assert.Equal(t, 3.5, m.Median(0, 2), "should be equal")
+	assert.Equal(t, 3.0, m.Median(1, 0), "should be equal")
+	assert.Equal(t, 8.0, m.Median(1, 3), "should be equal")
+	assert.Equal(t, 5.0, m.Get(0, 0), "should leave the mat intact")
+
+	m.Set(1, 1, math.NaN())
+	assert.True(t, math.IsNaN(m.Median()), "should be NaN")
+	assert.Equal(t, 3.0, m.Median(0, 0), "should be equal")
+}
+
+func TestSelectKth(t *testing.T) {
+	t.Helper()
+	for _, n := range []int{1, 2, 3, 10, 101, 1000} {
+		v := make([]float64, n)
+		for i := range v {
+			v[i] = float64(rand.Intn(n/2 + 1))
+		}
+		sorted := append([]float64(nil), v...)
+		sort.Float64s(sorted)
+		for _, k := range []int{0, n / 3, n / 2, n - 1} {
+			w := append([]float64(nil), v...)
+			assert.Equal(t, sorted[k], selectKth(w, k), "should be the kth smallest")
+			for i := 0; i < k; i++ {
+				assert.True(t, w[i] <= w[k], "smaller elements should come first")
+			}
+			for i := k + 1; i < n; i++ {
+				assert.True(t, w[i] >= w[k], "larger elements should come last")
+			}
+		}
+	}
+}
+
+func TestVarf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{2, 4, 4, 4},
+		{5, 5, 7, 9},
+	})
+	assert.Equal(t, 4.0, m.Var(0), "should be equal")
+	assert.InDelta(t, 32.0/7, m.Var(1), 1e-15, "should be equal")
+	assert.Equal(t, 0.75, m.Var(0, 0, 0), "should be equal")
+	assert.Equal(t, 1.0, m.Var(1, 0, 0), "should be equal")
+	assert.Equal(t, 2.25, m.Var(0, 1, 0), "should be equal")
+	assert.Equal(t, 4.5, m.Var(1, 1, 0), "should be equal")
+	assert.True(t, math.IsNaN(m.Var(2, 1, 0)), "should be NaN")
+	assert.Equal(t, math.Sqrt(m.Var(0, 0, 1)), m.Std(0, 1), "std should match var")
+	assert.Equal(t, math.Sqrt(m.Var(0, 1, 3)), m.Std(1, 3), "std should match var")
+}
+
+func TestPercentilef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{10, 1, 7, 4},
+		{2, 3, 5, 6},
+	})
+	// Sorted: 1 2 3 4 5 6 7 10
+	assert.Equal(t, 1.0, m.Percentile(0, InterpLinear), "should be the minimum")
+	assert.Equal(t, 10.0, m.Percentile(100, InterpLinear), "should be the maximum")
+	assert.Equal(t, 4.5, m.Percentile(50, InterpLinear), "should be the median")
+	assert.Equal(t, 4.0, m.Percentile(50, InterpLower), "should be equal")
+	assert.Equal(t, 5.0, m.Percentile(50, InterpUpper), "should be equal")
+	assert.Equal(t, 5.0, m.Percentile(50, InterpNearest), "should round to the even rank")
+	assert.InDelta(t, 7.9, m.Percentile(90, InterpLinear), 1e-12, "should be equal")
+	assert.Equal(t, 7.0, m.Percentile(90, InterpNearest), "should be equal")
+	assert.Equal(t, 10.0, m.Percentile(90, InterpUpper), "should be equal")
+	assert.Equal(t, 7.0, m.Percentile(90, InterpLower), "should be equal")
+	assert.Equal(t, 5.5, m.Percentile(50, InterpLinear, 0, 0), "should be equal")
+	assert.Equal(t, 6.0, m.Percentile(50, InterpLinear, 1, 2), "should be equal")
+	assert.Equal(t, 10.0, m.Get(0, 0), "should leave the mat intact")
+}
+
+func TestModef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{
+		{3, 1, 3, 1},
+		{2, 2, 5, math.NaN()},
+	})
+	mode, n := m.Mode(TieSmallest)
+	assert.Equal(t, 1.0, mode, "should be the smallest tied value")
+	assert.Equal(t, 2, n, "should be equal")
+	mode, _ = m.Mode(TieLargest)
+	assert.Equal(t, 3.0, mode, "should be the largest tied value")
+	mode, _ = m.Mode(TieFirst)
+	assert.Equal(t, 3.0, mode, "should be the first tied value")
+	mode, n = m.Mode(TieFirst, 0, 1)
+	assert.Equal(t, 2.0, mode, "should be equal")
+	assert.Equal(t, 2, n, "should be equal")
+	mode, n = m.Mode(TieSmallest, 1, 3)
+	assert.Equal(t, 1.0, mode, "should ignore NaNs")
+	assert.Equal(t, 1, n, "should ignore NaNs")
+
+	m = Matf64FromData([]float64{math.NaN()})
+	mode, n = m.Mode(TieSmallest)
+	assert.True(t, math.IsNaN(mode), "should be NaN")
+	assert.Equal(t, 0, n, "should be equal")
+}
@@ -0,0 +1,238 @@
+package matrix
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+/*
+TruncatedSVD returns the k largest singular values of the rXc mat m, in
+decreasing order, along with the matching left and right singular vectors,
+as the columns of u (rXk) and v (cXk). The best rank k approximation of m is
+then u*diag(s)*vᵀ:
+
+	u, s, v := m.TruncatedSVD(2)
+	approx := u.Dot(matrix.Diagf64(s)).DotT(v)
+
+The singular vectors are found by subspace iteration, which only ever
+multiplies m and its transpose by blocks of k+10 vectors, so the full
+decomposition is never computed. The start of the iteration is fixed, so the
+result is reproducible. k must be between 1 and the smaller of r and c. The
+mat is left intact.
+*/
+func (m *Matf64) TruncatedSVD(k int) (u *Matf64, s []float64, v *Matf64) {
+	l := svdBlock("TruncatedSVD()", m, k, 10)
+	q := RandnMatf64With(rand.New(rand.NewSource(1)), m.c, l)
+	var prev []float64
+	report := progressFunc()
+	for iter := 0; iter < 1000; iter++ {
+		y := m.Dot(q)
+		orthonormalize(y)
+		u, s, q = svdInRange(m, y)
+		if report != nil {
+			report("TruncatedSVD()", iter+1, -1)
+		}
+		if prev != nil && svdConverged(prev[:k], s[:k]) {
+			break
+		}
+		prev = s
+	}
+	return truncateSVD(u, s, q, k)
+}
+
+/*
+RandomizedSVD is an approximate TruncatedSVD for very large, and especially
+very tall, mats. It projects m onto k+10 random directions drawn from rng (or
+from the package source if rng is nil, see SetRandSource), refines the
+projection with the given number of power iterations, and decomposes m
+within the resulting subspace. This costs a fixed number of products of m
+with blocks of k+10 vectors, rather than iterating until convergence. When
+the singular values of m decay slowly, 1 or 2 power iterations improve the
+accuracy a lot. The mat is left intact.
+*/
+func (m *Matf64) RandomizedSVD(k, iters int, rng *rand.Rand) (u *Matf64, s []float64, v *Matf64) {
+	l := svdBlock("RandomizedSVD()", m, k, 10)
+	if iters < 0 {
+		s := "\nIn %s, the number of power iterations cannot be negative, but\n"
+		s += "%d was received.\n"
+		s = fmt.Sprintf(s, "RandomizedSVD()", iters)
+		printErr(s)
+	}
+	y := m.Dot(RandnMatf64With(randOrDefault(rng), m.c, l))
+	orthonormalize(y)
+	report := progressFunc()
+	for i := 0; i < iters; i++ {
+		z := m.TDot(y)
+		orthonormalize(z)
+		y = m.Dot(z)
+		orthonormalize(y)
+		if report != nil {
+			report("RandomizedSVD()", i+1, iters)
+		}
+	}
+	u, s, v = svdInRange(m, y)
+	return truncateSVD(u, s, v, k)
+}
+
+// svdBlock checks the rank k asked of the SVD of m, and returns the number of
+// vectors to iterate on, which is k plus some oversampling.
+func svdBlock(fn string, m *Matf64, k, over int) int {
+	n := m.r
+	if m.c < n {
+		n = m.c
+	}
+	if k < 1 || k > n {
+		s := "\nIn %s, the rank must be between 1 and %d for a %dX%d mat,\n"
+		s += "but %d was received.\n"
+		s = fmt.Sprintf(s, fn, n, m.r, m.c, k)
+		printHelperErr(s)
+	}
+	if k+over < n {
+		return k + over
+	}
+	return n
+}
+
+// svdInRange returns the SVD of m restricted to the range of the orthonormal
+// columns of y, that is the SVD of y*yᵀ*m. Writing yᵀ*m = w*diag(s)*vᵀ gives
+// u = y*w.
+func svdInRange(m, y *Matf64) (*Matf64, []float64, *Matf64) {
+	v := m.TDot(y)
+	s, w := jacobiSVD(v)
+	return y.Dot(w), s, v
+}
+
+// svdConverged reports whether the singular values s are within rounding of
+// the ones of the previous iteration.
+func svdConverged(prev, s []float64) bool {
+	for i := range s {
+		if math.Abs(s[i]-prev[i]) > 1e-13*s[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// truncateSVD keeps the first k singular values and vectors.
+func truncateSVD(u *Matf64, s []float64, v *Matf64, k int) (*Matf64, []float64, *Matf64) {
+	return u.SplitAt([]int{k}, 1)[0], append([]float64(nil), s[:k]...), v.SplitAt([]int{k}, 1)[0]
+}
+
+// orthonormalize replaces the columns of m by an orthonormal basis of their
+// span, with two passes of modified Gram-Schmidt. Columns that depend on the
+// previous ones are set to 0.
+func orthonormalize(m *Matf64) {
+	m.materialize()
+	for j := 0; j < m.c; j++ {
+		norm0 := colNorm(m, j)
+		for pass := 0; pass < 2; pass++ {
+			for p := 0; p < j; p++ {
+				d := 0.0
+				for i := 0; i < m.r; i++ {
+					d += m.vals[i*m.c+p] * m.vals[i*m.c+j]
+				}
+				for i := 0; i < m.r; i++ {
+					m.vals[i*m.c+j] -= d * m.vals[i*m.c+p]
+				}
+			}
+		}
+		norm := colNorm(m, j)
+		scale := 0.0
+		if norm > 1e-12*norm0 {
+			scale = 1 / norm
+		}
+		for i := 0; i < m.r; i++ {
+			m.vals[i*m.c+j] *= scale
+		}
+	}
+}
+
+func colNorm(m *Matf64, j int) float64 {
+	n := 0.0
+	for i := 0; i < m.r; i++ {
+		n = math.Hypot(n, m.vals[i*m.c+j])
+	}
+	return n
+}
+
+// jacobiSVD computes the SVD x = v*diag(s)*wᵀ of the nXl mat x with the
+// one-sided Jacobi method, which rotates pairs of columns of x until they are
+// orthogonal. x is replaced by v, whose columns for zero singular values are
+// 0, and the singular values are sorted in decreasing order.
+func jacobiSVD(x *Matf64) ([]float64, *Matf64) {
+	l := x.c
+	w := Identityf64(l)
+	for sweep := 0; sweep < 60; sweep++ {
+		rotated := false
+		for p := 0; p < l-1; p++ {
+			for q := p + 1; q < l; q++ {
+				var alpha, beta, gamma float64
+				for i := 0; i < x.r; i++ {
+					a, b := x.vals[i*l+p], x.vals[i*l+q]
+					alpha += a * a
+					beta += b * b
+					gamma += a * b
+				}
+				if gamma == 0 || math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+				rotated = true
+				zeta := (beta - alpha) / (2 * gamma)
+				t := 1 / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				if zeta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(1+t*t)
+				sn := c * t
+				rotateCols(x, p, q, c, sn)
+				rotateCols(w, p, q, c, sn)
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+	s := make([]float64, l)
+	for j := range s {
+		s[j] = colNorm(x, j)
+		scale := 0.0
+		if s[j] > 0 {
+			scale = 1 / s[j]
+		}
+		for i := 0; i < x.r; i++ {
+			x.vals[i*l+j] *= scale
+		}
+	}
+	// Selection sort of the columns by decreasing singular value, since l
+	// is small.
+	for j := range s {
+		k := j
+		for i := j + 1; i < l; i++ {
+			if s[i] > s[k] {
+				k = i
+			}
+		}
+		if k != j {
+			s[j], s[k] = s[k], s[j]
+			swapCols(x, j, k)
+			swapCols(w, j, k)
+		}
+	}
+	return s, w
+}
+
+// rotateCols applies a Givens rotation to the columns p and q of m.
+func rotateCols(m *Matf64, p, q int, c, s float64) {
+	for i := 0; i < m.r; i++ {
+		a, b := m.vals[i*m.c+p], m.vals[i*m.c+q]
+		m.vals[i*m.c+p] = c*a - s*b
+		m.vals[i*m.c+q] = s*a + c*b
+	}
+}
+
+func swapCols(m *Matf64, p, q int) {
+	for i := 0; i < m.r; i++ {
+		m.vals[i*m.c+p], m.vals[i*m.c+q] = m.vals[i*m.c+q], m.vals[i*m.c+p]
+	}
+}
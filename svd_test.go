@@ -0,0 +1,56 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// svdTestMat returns a rXc mat with the given singular values.
+func svdTestMat(rng *rand.Rand, r, c int, s []float64) *Matf64 {
+	u := RandnMatf64With(rng, r, len(s))
+	v := RandnMatf64With(rng, c, len(s))
+	orthonormalize(u)
+	orthonormalize(v)
+	return u.Dot(Diagf64(s)).DotT(v)
+}
+
+func TestTruncatedSVDf64(t *testing.T) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(7))
+	s := []float64{10, 5, 3, 1, 0.5, 0.1}
+	m := svdTestMat(rng, 30, 8, s)
+	orig := m.Copy()
+	u, got, v := m.TruncatedSVD(3)
+	assert.True(t, orig.Equals(m), "should be left intact")
+	assert.InDeltaSlice(t, s[:3], got, 1e-9, "should be equal")
+	r, c := u.Shape()
+	assert.Equal(t, []int{30, 3}, []int{r, c}, "should be equal")
+	r, c = v.Shape()
+	assert.Equal(t, []int{8, 3}, []int{r, c}, "should be equal")
+	// The singular vectors are orthonormal, and m*v = u*diag(s).
+	assert.InDeltaSlice(t, Identityf64(3).vals, u.TDot(u).vals, 1e-9, "should be equal")
+	assert.InDeltaSlice(t, Identityf64(3).vals, v.TDot(v).vals, 1e-9, "should be equal")
+	assert.InDeltaSlice(t, u.Dot(Diagf64(got)).vals, m.Dot(v).vals, 1e-9, "should be equal")
+
+	// A full rank decomposition gives back the mat.
+	m = Matf64FromData([][]float64{{3, 0}, {4, 5}})
+	u, got, v = m.TruncatedSVD(2)
+	assert.InDeltaSlice(t, []float64{3 * 1.4142135623730951 * 1.5811388300841898, 1.4142135623730951 * 1.5811388300841898}, got, 1e-12, "should be equal")
+	assert.InDeltaSlice(t, m.vals, u.Dot(Diagf64(got)).DotT(v).vals, 1e-12, "should be equal")
+}
+
+func TestRandomizedSVDf64(t *testing.T) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(3))
+	s := []float64{8, 4, 2, 1, 0.5}
+	m := svdTestMat(rng, 500, 40, s)
+	u, got, v := m.RandomizedSVD(5, 0, rng)
+	assert.InDeltaSlice(t, s, got, 1e-9, "should be equal")
+	assert.InDeltaSlice(t, m.vals, u.Dot(Diagf64(got)).DotT(v).vals, 1e-9, "should be equal")
+
+	m = svdTestMat(rng, 300, 60, []float64{5, 4, 3, 2, 1, 0.9, 0.8, 0.7, 0.6, 0.5, 0.4, 0.3})
+	_, got, _ = m.RandomizedSVD(2, 2, rng)
+	assert.InDeltaSlice(t, []float64{5, 4}, got, 1e-3, "should be equal")
+}
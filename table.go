@@ -0,0 +1,141 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+/*
+ToMarkdown returns the mat as a GitHub flavored Markdown table, ready to be
+pasted into a report or a notebook. prec is the number of decimals of each
+value, or -1 for the shortest representation that parses back to the same
+value. The header holds the names of the columns, one per column of the mat.
+As Markdown tables must have a header, the columns are named c0, c1, ...
+when none is given. For example:
+
+	m.ToMarkdown(2, "x", "y")
+
+returns
+
+	| x | y |
+	|---:|---:|
+	| 1.00 | 2.50 |
+	| 3.00 | 4.25 |
+*/
+func (m *Matf64) ToMarkdown(prec int, header ...string) string {
+	header = m.tableHeader("ToMarkdown()", header)
+	if header == nil {
+		header = make([]string, m.c)
+		for j := range header {
+			header[j] = "c" + strconv.Itoa(j)
+		}
+	}
+	var b bytes.Buffer
+	b.WriteByte('|')
+	for _, h := range header {
+		b.WriteString(" " + strings.Replace(h, "|", `\|`, -1) + " |")
+	}
+	b.WriteString("\n|")
+	for range header {
+		b.WriteString("---:|")
+	}
+	b.WriteByte('\n')
+	for i := 0; i < m.r; i++ {
+		b.WriteByte('|')
+		for j := 0; j < m.c; j++ {
+			b.WriteString(" " + m.tableCell(i, j, prec) + " |")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+/*
+ToLaTeX returns the mat as a LaTeX tabular environment with right aligned
+columns. prec and header follow the same rules as in ToMarkdown, except that
+no header row is written when no header is given. The names of the columns
+are escaped, so that names such as "x_1" can be used as they are.
+*/
+func (m *Matf64) ToLaTeX(prec int, header ...string) string {
+	header = m.tableHeader("ToLaTeX()", header)
+	var b bytes.Buffer
+	b.WriteString("\\begin{tabular}{" + strings.Repeat("r", m.c) + "}\n")
+	if header != nil {
+		for j, h := range header {
+			if j != 0 {
+				b.WriteString(" & ")
+			}
+			b.WriteString(latexReplacer.Replace(h))
+		}
+		b.WriteString(" \\\\\n\\hline\n")
+	}
+	for i := 0; i < m.r; i++ {
+		for j := 0; j < m.c; j++ {
+			if j != 0 {
+				b.WriteString(" & ")
+			}
+			b.WriteString(m.tableCell(i, j, prec))
+		}
+		b.WriteString(" \\\\\n")
+	}
+	b.WriteString("\\end{tabular}\n")
+	return b.String()
+}
+
+/*
+ToHTMLTable returns the mat as an HTML table. prec and header follow the same
+rules as in ToLaTeX, the header, when given, being written as a thead
+element. The names of the columns are escaped.
+*/
+func (m *Matf64) ToHTMLTable(prec int, header ...string) string {
+	header = m.tableHeader("ToHTMLTable()", header)
+	var b bytes.Buffer
+	b.WriteString("<table>\n")
+	if header != nil {
+		b.WriteString("<thead>\n<tr>")
+		for _, h := range header {
+			b.WriteString("<th>" + html.EscapeString(h) + "</th>")
+		}
+		b.WriteString("</tr>\n</thead>\n")
+	}
+	b.WriteString("<tbody>\n")
+	for i := 0; i < m.r; i++ {
+		b.WriteString("<tr>")
+		for j := 0; j < m.c; j++ {
+			b.WriteString("<td>" + m.tableCell(i, j, prec) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+	return b.String()
+}
+
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`,
+	"_", `\_`, "{", `\{`, "}", `\}`, "~", `\textasciitilde{}`,
+	"^", `\textasciicircum{}`,
+)
+
+// tableHeader checks that the header passed to one of the table functions
+// names every column, and returns nil when no header was passed.
+func (m *Matf64) tableHeader(fn string, header []string) []string {
+	if len(header) == 0 {
+		return nil
+	}
+	if len(header) != m.c {
+		s := "\nIn %s, the header has %d names, but the mat has %d columns.\n"
+		s = fmt.Sprintf(s, fn, len(header), m.c)
+		printHelperErr(s)
+	}
+	return header
+}
+
+func (m *Matf64) tableCell(i, j, prec int) string {
+	if prec < 0 {
+		return strconv.FormatFloat(m.vals[i*m.c+j], 'g', -1, 64)
+	}
+	return strconv.FormatFloat(m.vals[i*m.c+j], 'f', prec, 64)
+}
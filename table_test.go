@@ -0,0 +1,33 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMarkdownf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2.5}, {3, 4.25}})
+	want := "| x | y |\n|---:|---:|\n| 1.00 | 2.50 |\n| 3.00 | 4.25 |\n"
+	assert.Equal(t, want, m.ToMarkdown(2, "x", "y"), "should be equal")
+	want = "| c0 | c1 |\n|---:|---:|\n| 1 | 2.5 |\n| 3 | 4.25 |\n"
+	assert.Equal(t, want, m.ToMarkdown(-1), "should be equal")
+}
+
+func TestToLaTeXf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2.5}})
+	want := "\\begin{tabular}{rr}\nx\\_1 & 50\\% \\\\\n\\hline\n1.0 & 2.5 \\\\\n\\end{tabular}\n"
+	assert.Equal(t, want, m.ToLaTeX(1, "x_1", "50%"), "should be equal")
+	want = "\\begin{tabular}{rr}\n1 & 2.5 \\\\\n\\end{tabular}\n"
+	assert.Equal(t, want, m.ToLaTeX(-1), "should be equal")
+}
+
+func TestToHTMLTablef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}})
+	want := "<table>\n<thead>\n<tr><th>a&lt;b</th><th>c</th></tr>\n</thead>\n" +
+		"<tbody>\n<tr><td>1</td><td>2</td></tr>\n</tbody>\n</table>\n"
+	assert.Equal(t, want, m.ToHTMLTable(-1, "a<b", "c"), "should be equal")
+}
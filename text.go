@@ -0,0 +1,169 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+MarshalText implements the encoding.TextMarshaler interface. The mat is
+written with the same notation as MATLAB, where values are separated by
+spaces and rows by semicolons:
+
+	[1 2 3; 4 5 6]
+
+Values are formatted with the shortest representation that parses back to
+the exact same float64, so the text can be turned back into an identical
+mat by UnmarshalText. This makes it possible to use a Matf64 in any format
+that relies on these interfaces, such as YAML and TOML configuration files,
+or as a command line flag with flag.TextVar.
+
+An empty mat is written as [], except when only one of its dimensions is 0,
+which brackets cannot express. It is then written as MATLAB's mat2str does,
+for example zeros(0,3) for a mat with no rows and 3 columns.
+*/
+func (m *Matf64) MarshalText() ([]byte, error) {
+	if len(m.vals) == 0 && m.r+m.c != 0 {
+		return []byte(fmt.Sprintf("zeros(%d,%d)", m.r, m.c)), nil
+	}
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; i < m.r; i++ {
+		if i != 0 {
+			b.WriteString("; ")
+		}
+		for j := 0; j < m.c; j++ {
+			if j != 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strconv.FormatFloat(m.vals[i*m.c+j], 'g', -1, 64))
+		}
+	}
+	b.WriteByte(']')
+	return b.Bytes(), nil
+}
+
+/*
+UnmarshalText implements the encoding.TextUnmarshaler interface, and parses
+the format written by MarshalText. Values may also be separated by commas,
+and every row must have the same number of values.
+*/
+func (m *Matf64) UnmarshalText(text []byte) error {
+	rows, r, c, err := parseText("UnmarshalText()", string(text), 64)
+	if err != nil {
+		return err
+	}
+	*m = Matf64{r: r, c: c, vals: make([]float64, 0)}
+	if len(rows) != 0 {
+		*m = *matf64FromTwoDSliceHelper(rows, nil)
+	}
+	return nil
+}
+
+/*
+MarshalText implements the encoding.TextMarshaler interface, using the same
+format as Matf64.
+*/
+func (m *Matf32) MarshalText() ([]byte, error) {
+	if len(m.vals) == 0 && m.r+m.c != 0 {
+		return []byte(fmt.Sprintf("zeros(%d,%d)", m.r, m.c)), nil
+	}
+	var b bytes.Buffer
+	b.WriteByte('[')
+	for i := 0; i < m.r; i++ {
+		if i != 0 {
+			b.WriteString("; ")
+		}
+		for j := 0; j < m.c; j++ {
+			if j != 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strconv.FormatFloat(float64(m.vals[i*m.c+j]), 'g', -1, 32))
+		}
+	}
+	b.WriteByte(']')
+	return b.Bytes(), nil
+}
+
+/*
+UnmarshalText implements the encoding.TextUnmarshaler interface, and parses
+the format written by MarshalText.
+*/
+func (m *Matf32) UnmarshalText(text []byte) error {
+	rows, r, c, err := parseText("UnmarshalText()", string(text), 32)
+	if err != nil {
+		return err
+	}
+	*m = Matf32{r, c, make([]float32, 0)}
+	for _, row := range rows {
+		for _, v := range row {
+			m.vals = append(m.vals, float32(v))
+		}
+	}
+	if len(rows) != 0 {
+		m.r, m.c = len(rows), len(rows[0])
+	}
+	return nil
+}
+
+// parseText parses the text representation of a mat into its rows. For an
+// empty mat, no rows are returned, along with its shape.
+func parseText(fn, text string, bitSize int) ([][]float64, int, int, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "zeros(") && strings.HasSuffix(text, ")") {
+		r, c, err := parseEmptyShape(text[len("zeros(") : len(text)-1])
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("In %s: %v in %q", fn, err, text)
+		}
+		return nil, r, c, nil
+	}
+	if !strings.HasPrefix(text, "[") || !strings.HasSuffix(text, "]") {
+		return nil, 0, 0, fmt.Errorf("In %s: a mat must be enclosed in brackets, got %q", fn, text)
+	}
+	text = strings.TrimSpace(text[1 : len(text)-1])
+	if text == "" {
+		return nil, 0, 0, nil
+	}
+	lines := strings.Split(text, ";")
+	rows := make([][]float64, len(lines))
+	for i, line := range lines {
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+		})
+		if len(fields) == 0 || (i > 0 && len(fields) != len(rows[0])) {
+			return nil, 0, 0, fmt.Errorf("In %s: row %d has %d values, but row 0 has %d", fn, i, len(fields), len(rows[0]))
+		}
+		rows[i] = make([]float64, len(fields))
+		for j, f := range fields {
+			v, err := strconv.ParseFloat(f, bitSize)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("In %s: %v", fn, err)
+			}
+			rows[i][j] = v
+		}
+	}
+	return rows, len(rows), len(rows[0]), nil
+}
+
+// parseEmptyShape parses the "r,c" of zeros(r,c), which must have a
+// dimension of 0.
+func parseEmptyShape(text string) (int, int, error) {
+	dims := strings.Split(text, ",")
+	if len(dims) != 2 {
+		return 0, 0, fmt.Errorf("zeros takes 2 dimensions")
+	}
+	r, err := strconv.Atoi(strings.TrimSpace(dims[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	c, err := strconv.Atoi(strings.TrimSpace(dims[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	if r < 0 || c < 0 || (r != 0 && c != 0) {
+		return 0, 0, fmt.Errorf("only empty shapes can be written with zeros")
+	}
+	return r, c, nil
+}
@@ -0,0 +1,79 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 0.1, -3e-20}, {math.Inf(1), 5, 6}})
+	b, err := m.MarshalText()
+	assert.Nil(t, err, "should not fail")
+	assert.Equal(t, "[1 0.1 -3e-20; +Inf 5 6]", string(b), "should be equal")
+	n := Newf64()
+	assert.Nil(t, n.UnmarshalText(b), "should not fail")
+	assert.True(t, m.Equals(n), "should be equal")
+
+	m = RandMatf64(13, 4)
+	b, _ = m.MarshalText()
+	assert.Nil(t, n.UnmarshalText(b), "should not fail")
+	assert.True(t, m.Equals(n), "should round trip exactly")
+
+	assert.Nil(t, n.UnmarshalText([]byte(" [1, 2;\n 3, 4] ")), "should not fail")
+	assert.True(t, Matf64FromData([]float64{1, 2, 3, 4}, 2, 2).Equals(n), "should be equal")
+	assert.Nil(t, n.UnmarshalText([]byte("[]")), "should not fail")
+	assert.True(t, Newf64().Equals(n), "should be empty")
+
+	assert.NotNil(t, n.UnmarshalText([]byte("[1 2; 3]")), "should fail")
+	assert.NotNil(t, n.UnmarshalText([]byte("1 2")), "should fail")
+	assert.NotNil(t, n.UnmarshalText([]byte("[1 a]")), "should fail")
+}
+
+func TestTextf32(t *testing.T) {
+	t.Helper()
+	m := RandMatf32(3, 5)
+	b, err := m.MarshalText()
+	assert.Nil(t, err, "should not fail")
+	n := Newf32()
+	assert.Nil(t, n.UnmarshalText(b), "should not fail")
+	assert.True(t, m.Equals(n), "should round trip exactly")
+}
+
+func TestTextEmpty(t *testing.T) {
+	t.Helper()
+	tests := []struct {
+		r, c int
+		text string
+	}{
+		{0, 0, "[]"},
+		{3, 0, "zeros(3,0)"},
+		{0, 4, "zeros(0,4)"},
+	}
+	for _, test := range tests {
+		m := Newf64(test.r, test.c)
+		b, err := m.MarshalText()
+		assert.Nil(t, err, "should not fail")
+		assert.Equal(t, test.text, string(b), "should be equal")
+		n := Newf64(2)
+		assert.Nil(t, n.UnmarshalText(b), "should not fail")
+		r, c := n.Shape()
+		assert.Equal(t, []int{test.r, test.c}, []int{r, c}, "should keep the shape")
+
+		m32 := Newf32(test.r, test.c)
+		b, err = m32.MarshalText()
+		assert.Nil(t, err, "should not fail")
+		assert.Equal(t, test.text, string(b), "should be equal")
+		n32 := Newf32(2)
+		assert.Nil(t, n32.UnmarshalText(b), "should not fail")
+		r, c = n32.Shape()
+		assert.Equal(t, []int{test.r, test.c}, []int{r, c}, "should keep the shape")
+	}
+
+	n := Newf64()
+	assert.NotNil(t, n.UnmarshalText([]byte("zeros(2,3)")), "should fail on a non-empty shape")
+	assert.NotNil(t, n.UnmarshalText([]byte("zeros(-1,0)")), "should fail on a negative shape")
+	assert.NotNil(t, n.UnmarshalText([]byte("zeros(2)")), "should fail")
+}
@@ -0,0 +1,69 @@
+package matrix
+
+import (
+	"math"
+)
+
+/*
+UniqueRows returns a new mat holding each distinct row of m once, in the
+order of their first appearance, along with the index of the row of the new
+mat that each row of m maps to, so that row i of m is equal to row idx[i] of
+the result. Rows are compared exactly, except that 0 and -0 are equal, and
+so are all NaNs. The original mat is left intact.
+*/
+func (m *Matf64) UniqueRows() (*Matf64, []int) {
+	idx := make([]int, m.r)
+	seen := make(map[string]int)
+	u := Newf64(0, m.c)
+	for i := 0; i < m.r; i++ {
+		row := m.vals[i*m.c : (i+1)*m.c]
+		k := rowKey(row)
+		j, ok := seen[k]
+		if !ok {
+			j = u.r
+			seen[k] = j
+			u.AppendRow(row)
+		}
+		idx[i] = j
+	}
+	return u, idx
+}
+
+/*
+DuplicateRows returns the indices of the rows of m that are equal to an
+earlier row, in increasing order. Deleting these rows leaves the same rows
+as UniqueRows. Rows are compared as in UniqueRows.
+*/
+func (m *Matf64) DuplicateRows() []int {
+	var dup []int
+	seen := make(map[string]bool)
+	for i := 0; i < m.r; i++ {
+		k := rowKey(m.vals[i*m.c : (i+1)*m.c])
+		if seen[k] {
+			dup = append(dup, i)
+		}
+		seen[k] = true
+	}
+	return dup
+}
+
+// rowKey returns a string which is the same for two rows if and only if they
+// hold the same values, treating 0 and -0 as equal, and all NaNs as equal.
+func rowKey(row []float64) string {
+	b := make([]byte, 8*len(row))
+	for i, v := range row {
+		var bits uint64
+		switch {
+		case math.IsNaN(v):
+			bits = 0x7FF8000000000001
+		case v == 0:
+			bits = 0
+		default:
+			bits = math.Float64bits(v)
+		}
+		for k := 0; k < 8; k++ {
+			b[8*i+k] = byte(bits >> (8 * uint(k)))
+		}
+	}
+	return string(b)
+}
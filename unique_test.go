@@ -0,0 +1,29 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueRowsf64(t *testing.T) {
+	t.Helper()
+	nan := math.NaN()
+	m := Matf64FromData([][]float64{
+		{1, 2},
+		{3, nan},
+		{1, 2},
+		{0, 4},
+		{3, nan},
+		{math.Copysign(0, -1), 4},
+	})
+	u, idx := m.UniqueRows()
+	assert.Equal(t, 3, u.r, "should be equal")
+	assert.Equal(t, 2, u.c, "should be equal")
+	assert.Equal(t, []float64{1, 2}, u.Row(0).vals, "should be equal")
+	assert.Equal(t, []float64{0, 4}, u.Row(2).vals, "should be equal")
+	assert.Equal(t, []int{0, 1, 0, 2, 1, 2}, idx, "should be equal")
+	assert.Equal(t, []int{2, 4, 5}, m.DuplicateRows(), "should be equal")
+	assert.Nil(t, u.DuplicateRows(), "should have no duplicates")
+}
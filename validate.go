@@ -0,0 +1,151 @@
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// maxListed is the number of offending elements listed in the errors
+// returned by Validate.
+const maxListed = 10
+
+/*
+ValidateOpts lists the properties checked by Validate. The zero value checks
+nothing.
+*/
+type ValidateOpts struct {
+	// Finite requires every value to be neither NaN nor infinite.
+	Finite bool
+	// Rows and Cols require the mat to have the given number of rows and
+	// columns. 0 accepts any number.
+	Rows, Cols int
+	// Square requires the mat to have as many rows as columns.
+	Square bool
+	// Symmetric requires the mat to be square and equal to its transpose,
+	// within Tol.
+	Symmetric bool
+	// PositiveDefinite requires the mat to be symmetric, within Tol, and
+	// positive definite, which is checked with a Cholesky factorization.
+	PositiveDefinite bool
+	// Tol is the largest absolute difference between m[i][j] and m[j][i]
+	// tolerated by the symmetry checks.
+	Tol float64
+}
+
+/*
+Validate checks that the mat has the properties listed in opts, and returns
+an error describing every property it lacks, or nil. It is meant to guard
+the boundaries of an API accepting mats from its callers, which is why,
+unlike most functions of this package, it returns an error rather than
+exiting. For example:
+
+	err := m.Validate(matrix.ValidateOpts{Finite: true, Cols: 3})
+
+checks that m has 3 columns and no NaN or infinite values. The error lists
+the row and column of the offending values, up to 10 of each kind.
+*/
+func (m *Matf64) Validate(opts ValidateOpts) error {
+	var problems []string
+	if (opts.Rows > 0 && opts.Rows != m.r) || (opts.Cols > 0 && opts.Cols != m.c) {
+		want := fmt.Sprintf("%sX%s", shapeDim(opts.Rows), shapeDim(opts.Cols))
+		problems = append(problems, fmt.Sprintf("the mat is %dX%d instead of %s", m.r, m.c, want))
+	}
+	if opts.Finite {
+		var bad []int
+		n := 0
+		for i, v := range m.vals {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				if n < maxListed {
+					bad = append(bad, i)
+				}
+				n++
+			}
+		}
+		if n > 0 {
+			problems = append(problems, fmt.Sprintf("%d values are not finite, at %s", n, m.listIndices(bad, n)))
+		}
+	}
+	square := m.r == m.c
+	if (opts.Square || opts.Symmetric || opts.PositiveDefinite) && !square {
+		problems = append(problems, fmt.Sprintf("the mat is %dX%d, which is not square", m.r, m.c))
+	}
+	symmetric := square
+	if (opts.Symmetric || opts.PositiveDefinite) && square {
+		var bad []int
+		n := 0
+		for i := 0; i < m.r; i++ {
+			for j := i + 1; j < m.c; j++ {
+				if !(math.Abs(m.vals[i*m.c+j]-m.vals[j*m.c+i]) <= opts.Tol) {
+					if n < maxListed {
+						bad = append(bad, i*m.c+j)
+					}
+					n++
+				}
+			}
+		}
+		if n > 0 {
+			symmetric = false
+			problems = append(problems, fmt.Sprintf("%d values differ from their transpose, at %s", n, m.listIndices(bad, n)))
+		}
+	}
+	if opts.PositiveDefinite && symmetric {
+		if k := m.choleskyPivot(); k >= 0 {
+			problems = append(problems, fmt.Sprintf("the mat is not positive definite, the Cholesky factorization fails at column %d", k))
+		}
+	}
+	if len(problems) != 0 {
+		return fmt.Errorf("In %s: %s", "Validate()", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func shapeDim(n int) string {
+	if n <= 0 {
+		return "any"
+	}
+	return fmt.Sprint(n)
+}
+
+// listIndices formats the rows and columns of the values at the flat indices
+// idx, which are the first of n offending values.
+func (m *Matf64) listIndices(idx []int, n int) string {
+	var b bytes.Buffer
+	for k, i := range idx {
+		if k != 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "(%d, %d)", i/m.c, i%m.c)
+	}
+	if n > len(idx) {
+		fmt.Fprintf(&b, " and %d more", n-len(idx))
+	}
+	return b.String()
+}
+
+// choleskyPivot attempts the Cholesky factorization of the lower triangle of
+// the square mat, and returns the column at which it fails, which is the
+// first non positive pivot, or -1 if the mat is positive definite.
+func (m *Matf64) choleskyPivot() int {
+	n := m.r
+	l := make([]float64, n*n)
+	for j := 0; j < n; j++ {
+		d := m.vals[j*n+j]
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if !(d > 0) {
+			return j
+		}
+		l[j*n+j] = math.Sqrt(d)
+		for i := j + 1; i < n; i++ {
+			v := m.vals[i*n+j]
+			for k := 0; k < j; k++ {
+				v -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = v / l[j*n+j]
+		}
+	}
+	return -1
+}
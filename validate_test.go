@@ -0,0 +1,41 @@
+package matrix
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2, 3}, {4, 5, 6}})
+	assert.Nil(t, m.Validate(ValidateOpts{}), "should check nothing")
+	assert.Nil(t, m.Validate(ValidateOpts{Finite: true, Cols: 3}), "should be valid")
+
+	err := m.Validate(ValidateOpts{Rows: 3})
+	assert.EqualError(t, err, "In Validate(): the mat is 2X3 instead of 3Xany", "should be equal")
+
+	m.Set(0, 1, math.NaN()).Set(1, 2, math.Inf(-1))
+	err = m.Validate(ValidateOpts{Finite: true, Square: true})
+	assert.EqualError(t, err, "In Validate(): 2 values are not finite, at (0, 1), (1, 2); "+
+		"the mat is 2X3, which is not square", "should be equal")
+
+	m = Newf64(12, 12)
+	for i := 0; i < 12; i++ {
+		m.Set(i, 0, 1)
+	}
+	err = m.Validate(ValidateOpts{Symmetric: true})
+	assert.True(t, strings.HasSuffix(err.Error(), "(0, 10) and 1 more"), "should list 10 values")
+}
+
+func TestValidatePositiveDefinitef64(t *testing.T) {
+	t.Helper()
+	m := RandSPDf64(6, 100)
+	assert.Nil(t, m.Validate(ValidateOpts{PositiveDefinite: true, Tol: 1e-12}), "should be valid")
+	m = Matf64FromData([][]float64{{1, 2}, {2, 1}})
+	err := m.Validate(ValidateOpts{PositiveDefinite: true})
+	assert.EqualError(t, err, "In Validate(): the mat is not positive definite, the "+
+		"Cholesky factorization fails at column 1", "should be equal")
+}
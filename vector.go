@@ -0,0 +1,86 @@
+package matrix
+
+import (
+	"fmt"
+)
+
+/*
+IsVector returns true if the mat has a single row or a single column, i.e.
+if it is a row vector or a column vector. A 1X1 mat is both.
+*/
+func (m *Matf64) IsVector() bool {
+	return m.isRowVector() || m.isColVector()
+}
+
+/*
+Squeeze returns the elements of a row or column vector as a plain slice, so
+that the result of, say, m.Col(2) can be handed to code expecting a
+[]float64 without caring about the orientation of the vector. The returned
+slice is a copy. m must be a vector.
+*/
+func (m *Matf64) Squeeze() []float64 {
+	checkVector("Squeeze()", m)
+	v := make([]float64, len(m.vals))
+	copy(v, m.vals)
+	return v
+}
+
+/*
+Inner returns the dot product of two vectors as a float64, rather than as the
+1X1 mat that Dot would return. The vectors may each be a row or a column
+vector, so that for instance
+
+	m.Row(0).Inner(m.Col(0))
+
+works without transposing anything. Both mats must be vectors of the same
+length.
+*/
+func (m *Matf64) Inner(n *Matf64) float64 {
+	checkVector("Inner()", m)
+	checkVector("Inner()", n)
+	if len(m.vals) != len(n.vals) {
+		s := "\nIn %s, the vectors have lengths %d and %d. They must be equal.\n"
+		s = fmt.Sprintf(s, "Inner()", len(m.vals), len(n.vals))
+		printErr(s)
+	}
+	return dotf64Helper(m.vals, n.vals)
+}
+
+/*
+Outer returns the outer product of two vectors, a new mat with as many rows
+as m has elements and as many columns as n has elements, whose element at
+row i and column j is the product of the ith element of m and the jth
+element of n. As with Inner, the orientation of the vectors does not matter.
+*/
+func (m *Matf64) Outer(n *Matf64) *Matf64 {
+	checkVector("Outer()", m)
+	checkVector("Outer()", n)
+	o := Newf64(len(m.vals), len(n.vals))
+	for i, x := range m.vals {
+		row := o.vals[i*o.c : (i+1)*o.c]
+		for j, y := range n.vals {
+			row[j] = x * y
+		}
+	}
+	return o
+}
+
+/*
+Norm returns the Lp norm of the mat taken as a flat list of values. For a
+vector this is the usual vector norm, and for any other mat with p equal to
+2 it is the Frobenius norm. p follows the same rules as in NormalizeRows, so
+Norm(math.Inf(1)) returns the largest absolute value.
+*/
+func (m *Matf64) Norm(p float64) float64 {
+	checkNormOrder("Norm()", p)
+	return pNorm(m.vals, 1, p)
+}
+
+func checkVector(fn string, m *Matf64) {
+	if !m.IsVector() {
+		s := "\nIn %s, a row or column vector was expected, but a %dX%d mat "
+		s += "was received.\n"
+		s = fmt.Sprintf(s, fn, m.r, m.c)
+		printHelperErr(s)
+	}
+}
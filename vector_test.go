@@ -0,0 +1,51 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsVectorf64(t *testing.T) {
+	t.Helper()
+	assert.True(t, Newf64(1, 4).IsVector(), "row vector")
+	assert.True(t, Newf64(4, 1).IsVector(), "column vector")
+	assert.False(t, Newf64(2, 2).IsVector(), "not a vector")
+}
+
+func TestSqueezef64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	v := m.Col(1).Squeeze()
+	assert.Equal(t, []float64{2, 4}, v, "should be equal")
+	v[0] = 9
+	assert.Equal(t, 2.0, m.Get(0, 1), "should be a copy")
+}
+
+func TestInnerf64(t *testing.T) {
+	t.Helper()
+	m := Matf64FromData([][]float64{{1, 2}, {3, 4}})
+	assert.Equal(t, 7.0, m.Row(0).Inner(m.Col(0)), "should be equal")
+	assert.Equal(t, m.Row(1).Dot(m.Col(1)).Get(0, 0), m.Row(1).Inner(m.Col(1)),
+		"should match Dot")
+}
+
+func TestOuterf64(t *testing.T) {
+	t.Helper()
+	a := Matf64FromData([]float64{1, 2, 3})
+	b := Matf64FromData([]float64{4, 5})
+	want := Matf64FromData([][]float64{{4, 5}, {8, 10}, {12, 15}})
+	assert.True(t, want.Equals(a.Outer(b)), "should be equal")
+	assert.True(t, want.Equals(a.Copy().T().Outer(b)), "orientation should not matter")
+}
+
+func TestNormf64(t *testing.T) {
+	t.Helper()
+	v := Matf64FromData([]float64{3, -4})
+	assert.Equal(t, 5.0, v.Norm(2), "should be equal")
+	assert.Equal(t, 7.0, v.Norm(1), "should be equal")
+	assert.Equal(t, 4.0, v.Norm(math.Inf(1)), "should be equal")
+	m := Matf64FromData([][]float64{{1, 1}, {1, 1}})
+	assert.Equal(t, 2.0, m.Norm(2), "should be the Frobenius norm")
+}
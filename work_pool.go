@@ -1,5 +1,9 @@
 package matrix
 
+import (
+	"sync/atomic"
+)
+
 var (
 	f32Pool = newf32Pool()
 	f64Pool = newf64Pool()
@@ -10,7 +14,8 @@ type f32Bucket struct {
 }
 
 type matf32Pool struct {
-	pool chan *f32Bucket
+	stats poolCounters // First, so that the counters are 64-bit aligned.
+	pool  chan *f32Bucket
 }
 
 func newf32Pool() *matf32Pool {
@@ -23,7 +28,9 @@ func (p *matf32Pool) get() *f32Bucket {
 	var c *f32Bucket
 	select {
 	case c = <-p.pool:
+		p.stats.hit(4 * cap(c.vals))
 	default:
+		p.stats.miss()
 		c = &f32Bucket{
 			vals: make([]float32, 0),
 		}
@@ -32,10 +39,14 @@ func (p *matf32Pool) get() *f32Bucket {
 }
 
 func (p *matf32Pool) put(m *f32Bucket) {
+	n := 4 * cap(m.vals)
+	// The bytes are counted before the bucket is visible to get, so that
+	// the count never goes negative.
+	p.stats.add(n)
 	select {
 	case p.pool <- m:
 	default:
-		return
+		p.stats.add(-n)
 	}
 }
 
@@ -44,7 +55,8 @@ type f64Bucket struct {
 }
 
 type matf64Pool struct {
-	pool chan *f64Bucket
+	stats poolCounters // First, so that the counters are 64-bit aligned.
+	pool  chan *f64Bucket
 }
 
 func newf64Pool() *matf64Pool {
@@ -57,7 +69,9 @@ func (p *matf64Pool) get() *f64Bucket {
 	var c *f64Bucket
 	select {
 	case c = <-p.pool:
+		p.stats.hit(8 * cap(c.vals))
 	default:
+		p.stats.miss()
 		c = &f64Bucket{
 			vals: make([]float64, 0),
 		}
@@ -66,9 +80,60 @@ func (p *matf64Pool) get() *f64Bucket {
 }
 
 func (p *matf64Pool) put(m *f64Bucket) {
+	n := 8 * cap(m.vals)
+	// The bytes are counted before the bucket is visible to get, so that
+	// the count never goes negative.
+	p.stats.add(n)
 	select {
 	case p.pool <- m:
 	default:
-		return
+		p.stats.add(-n)
+	}
+}
+
+/*
+PoolStat holds statistics about the pools of scratch buffers that some
+methods, such as T, use to avoid allocating memory on every call.
+*/
+type PoolStat struct {
+	// Hits is the number of times a buffer was reused from a pool.
+	Hits int64
+	// Misses is the number of times a pool was empty, so that a new buffer
+	// had to be allocated.
+	Misses int64
+	// Bytes is the size of the buffers currently held by the pools.
+	Bytes int64
+}
+
+/*
+PoolStats returns the statistics of the scratch buffer pools of the package,
+for both Matf32 and Matf64 objects, since the start of the program. They
+help to evaluate how much memory the pools hold on to in a given workload,
+together with MemBytes.
+*/
+func PoolStats() PoolStat {
+	var s PoolStat
+	for _, c := range []*poolCounters{&f32Pool.stats, &f64Pool.stats} {
+		s.Hits += atomic.LoadInt64(&c.hits)
+		s.Misses += atomic.LoadInt64(&c.misses)
+		s.Bytes += atomic.LoadInt64(&c.bytes)
 	}
+	return s
+}
+
+type poolCounters struct {
+	hits, misses, bytes int64
+}
+
+func (c *poolCounters) hit(bytes int) {
+	atomic.AddInt64(&c.hits, 1)
+	atomic.AddInt64(&c.bytes, -int64(bytes))
+}
+
+func (c *poolCounters) miss() {
+	atomic.AddInt64(&c.misses, 1)
+}
+
+func (c *poolCounters) add(bytes int) {
+	atomic.AddInt64(&c.bytes, int64(bytes))
 }
@@ -0,0 +1,27 @@
+package matrix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolStats(t *testing.T) {
+	t.Helper()
+	m := RandMatf64(30, 20)
+	before := PoolStats()
+	m.T()
+	m.T()
+	after := PoolStats()
+	assert.Equal(t, int64(2), after.Hits+after.Misses-before.Hits-before.Misses,
+		"should count every use of the pool")
+	assert.True(t, after.Bytes >= 8*600, "should hold the buffer")
+}
+
+func TestMemBytes(t *testing.T) {
+	t.Helper()
+	m := Newf64(3, 4)
+	assert.Equal(t, 8*cap(m.vals), m.MemBytes(), "should be equal")
+	assert.True(t, m.MemBytes() >= 8*12, "should hold every value")
+	assert.Equal(t, 4*cap(Newf32(2).vals), Newf32(2).MemBytes(), "should be equal")
+}